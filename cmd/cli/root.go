@@ -31,6 +31,9 @@ to execute workflows and manage integrations.`,
 	rootCmd.AddCommand(NewResetCommand(executorContainer))
 	rootCmd.AddCommand(NewStatusCommand(executorContainer))
 	rootCmd.AddCommand(NewWorkspacesCommand(executorContainer))
+	rootCmd.AddCommand(NewRunCommand(executorContainer))
+	rootCmd.AddCommand(NewTestCommand(executorContainer))
+	rootCmd.AddCommand(NewValidateCommand(executorContainer))
 
 	return rootCmd
 }
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/flowbaker/flowbaker/internal/initialization"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/local"
+	"github.com/spf13/cobra"
+)
+
+type runOptions struct {
+	WorkflowPath    string
+	InputPath       string
+	CredentialsPath string
+	TriggerNodeID   string
+	Output          string
+	DryRun          bool
+}
+
+const (
+	runOutputText = "text"
+	runOutputJSON = "json"
+)
+
+func NewRunCommand(executorContainer *initialization.ExecutorContainer) *cobra.Command {
+	options := runOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "run <workflow-file>",
+		Short: "Run a workflow locally without a workspace",
+		Long: `Run a workflow definition from a JSON or YAML file without connecting to the Flowbaker platform.
+Trigger input is read from --input (use "-" for stdin). Credentials are read from --credentials and from
+FLOWBAKER_CREDENTIAL_<ID> environment variables holding the credential payload as JSON.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.WorkflowPath = args[0]
+
+			return runRun(executorContainer, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.InputPath, "input", "i", "", `Trigger input file in JSON or YAML, "-" reads from stdin`)
+	cmd.Flags().StringVarP(&options.CredentialsPath, "credentials", "c", "", "Credentials file in JSON or YAML")
+	cmd.Flags().StringVarP(&options.TriggerNodeID, "trigger", "t", "", "ID of the trigger node to start from, required if the workflow has several triggers")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", runOutputText, "Output format of the execution history: text or json")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Run read actions and output the parameters of write actions instead of running them")
+
+	return cmd
+}
+
+func runRun(executorContainer *initialization.ExecutorContainer, options runOptions) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if options.Output != runOutputText && options.Output != runOutputJSON {
+		return fmt.Errorf("unsupported output format %q, use %s or %s", options.Output, runOutputText, runOutputJSON)
+	}
+
+	workflow, err := local.LoadWorkflow(options.WorkflowPath)
+	if err != nil {
+		return err
+	}
+
+	inputJSON, err := local.ReadInput(options.InputPath, os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	runner, err := newLocalRunner(ctx, executorContainer, options.CredentialsPath)
+	if err != nil {
+		return err
+	}
+
+	result, err := runner.Run(ctx, local.RunParams{
+		Workflow:      workflow,
+		TriggerNodeID: options.TriggerNodeID,
+		InputJSON:     inputJSON,
+		DryRun:        options.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if options.Output == runOutputJSON {
+		if err := printRunResultJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printRunResult(result)
+	}
+
+	if failedEntries := result.GetFailedEntries(); len(failedEntries) > 0 {
+		return fmt.Errorf("workflow failed at node %s: %s", failedEntries[0].NodeID, failedEntries[0].Error)
+	}
+
+	return nil
+}
+
+func newLocalRunner(ctx context.Context, executorContainer *initialization.ExecutorContainer, credentialsPath string) (*local.Runner, error) {
+	credentialManager, err := local.NewCredentialManager(local.NewCredentialManagerParams{
+		Path:    credentialsPath,
+		Environ: os.Environ(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := local.NewClient()
+
+	deps, err := executorContainer.BuildExecutorDependencies(ctx, initialization.ExecutorDependencyConfig{
+		FlowbakerClient:   client,
+		CredentialManager: credentialManager,
+		Config:            domain.ExecutorConfig{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build executor dependencies: %w", err)
+	}
+
+	return local.NewRunner(local.RunnerDependencies{
+		ExecutorService: deps.WorkflowExecutorService,
+		Client:          client,
+	}), nil
+}
+
+func printRunResult(result local.RunResult) {
+	fmt.Printf("Execution %s triggered by node %s\n\n", result.ExecutionID, result.TriggerNodeID)
+
+	for _, entry := range result.NodeExecutionResults {
+		switch entry.EventType {
+		case domain.NodeExecuted:
+			fmt.Printf("✅ #%d %s %s\n", entry.ExecutionOrder, entry.NodeID, formatItemCounts(entry.ItemsByOutputIndex))
+		case domain.NodeFailed:
+			fmt.Printf("❌ %s failed: %s\n", entry.NodeID, entry.Error)
+		case domain.NodeRetried:
+			fmt.Printf("🔁 %s attempt %d failed: %s\n", entry.NodeID, entry.Attempt, entry.Error)
+		case domain.NodeExecutionStarted:
+			fmt.Printf("⏸️  %s paused the execution\n", entry.NodeID)
+		}
+	}
+
+	if result.IsPaused {
+		fmt.Println("\nExecution paused, it cannot be resumed when running locally")
+	}
+
+	if len(result.Payload) > 0 {
+		fmt.Printf("\nResponse (%d): %s\n", result.StatusCode, string(result.Payload))
+	}
+}
+
+func formatItemCounts(itemsByOutputIndex domain.NodeItemsMap) string {
+	outputIndices := make([]int, 0, len(itemsByOutputIndex))
+	for outputIndex := range itemsByOutputIndex {
+		outputIndices = append(outputIndices, outputIndex)
+	}
+
+	sort.Ints(outputIndices)
+
+	counts := make([]string, 0, len(outputIndices))
+	for _, outputIndex := range outputIndices {
+		counts = append(counts, fmt.Sprintf("output %d: %d item(s)", outputIndex, len(itemsByOutputIndex[outputIndex].Items)))
+	}
+
+	if len(counts) == 0 {
+		return "(no output)"
+	}
+
+	return "(" + strings.Join(counts, ", ") + ")"
+}
+
+type runResultOutput struct {
+	ExecutionID   string                      `json:"execution_id"`
+	TriggerNodeID string                      `json:"trigger_node_id"`
+	IsPaused      bool                        `json:"is_paused"`
+	StatusCode    int                         `json:"status_code"`
+	Payload       json.RawMessage             `json:"payload,omitempty"`
+	History       []domain.NodeExecutionEntry `json:"history"`
+}
+
+func printRunResultJSON(result local.RunResult) error {
+	output := runResultOutput{
+		ExecutionID:   result.ExecutionID,
+		TriggerNodeID: result.TriggerNodeID,
+		IsPaused:      result.IsPaused,
+		StatusCode:    result.StatusCode,
+		History:       result.NodeExecutionResults,
+	}
+
+	if json.Valid(result.Payload) {
+		output.Payload = result.Payload
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(output)
+}
@@ -2,17 +2,22 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/charmbracelet/huh"
 	"github.com/flowbaker/flowbaker/internal/controllers"
 	"github.com/flowbaker/flowbaker/internal/initialization"
 	"github.com/flowbaker/flowbaker/internal/middlewares"
 	"github.com/flowbaker/flowbaker/internal/server"
+	"github.com/flowbaker/flowbaker/internal/tracing"
 	"github.com/flowbaker/flowbaker/internal/version"
 	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/logger"
@@ -20,20 +25,41 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	resumeModeAsk    = "ask"
+	resumeModeAlways = "always"
+	resumeModeNever  = "never"
+)
+
+// handOffTimeout is how long executions that are still running after the shutdown grace
+// period get to reach their next node and hand off their state.
+const handOffTimeout = 15 * time.Second
+
 func NewStartCommand(executorContainer *initialization.ExecutorContainer) *cobra.Command {
+	var resumeMode string
+
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start the executor (auto-setup if needed)",
-		Long:  `Start the executor service. If this is the first time running, it will automatically guide you through the setup process.`,
+		Long: `Start the executor service. If this is the first time running, it will automatically guide you through the setup process.
+Executions that were still running when the executor last stopped are offered for resuming from their last checkpoint.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStart(executorContainer)
+			switch resumeMode {
+			case resumeModeAsk, resumeModeAlways, resumeModeNever:
+			default:
+				return fmt.Errorf("invalid --resume value %q, expected ask, always or never", resumeMode)
+			}
+
+			return runStart(executorContainer, resumeMode)
 		},
 	}
 
+	cmd.Flags().StringVar(&resumeMode, "resume", resumeModeAsk, "Resume interrupted executions: ask, always or never")
+
 	return cmd
 }
 
-func runStart(executorContainer *initialization.ExecutorContainer) error {
+func runStart(executorContainer *initialization.ExecutorContainer, resumeMode string) error {
 	ctx := context.Background()
 	configManager := executorContainer.GetConfigManager()
 	workspaceRegistrationManager := executorContainer.GetWorkspaceRegistrationManager()
@@ -62,10 +88,10 @@ func runStart(executorContainer *initialization.ExecutorContainer) error {
 		}
 	}
 
-	return runExecutor(executorContainer)
+	return runExecutor(executorContainer, resumeMode)
 }
 
-func runExecutor(executorContainer *initialization.ExecutorContainer) error {
+func runExecutor(executorContainer *initialization.ExecutorContainer, resumeMode string) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
@@ -82,6 +108,23 @@ func runExecutor(executorContainer *initialization.ExecutorContainer) error {
 		Str("api_base_url", config.APIBaseURL).
 		Msg("Executor configuration loaded")
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.SetupParams{
+		Endpoint:   config.TracingEndpoint,
+		ExecutorID: config.ExecutorID,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+
+	defer func() {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelShutdown()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("Failed to flush traces")
+		}
+	}()
+
 	flowbakerClient := flowbaker.NewClient(
 		flowbaker.WithBaseURL(config.APIBaseURL),
 		flowbaker.WithExecutorID(config.ExecutorID),
@@ -91,14 +134,17 @@ func runExecutor(executorContainer *initialization.ExecutorContainer) error {
 	log.Info().Msg("Flowbaker client with signature-based auth ready")
 
 	deps, err := executorContainer.BuildExecutorDependencies(context.Background(), initialization.ExecutorDependencyConfig{
-		FlowbakerClient: flowbakerClient,
-		ExecutorID:      config.ExecutorID,
-		Config:          config,
+		FlowbakerClient:   flowbakerClient,
+		ExecutorID:        config.ExecutorID,
+		Config:            config,
+		EnableCheckpoints: config.EnableCheckpoints,
 	})
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to build executor dependencies")
 	}
 
+	resumeCheckpoints(ctx, deps, resumeMode)
+
 	var keyProvider middlewares.WorkspaceAPIKeyProvider
 	if config.SkipWorkspaceAssignments || config.StaticAPISignaturePublicKey != "" {
 		keyProvider = nil
@@ -112,23 +158,134 @@ func runExecutor(executorContainer *initialization.ExecutorContainer) error {
 	server := server.NewHTTPServer(context.Background(), server.HTTPServerDependencies{
 		Config:             config,
 		ExecutorController: deps.ExecutorController,
+		ExecutorService:    deps.WorkflowExecutorService,
 		KeyProvider:        keyProvider,
+		MetricsRegistry:    deps.MetricsRegistry,
 	})
 
 	shutdownChan := make(chan os.Signal, 1)
 	signal.Notify(shutdownChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// The server keeps running while the executor drains, so running executions can
+	// still be stopped and new ones are rejected with 503 instead of a refused connection.
+	serverCtx, stopServer := context.WithCancel(context.Background())
+	defer stopServer()
+
+	go func() {
+		<-ctx.Done()
+		defer stopServer()
+
+		gracePeriod := time.Duration(config.ShutdownGracePeriodSeconds) * time.Second
+
+		log.Info().Dur("grace_period", gracePeriod).Msg("Draining executions")
+
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), gracePeriod+handOffTimeout)
+		defer cancelDrain()
+
+		if err := deps.WorkflowExecutorService.Drain(drainCtx, gracePeriod); err != nil {
+			log.Error().Err(err).Msg("Failed to drain executions")
+		}
+	}()
+
 	if err := server.Listen(":8081", fiber.ListenConfig{
-		GracefulContext:       ctx,
+		GracefulContext:       serverCtx,
 		DisableStartupMessage: true,
 	}); err != nil {
 		log.Error().Err(err).Msg("HTTP server failed")
 	}
 
+	for _, sink := range deps.EventSinks {
+		if err := sink.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close event sink")
+		}
+	}
+
 	log.Info().Msg("Executor service stopped")
 	return nil
 }
 
+// resumeCheckpoints continues the executions that were interrupted when the executor
+// last stopped. In ask mode without a terminal the checkpoints are kept for a later
+// start, so an unattended restart never decides for the user.
+func resumeCheckpoints(ctx context.Context, deps *initialization.ExecutorDependencies, resumeMode string) {
+	if deps.CheckpointStore == nil {
+		return
+	}
+
+	checkpoints, err := deps.CheckpointStore.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list execution checkpoints")
+		return
+	}
+
+	if len(checkpoints) == 0 {
+		return
+	}
+
+	resume := resumeMode == resumeModeAlways
+
+	if resumeMode == resumeModeAsk {
+		if !isInteractive() {
+			log.Warn().Int("count", len(checkpoints)).Msg("Interrupted executions found, run 'start --resume always' to resume them")
+			return
+		}
+
+		var description strings.Builder
+		for _, checkpoint := range checkpoints {
+			fmt.Fprintf(&description, "%s (workflow %s, last saved %s)\n",
+				checkpoint.ExecutionID,
+				checkpoint.Workflow.Name,
+				checkpoint.UpdatedAt.Local().Format(time.DateTime),
+			)
+		}
+
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Resume %d interrupted execution(s)?", len(checkpoints))).
+					Description(description.String()).
+					Affirmative("Resume").
+					Negative("Discard").
+					Value(&resume),
+			),
+		)
+
+		if err := form.Run(); err != nil {
+			log.Error().Err(err).Msg("Failed to ask for resuming executions, keeping checkpoints")
+			return
+		}
+	}
+
+	for _, checkpoint := range checkpoints {
+		if !resume {
+			if err := deps.CheckpointStore.Delete(ctx, checkpoint.ExecutionID); err != nil {
+				log.Error().Err(err).Str("execution_id", checkpoint.ExecutionID).Msg("Failed to discard execution checkpoint")
+			}
+
+			continue
+		}
+
+		log.Info().Str("execution_id", checkpoint.ExecutionID).Str("workflow_id", checkpoint.Workflow.ID).Msg("Resuming interrupted execution")
+
+		// Resumed executions are drained on shutdown like the others, so they do not
+		// stop with the signal context.
+		go func(checkpoint executor.ExecutionCheckpoint) {
+			if _, err := deps.WorkflowExecutorService.Execute(context.WithoutCancel(ctx), checkpoint.ExecuteParams()); err != nil {
+				log.Error().Err(err).Str("execution_id", checkpoint.ExecutionID).Msg("Failed to resume execution")
+			}
+		}(checkpoint)
+	}
+}
+
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // startHealthCheckServer starts a minimal HTTP server with only the health check endpoint
 // This is used during the initial setup phase to allow API connectivity verification
 func startHealthCheckServer(ctx context.Context, executorContainer *initialization.ExecutorContainer) *fiber.App {
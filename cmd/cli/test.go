@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flowbaker/flowbaker/internal/initialization"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/local"
+	"github.com/flowbaker/flowbaker/pkg/workflowtest"
+	"github.com/spf13/cobra"
+)
+
+func NewTestCommand(executorContainer *initialization.ExecutorContainer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test <test-file>...",
+		Short: "Run workflow tests locally",
+		Long: `Run declarative workflow tests from JSON or YAML files. Each test executes the workflow locally with
+the listed nodes mocked and asserts on the outputs of the nodes. The command fails if any test fails.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTest(executorContainer, args)
+		},
+	}
+
+	return cmd
+}
+
+func runTest(executorContainer *initialization.ExecutorContainer, paths []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	passed, failed := 0, 0
+
+	for _, path := range paths {
+		results, err := runTestSuite(ctx, executorContainer, path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", path)
+
+		for _, result := range results {
+			if result.Passed() {
+				passed++
+				fmt.Printf("  ✅ %s\n", result.Name)
+				continue
+			}
+
+			failed++
+			fmt.Printf("  ❌ %s\n", result.Name)
+
+			if result.Err != nil {
+				fmt.Printf("     %v\n", result.Err)
+			}
+
+			for _, failure := range result.Failures {
+				fmt.Printf("     %s\n", failure)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", passed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d workflow test(s) failed", failed)
+	}
+
+	return nil
+}
+
+func runTestSuite(ctx context.Context, executorContainer *initialization.ExecutorContainer, path string) ([]workflowtest.CaseResult, error) {
+	suite, err := workflowtest.LoadSuite(path)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := local.LoadWorkflow(suite.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialManager, err := local.NewCredentialManager(local.NewCredentialManagerParams{
+		Path:    suite.Credentials,
+		Environ: os.Environ(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := local.NewClient()
+	selector := workflowtest.NewMockSelector(domain.NewIntegrationSelector())
+
+	deps, err := executorContainer.BuildExecutorDependencies(ctx, initialization.ExecutorDependencyConfig{
+		FlowbakerClient:     client,
+		CredentialManager:   credentialManager,
+		IntegrationSelector: selector,
+		Config:              domain.ExecutorConfig{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build executor dependencies: %w", err)
+	}
+
+	runner := workflowtest.NewRunner(workflowtest.RunnerDependencies{
+		Runner: local.NewRunner(local.RunnerDependencies{
+			ExecutorService: deps.WorkflowExecutorService,
+			Client:          client,
+		}),
+		Selector: selector,
+	})
+
+	return runner.RunSuite(ctx, workflow, suite), nil
+}
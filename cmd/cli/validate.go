@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flowbaker/flowbaker/internal/initialization"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+	"github.com/spf13/cobra"
+)
+
+func NewValidateCommand(executorContainer *initialization.ExecutorContainer) *cobra.Command {
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "validate <workflow-file>...",
+		Short: "Check workflows for problems before running them",
+		Long: `Check workflow definitions in JSON or YAML for problems the executor would hit while running them,
+such as edges to missing nodes or inputs, unknown integrations and actions, and missing required properties.
+The command fails if any workflow has errors, or warnings when --strict is set.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(executorContainer, args, strict)
+		},
+	}
+
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail on warnings as well as errors")
+
+	return cmd
+}
+
+func runValidate(executorContainer *initialization.ExecutorContainer, paths []string, strict bool) error {
+	ctx := context.Background()
+
+	deps, err := executorContainer.BuildExecutorDependencies(ctx, initialization.ExecutorDependencyConfig{
+		FlowbakerClient: local.NewClient(),
+		Config:          domain.ExecutorConfig{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build executor dependencies: %w", err)
+	}
+
+	errorCount, warningCount := 0, 0
+
+	for _, path := range paths {
+		workflow, err := local.LoadWorkflow(path)
+		if err != nil {
+			return err
+		}
+
+		problems := deps.WorkflowValidator.Validate(ctx, workflow)
+		if len(problems) == 0 {
+			fmt.Printf("✅ %s\n", path)
+			continue
+		}
+
+		fmt.Printf("%s\n", path)
+
+		for _, problem := range problems {
+			if problem.Severity == executor.ProblemSeverityError {
+				errorCount++
+				fmt.Printf("  ❌ %s\n", problem)
+			} else {
+				warningCount++
+				fmt.Printf("  ⚠️  %s\n", problem)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d error(s), %d warning(s)\n", errorCount, warningCount)
+
+	if errorCount > 0 || (strict && warningCount > 0) {
+		return fmt.Errorf("workflow validation failed")
+	}
+
+	return nil
+}
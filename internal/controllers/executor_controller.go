@@ -1,7 +1,11 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
 	executortypes "github.com/flowbaker/flowbaker/pkg/clients/flowbaker-executor"
 
@@ -12,24 +16,34 @@ import (
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
 )
 
 // ExecutorController handles API-initiated requests to executor services
 // This controller is used when the API needs to send commands to executors
 type ExecutorController struct {
 	executorService              executor.WorkflowExecutorService
+	workflowValidator            *executor.WorkflowValidator
 	workspaceRegistrationManager domain.WorkspaceRegistrationManager
+	historyStore                 executor.ExecutionHistoryStore
+	payloadStore                 executor.PayloadStore
 }
 
 type ExecutorControllerDependencies struct {
 	WorkflowExecutorService      executor.WorkflowExecutorService
+	WorkflowValidator            *executor.WorkflowValidator
 	WorkspaceRegistrationManager domain.WorkspaceRegistrationManager
+	HistoryStore                 executor.ExecutionHistoryStore // Optional, the history endpoints return 404 when nil
+	PayloadStore                 executor.PayloadStore          // Optional, needed to serve executions with spilled items
 }
 
 func NewExecutorController(deps ExecutorControllerDependencies) *ExecutorController {
 	return &ExecutorController{
 		executorService:              deps.WorkflowExecutorService,
+		workflowValidator:            deps.WorkflowValidator,
 		workspaceRegistrationManager: deps.WorkspaceRegistrationManager,
+		historyStore:                 deps.HistoryStore,
+		payloadStore:                 deps.PayloadStore,
 	}
 }
 
@@ -91,17 +105,54 @@ func (c *ExecutorController) StartExecution(ctx fiber.Ctx) error {
 		EnableEvents:          req.EnableEvents,
 		IsTestingWorkflow:     isTestingWorkflow,
 		ExecutorStateSnapshot: req.ExecutorStateSnapshot,
+		CallDepth:             req.CallDepth,
+		DryRun:                req.DryRun,
 	}
 
 	if isTestingWorkflow {
 		p.UserID = req.UserID
 	}
 
-	result, err := c.executorService.Execute(ctx.RequestCtx(), p)
+	if c.workflowValidator != nil {
+		if err := c.workflowValidator.Check(ctx.RequestCtx(), p.Workflow, p.IsTestingWorkflow); err != nil {
+			log.Warn().Err(err).Str("execution_id", req.ExecutionID).Msg("Rejected invalid workflow")
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		}
+	}
+
+	// Queued executions run after the handler returned and the request context was
+	// recycled, so the execution gets its own context that is only canceled when the
+	// server shuts down while the request is in flight. It continues the trace of the
+	// caller, so the execution spans join the trace that triggered it.
+	executionCtx, cancelExecution := context.WithCancel(context.Background())
+	executionCtx = otel.GetTextMapPropagator().Extract(executionCtx, requestHeaderCarrier{ctx: ctx})
+
+	stopCancelOnShutdown := context.AfterFunc(ctx.RequestCtx(), cancelExecution)
+	defer stopCancelOnShutdown()
+
+	result, err := c.executorService.Execute(executionCtx, p)
+	if errors.Is(err, executor.ErrExecutorAtCapacity) {
+		log.Warn().Str("execution_id", req.ExecutionID).Msg("Rejected execution, executor is at capacity")
+		return fiber.NewError(fiber.StatusTooManyRequests, err.Error())
+	}
+	if errors.Is(err, domain.ErrMaxCallDepthExceeded) {
+		log.Warn().Str("execution_id", req.ExecutionID).Int("call_depth", req.CallDepth).Msg("Rejected execution, call depth exceeded")
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+	if errors.Is(err, executor.ErrExecutorDraining) {
+		log.Warn().Str("execution_id", req.ExecutionID).Msg("Rejected execution, executor is draining")
+		return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+	}
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to execute workflow")
 	}
 
+	if result.Queued {
+		return ctx.Status(fiber.StatusAccepted).JSON(executortypes.ExecutionResult{
+			Status: executortypes.ExecutionStatusQueued,
+		})
+	}
+
 	response := executortypes.ExecutionResult{
 		Payload:    result.Payload,
 		Headers:    result.Headers,
@@ -196,6 +247,125 @@ func (c *ExecutorController) StopExecution(ctx fiber.Ctx) error {
 	})
 }
 
+const (
+	defaultListExecutionsLimit = 50
+	maxListExecutionsLimit     = 500
+)
+
+// ListExecutions lists the executions of the workspace kept in the local history,
+// newest first
+func (c *ExecutorController) ListExecutions(ctx fiber.Ctx) error {
+	workspaceID := ctx.Params("workspaceID")
+	if workspaceID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Workspace ID is required")
+	}
+
+	if c.historyStore == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Local execution history is not enabled")
+	}
+
+	filter := executor.ExecutionHistoryFilter{
+		WorkspaceID: workspaceID,
+		WorkflowID:  ctx.Query("workflow_id"),
+		Status:      executor.ExecutionStatus(ctx.Query("status")),
+		Limit:       fiber.Query(ctx, "limit", defaultListExecutionsLimit),
+	}
+
+	if filter.Limit <= 0 || filter.Limit > maxListExecutionsLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxListExecutionsLimit))
+	}
+
+	var err error
+
+	if filter.StartedAfter, err = parseTimeQuery(ctx, "started_after"); err != nil {
+		return err
+	}
+
+	if filter.StartedBefore, err = parseTimeQuery(ctx, "started_before"); err != nil {
+		return err
+	}
+
+	records, err := c.historyStore.List(ctx.RequestCtx(), filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list executions")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to list executions")
+	}
+
+	executions := make([]executortypes.ExecutionSummary, 0, len(records))
+	for _, record := range records {
+		executions = append(executions, executionSummaryFromRecord(record))
+	}
+
+	return ctx.JSON(executortypes.ListExecutionsResponse{
+		Executions: executions,
+	})
+}
+
+// GetExecution returns an execution kept in the local history with the items of
+// every node
+func (c *ExecutorController) GetExecution(ctx fiber.Ctx) error {
+	workspaceID := ctx.Params("workspaceID")
+	if workspaceID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Workspace ID is required")
+	}
+
+	executionID := ctx.Params("executionID")
+	if executionID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Execution ID is required")
+	}
+
+	if c.historyStore == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Local execution history is not enabled")
+	}
+
+	record, err := c.historyStore.Get(ctx.RequestCtx(), executionID)
+	if errors.Is(err, executor.ErrExecutionRecordNotFound) || (err == nil && record.WorkspaceID != workspaceID) {
+		return fiber.NewError(fiber.StatusNotFound, "Execution not found")
+	}
+	if err != nil {
+		log.Error().Err(err).Str("execution_id", executionID).Msg("Failed to get execution")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get execution")
+	}
+
+	historyEntries, err := executor.ResolveEntries(ctx.RequestCtx(), c.payloadStore, record.HistoryEntries)
+	if err != nil {
+		log.Error().Err(err).Str("execution_id", executionID).Msg("Failed to read spilled items of execution")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get execution")
+	}
+
+	return ctx.JSON(executortypes.GetExecutionResponse{
+		Execution:      executionSummaryFromRecord(record),
+		NodeExecutions: mappers.DomainNodeExecutionsToFlowbaker(record.NodeExecutions),
+		HistoryEntries: mappers.DomainNodeExecutionEntriesToFlowbaker(historyEntries),
+	})
+}
+
+func executionSummaryFromRecord(record executor.ExecutionRecord) executortypes.ExecutionSummary {
+	return executortypes.ExecutionSummary{
+		ExecutionID:   record.ExecutionID,
+		WorkflowID:    record.WorkflowID,
+		TriggerNodeID: record.TriggerNodeID,
+		Status:        string(record.Status),
+		IsTesting:     record.IsTesting,
+		StartedAt:     record.StartedAt,
+		EndedAt:       record.EndedAt,
+	}
+}
+
+func parseTimeQuery(ctx fiber.Ctx, key string) (time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s must be an RFC 3339 time", key))
+	}
+
+	return t, nil
+}
+
 // HandlePollingEvent handles a polling event request from the API
 func (c *ExecutorController) HandlePollingEvent(ctx fiber.Ctx) error {
 	workspaceID := ctx.Params("workspaceID")
@@ -335,3 +505,28 @@ func (c *ExecutorController) UnregisterWorkspace(ctx fiber.Ctx) error {
 		Success: true,
 	})
 }
+
+// requestHeaderCarrier adapts the request headers of a fiber context to a
+// propagation.TextMapCarrier.
+type requestHeaderCarrier struct {
+	ctx fiber.Ctx
+}
+
+func (c requestHeaderCarrier) Get(key string) string {
+	return c.ctx.Get(key)
+}
+
+func (c requestHeaderCarrier) Set(key, value string) {
+	c.ctx.Request().Header.Set(key, value)
+}
+
+func (c requestHeaderCarrier) Keys() []string {
+	headers := c.ctx.GetReqHeaders()
+
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -2,6 +2,9 @@ package initialization
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/flowbaker/flowbaker/internal/controllers"
@@ -10,21 +13,37 @@ import (
 	"github.com/flowbaker/flowbaker/pkg/domain"
 	"github.com/flowbaker/flowbaker/pkg/domain/executor"
 	"github.com/flowbaker/flowbaker/pkg/expressions"
+	"github.com/flowbaker/flowbaker/pkg/metrics"
 
 	"github.com/rs/zerolog/log"
 )
 
 type ExecutorDependencies struct {
-	FlowbakerClient         *flowbaker.Client
+	FlowbakerClient         flowbaker.ClientInterface
 	IntegrationSelector     domain.IntegrationSelector
 	WorkflowExecutorService executor.WorkflowExecutorService
+	WorkflowValidator       *executor.WorkflowValidator
 	ExecutorController      *controllers.ExecutorController
+	CheckpointStore         executor.CheckpointStore       // Nil unless EnableCheckpoints is set
+	HistoryStore            executor.ExecutionHistoryStore // Nil unless Config.EnableLocalHistory is set
+	MetricsRegistry         *metrics.Registry
+	EventSinks              []domain.EventSink // Closed by the caller after the executions are drained
 }
 
 type ExecutorDependencyConfig struct {
-	FlowbakerClient *flowbaker.Client
+	FlowbakerClient flowbaker.ClientInterface
 	ExecutorID      string
 	Config          domain.ExecutorConfig
+
+	// CredentialManager replaces the credential manager backed by the platform, used
+	// when running workflows without a workspace.
+	CredentialManager domain.ExecutorCredentialManager
+	// IntegrationSelector receives the integration registrations instead of a new
+	// selector, used to wrap the selector e.g. for mocking nodes in workflow tests.
+	IntegrationSelector domain.IntegrationSelector
+	// EnableCheckpoints saves the state of running executions to Config.CheckpointDir
+	// after every node so they can be resumed after a restart.
+	EnableCheckpoints bool
 }
 
 type ExecutorContainer struct {
@@ -72,14 +91,21 @@ func (c *ExecutorContainer) BuildExecutorDependencies(ctx context.Context, confi
 		return nil, err
 	}
 
-	integrationSelector := domain.NewIntegrationSelector()
+	integrationSelector := config.IntegrationSelector
+	if integrationSelector == nil {
+		integrationSelector = domain.NewIntegrationSelector()
+	}
 
 	executorStorageManager := managers.NewExecutorStorageManager(managers.ExecutorStorageManagerDependencies{
 		Client: config.FlowbakerClient,
 	})
 
-	executorCredentialDecryptor := managers.NewExecutorCredentialDecryptionService(config.Config.X25519PrivateKey)
-	executorCredentialManager := managers.NewExecutorCredentialManager(config.FlowbakerClient, executorCredentialDecryptor)
+	executorCredentialManager := config.CredentialManager
+	if executorCredentialManager == nil {
+		executorCredentialDecryptor := managers.NewExecutorCredentialDecryptionService(config.Config.X25519PrivateKey)
+		executorCredentialManager = managers.NewExecutorCredentialManager(config.FlowbakerClient, executorCredentialDecryptor)
+	}
+
 	executorEventPublisher := managers.NewExecutorEventPublisher(config.FlowbakerClient)
 	executorTaskPublisher := managers.NewExecutorTaskPublisher(managers.ExecutorTaskPublisherDependencies{
 		Client: config.FlowbakerClient,
@@ -113,22 +139,247 @@ func (c *ExecutorContainer) BuildExecutorDependencies(ctx context.Context, confi
 		return nil, err
 	}
 
+	var checkpointStore executor.CheckpointStore
+	if config.EnableCheckpoints {
+		checkpointStore, err = newCheckpointStore(config.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var historyStore executor.ExecutionHistoryStore
+	if config.Config.EnableLocalHistory {
+		historyStore, err = newHistoryStore(config.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outputCache, err := newNodeOutputCache(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadStore, err := newPayloadStore(config.Config, executorStorageManager)
+	if err != nil {
+		return nil, err
+	}
+
+	eventSinks, err := newEventSinks(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsRegistry := metrics.NewRegistry(
+		metrics.WithSeriesTTL(time.Duration(config.Config.MetricsSeriesTTLMinutes)*time.Minute),
+		metrics.WithMaxSeries(config.Config.MetricsMaxSeries),
+	)
+
+	metricsOptions := []executor.ExecutionMetricsOption{}
+	if config.Config.MetricsNodeLabels {
+		metricsOptions = append(metricsOptions, executor.WithNodeIDLabel())
+	}
+
 	workflowExecutorService := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
 		IntegrationSelector:   integrationSelector,
 		OrderedEventPublisher: orderedEventPublisher,
 		FlowbakerClient:       config.FlowbakerClient,
 		CredentialManager:     executorCredentialManager,
+		CheckpointStore:       checkpointStore,
+		Metrics:               executor.NewExecutionMetrics(metricsRegistry, metricsOptions...),
+		HistoryStore:          historyStore,
+		OutputCache:           outputCache,
+		ParameterBinder:       kangarooBinder,
+		PayloadStore:          payloadStore,
+		SpillThresholdBytes:   config.Config.PayloadSpillThresholdBytes,
+		Schemas:               integrationSchemas,
+		EventSinks:            eventSinks,
+		AdmissionLimits: executor.AdmissionLimits{
+			MaxConcurrentExecutions:   config.Config.MaxConcurrentExecutions,
+			MaxConcurrentPerWorkspace: config.Config.MaxConcurrentExecutionsPerWorkspace,
+			MaxConcurrentPerWorkflow:  config.Config.MaxConcurrentExecutionsPerWorkflow,
+			MaxQueuedExecutions:       config.Config.MaxQueuedExecutions,
+		},
+	})
+
+	workflowValidator := executor.NewWorkflowValidator(executor.WorkflowValidatorDependencies{
+		IntegrationSelector: integrationSelector,
+		Schemas:             integrationSchemas,
 	})
 
 	executorController := controllers.NewExecutorController(controllers.ExecutorControllerDependencies{
 		WorkflowExecutorService:      workflowExecutorService,
+		WorkflowValidator:            workflowValidator,
 		WorkspaceRegistrationManager: c.workspaceRegistrationManager,
+		HistoryStore:                 historyStore,
+		PayloadStore:                 payloadStore,
 	})
 
 	return &ExecutorDependencies{
 		FlowbakerClient:         config.FlowbakerClient,
 		IntegrationSelector:     integrationSelector,
 		WorkflowExecutorService: workflowExecutorService,
+		WorkflowValidator:       workflowValidator,
 		ExecutorController:      executorController,
+		CheckpointStore:         checkpointStore,
+		HistoryStore:            historyStore,
+		MetricsRegistry:         metricsRegistry,
+		EventSinks:              eventSinks,
 	}, nil
 }
+
+func newCheckpointStore(config domain.ExecutorConfig) (executor.CheckpointStore, error) {
+	dir := config.CheckpointDir
+	if dir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		dir = filepath.Join(homeDir, ".flowbaker", "checkpoints")
+	}
+
+	return managers.NewFileCheckpointStore(managers.FileCheckpointStoreDependencies{
+		Dir: dir,
+	})
+}
+
+func newHistoryStore(config domain.ExecutorConfig) (executor.ExecutionHistoryStore, error) {
+	path := config.HistoryPath
+	if path == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		path = filepath.Join(homeDir, ".flowbaker", "history.db")
+	}
+
+	store, err := managers.NewBoltHistoryStore(managers.BoltHistoryStoreDependencies{
+		Path:          path,
+		Retention:     time.Duration(config.HistoryRetentionDays) * 24 * time.Hour,
+		MaxExecutions: config.HistoryMaxExecutions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func newNodeOutputCache(config domain.ExecutorConfig) (executor.NodeOutputCache, error) {
+	switch config.NodeOutputCache {
+	case "":
+		return nil, nil
+	case "memory":
+		return managers.NewMemoryNodeOutputCache(managers.MemoryNodeOutputCacheDependencies{
+			MaxEntries: config.NodeOutputCacheSize,
+		}), nil
+	case "disk":
+		dir := config.NodeOutputCacheDir
+		if dir == "" {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get home directory: %w", err)
+			}
+
+			dir = filepath.Join(homeDir, ".flowbaker", "cache")
+		}
+
+		return managers.NewDiskNodeOutputCache(managers.DiskNodeOutputCacheDependencies{
+			Dir: dir,
+		})
+	case "redis":
+		return managers.NewRedisNodeOutputCache(managers.RedisNodeOutputCacheDependencies{
+			URL: config.NodeOutputCacheRedisURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown node output cache %q", config.NodeOutputCache)
+	}
+}
+
+func newPayloadStore(config domain.ExecutorConfig, storageManager domain.ExecutorStorageManager) (executor.PayloadStore, error) {
+	switch config.PayloadSpill {
+	case "":
+		return nil, nil
+	case "disk":
+		dir := config.PayloadSpillDir
+		if dir == "" {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get home directory: %w", err)
+			}
+
+			dir = filepath.Join(homeDir, ".flowbaker", "payloads")
+		}
+
+		return managers.NewDiskPayloadStore(managers.DiskPayloadStoreDependencies{
+			Dir:       dir,
+			Retention: time.Duration(config.PayloadSpillRetentionDays) * 24 * time.Hour,
+		})
+	case "storage":
+		return managers.NewStoragePayloadStore(managers.StoragePayloadStoreDependencies{
+			StorageManager: storageManager,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown payload spill store %q", config.PayloadSpill)
+	}
+}
+
+// newEventSinks creates a sink for every configured destination. The sinks send in the
+// background, so a slow destination does not hold up executions.
+func newEventSinks(config domain.ExecutorConfig) ([]domain.EventSink, error) {
+	eventSinks := []domain.EventSink{}
+
+	addSink := func(name string, sink domain.EventSink) {
+		log.Info().Str("sink", name).Msg("Sending execution events to sink")
+
+		eventSinks = append(eventSinks, managers.NewAsyncEventSink(managers.AsyncEventSinkDependencies{
+			Name: name,
+			Sink: sink,
+		}))
+	}
+
+	if config.EventSinkFile != "" {
+		sink, err := managers.NewFileEventSink(managers.FileEventSinkDependencies{
+			Path: config.EventSinkFile,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		addSink("file", sink)
+	}
+
+	if config.EventSinkWebhookURL != "" {
+		addSink("webhook", managers.NewWebhookEventSink(managers.WebhookEventSinkDependencies{
+			URL: config.EventSinkWebhookURL,
+		}))
+	}
+
+	if len(config.EventSinkKafkaBrokers) > 0 {
+		sink, err := managers.NewKafkaEventSink(managers.KafkaEventSinkDependencies{
+			Brokers: config.EventSinkKafkaBrokers,
+			Topic:   config.EventSinkKafkaTopic,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		addSink("kafka", sink)
+	}
+
+	if config.EventSinkNATSURL != "" {
+		sink, err := managers.NewNATSEventSink(managers.NATSEventSinkDependencies{
+			URL:     config.EventSinkNATSURL,
+			Subject: config.EventSinkNATSSubject,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		addSink("nats", sink)
+	}
+
+	return eventSinks, nil
+}
@@ -13,6 +13,7 @@ import (
 	cronintegration "github.com/flowbaker/flowbaker/pkg/integrations/cron"
 	"github.com/flowbaker/flowbaker/pkg/integrations/discord"
 	"github.com/flowbaker/flowbaker/pkg/integrations/dropbox"
+	executeworkflow "github.com/flowbaker/flowbaker/pkg/integrations/execute_workflow"
 	"github.com/flowbaker/flowbaker/pkg/integrations/rawfiletoitem"
 	githubintegration "github.com/flowbaker/flowbaker/pkg/integrations/github"
 	gitlabintegration "github.com/flowbaker/flowbaker/pkg/integrations/gitlab"
@@ -245,6 +246,10 @@ var integrationRegisterParamsList = []integrationRegisterParams{
 		IntegrationType: domain.IntegrationType_Sleep,
 		NewCreator:      sleep.NewSleepIntegrationCreator,
 	},
+	{
+		IntegrationType: domain.IntegrationType_ExecuteWorkflow,
+		NewCreator:      executeworkflow.NewExecuteWorkflowIntegrationCreator,
+	},
 }
 
 func registerIntegrations(integrationSelector domain.IntegrationSelector, commonDeps domain.IntegrationDeps) error {
@@ -272,6 +272,7 @@ func RunFirstTimeSetup(ctx context.Context, params RunFirstTimeSetupParams) erro
 		SetupComplete:               true,
 		WorkspaceAssignments:        []domain.WorkspaceAssignment{result.WorkspaceAssignment},
 		EnableWorkspaceRegistration: true,
+		EnableCheckpoints:           true,
 		EnableStaticPasscode:        enableStaticPasscode,
 		StaticPasscode:              staticPasscode,
 		LastConnected:               time.Now().Format(time.RFC3339),
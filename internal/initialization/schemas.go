@@ -0,0 +1,117 @@
+package initialization
+
+import (
+	"github.com/flowbaker/flowbaker/pkg/integrations/ai_agent"
+	"github.com/flowbaker/flowbaker/pkg/integrations/base64"
+	"github.com/flowbaker/flowbaker/pkg/integrations/brightdata"
+	"github.com/flowbaker/flowbaker/pkg/integrations/chat"
+	claudeintegration "github.com/flowbaker/flowbaker/pkg/integrations/claude"
+	"github.com/flowbaker/flowbaker/pkg/integrations/click"
+	"github.com/flowbaker/flowbaker/pkg/integrations/condition"
+	router "github.com/flowbaker/flowbaker/pkg/integrations/content_classifier"
+	cronintegration "github.com/flowbaker/flowbaker/pkg/integrations/cron"
+	"github.com/flowbaker/flowbaker/pkg/integrations/discord"
+	"github.com/flowbaker/flowbaker/pkg/integrations/dropbox"
+	executeworkflow "github.com/flowbaker/flowbaker/pkg/integrations/execute_workflow"
+	"github.com/flowbaker/flowbaker/pkg/integrations/flowbaker_agent_memory"
+	"github.com/flowbaker/flowbaker/pkg/integrations/gemini"
+	githubintegration "github.com/flowbaker/flowbaker/pkg/integrations/github"
+	gitlabintegration "github.com/flowbaker/flowbaker/pkg/integrations/gitlab"
+	"github.com/flowbaker/flowbaker/pkg/integrations/google/gmail"
+	googledrive "github.com/flowbaker/flowbaker/pkg/integrations/google/google_drive"
+	googlesheets "github.com/flowbaker/flowbaker/pkg/integrations/google/google_sheets"
+	"github.com/flowbaker/flowbaker/pkg/integrations/google/youtube"
+	"github.com/flowbaker/flowbaker/pkg/integrations/groq"
+	"github.com/flowbaker/flowbaker/pkg/integrations/http"
+	"github.com/flowbaker/flowbaker/pkg/integrations/input"
+	"github.com/flowbaker/flowbaker/pkg/integrations/items_to_item"
+	"github.com/flowbaker/flowbaker/pkg/integrations/jira"
+	jwtintegration "github.com/flowbaker/flowbaker/pkg/integrations/jwt"
+	"github.com/flowbaker/flowbaker/pkg/integrations/knowledge"
+	"github.com/flowbaker/flowbaker/pkg/integrations/linear"
+	"github.com/flowbaker/flowbaker/pkg/integrations/loop"
+	"github.com/flowbaker/flowbaker/pkg/integrations/manipulation"
+	mongodb "github.com/flowbaker/flowbaker/pkg/integrations/mongo"
+	notionintegration "github.com/flowbaker/flowbaker/pkg/integrations/notion"
+	onerror "github.com/flowbaker/flowbaker/pkg/integrations/on_error"
+	"github.com/flowbaker/flowbaker/pkg/integrations/openai"
+	pipedriveintegration "github.com/flowbaker/flowbaker/pkg/integrations/pipedrive"
+	"github.com/flowbaker/flowbaker/pkg/integrations/postgresql"
+	"github.com/flowbaker/flowbaker/pkg/integrations/rawfiletoitem"
+	"github.com/flowbaker/flowbaker/pkg/integrations/redis"
+	resendintegration "github.com/flowbaker/flowbaker/pkg/integrations/resend"
+	s3integration "github.com/flowbaker/flowbaker/pkg/integrations/s3"
+	sendresponse "github.com/flowbaker/flowbaker/pkg/integrations/send_response"
+	slackintegration "github.com/flowbaker/flowbaker/pkg/integrations/slack"
+	"github.com/flowbaker/flowbaker/pkg/integrations/sleep"
+	"github.com/flowbaker/flowbaker/pkg/integrations/snowflake"
+	"github.com/flowbaker/flowbaker/pkg/integrations/split_array"
+	startupswatchintegration "github.com/flowbaker/flowbaker/pkg/integrations/startups_watch"
+	"github.com/flowbaker/flowbaker/pkg/integrations/storage"
+	"github.com/flowbaker/flowbaker/pkg/integrations/stripe"
+	"github.com/flowbaker/flowbaker/pkg/integrations/teams"
+	telegramintegration "github.com/flowbaker/flowbaker/pkg/integrations/telegram"
+	"github.com/flowbaker/flowbaker/pkg/integrations/toolset"
+	"github.com/flowbaker/flowbaker/pkg/integrations/transform"
+	"github.com/flowbaker/flowbaker/pkg/integrations/webhook"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+// integrationSchemas are the schemas the executor ships with, used to validate
+// workflows before running them.
+var integrationSchemas = []domain.Integration{
+	ai_agent.Schema,
+	base64.Schema,
+	brightdata.Schema,
+	chat.Schema,
+	claudeintegration.ClaudeSchema,
+	click.Schema,
+	condition.Schema,
+	router.Schema,
+	cronintegration.Schema,
+	discord.Schema,
+	dropbox.DropboxSchema,
+	executeworkflow.Schema,
+	flowbaker_agent_memory.Schema,
+	gemini.GeminiSchema,
+	githubintegration.GithubSchema,
+	gitlabintegration.GitlabSchema,
+	gmail.Schema,
+	googledrive.Schema,
+	googlesheets.Schema,
+	youtube.Schema,
+	groq.GroqSchema,
+	http.Schema,
+	input.Schema,
+	items_to_item.Schema,
+	jira.Schema,
+	jwtintegration.Schema,
+	knowledge.Schema,
+	linear.Schema,
+	loop.Schema,
+	manipulation.Schema,
+	mongodb.Schema,
+	notionintegration.NotionSchema,
+	onerror.Schema,
+	openai.Schema,
+	pipedriveintegration.PipedriveSchema,
+	postgresql.Schema,
+	rawfiletoitem.Schema,
+	redis.Schema,
+	resendintegration.ResendSchema,
+	s3integration.Schema,
+	sendresponse.Schema,
+	slackintegration.SlackSchema,
+	sleep.Schema,
+	snowflake.Schema,
+	split_array.Schema,
+	startupswatchintegration.StartupsWatchSchema,
+	storage.Schema,
+	stripe.Schema,
+	teams.TeamsSchema,
+	telegramintegration.Schema,
+	toolset.Schema,
+	transform.Schema,
+	webhook.Schema,
+}
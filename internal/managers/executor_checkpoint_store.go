@@ -0,0 +1,131 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+
+	"github.com/rs/zerolog/log"
+)
+
+const checkpointFileExtension = ".json"
+
+// fileCheckpointStore keeps one JSON file per execution in a directory. Files are
+// written to a temporary file first and renamed, so a crash never leaves a partially
+// written checkpoint behind.
+type fileCheckpointStore struct {
+	dir string
+}
+
+type FileCheckpointStoreDependencies struct {
+	Dir string
+}
+
+func NewFileCheckpointStore(deps FileCheckpointStoreDependencies) (executor.CheckpointStore, error) {
+	if err := os.MkdirAll(deps.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	return &fileCheckpointStore{
+		dir: deps.Dir,
+	}, nil
+}
+
+func (s *fileCheckpointStore) Save(ctx context.Context, checkpoint executor.ExecutionCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(s.dir, checkpoint.ExecutionID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+
+	tempPath := tempFile.Name()
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+
+		return fmt.Errorf("failed to sync checkpoint file: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempPath)
+
+		return fmt.Errorf("failed to close checkpoint file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, s.path(checkpoint.ExecutionID)); err != nil {
+		os.Remove(tempPath)
+
+		return fmt.Errorf("failed to move checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fileCheckpointStore) Delete(ctx context.Context, executionID string) error {
+	if err := os.Remove(s.path(executionID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the stored checkpoints, oldest first. Files that can not be read are
+// skipped so one corrupt checkpoint does not block resuming the others.
+func (s *fileCheckpointStore) List(ctx context.Context) ([]executor.ExecutionCheckpoint, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint directory: %w", err)
+	}
+
+	checkpoints := []executor.ExecutionCheckpoint{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), checkpointFileExtension) {
+			continue
+		}
+
+		path := filepath.Join(s.dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("Failed to read checkpoint file")
+			continue
+		}
+
+		var checkpoint executor.ExecutionCheckpoint
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("Failed to parse checkpoint file")
+			continue
+		}
+
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].StartedAt.Before(checkpoints[j].StartedAt)
+	})
+
+	return checkpoints, nil
+}
+
+func (s *fileCheckpointStore) path(executionID string) string {
+	return filepath.Join(s.dir, filepath.Base(executionID)+checkpointFileExtension)
+}
@@ -11,10 +11,10 @@ import (
 )
 
 type ExecutorEventPublisher struct {
-	api *flowbaker.Client
+	api flowbaker.ClientInterface
 }
 
-func NewExecutorEventPublisher(api *flowbaker.Client) *ExecutorEventPublisher {
+func NewExecutorEventPublisher(api flowbaker.ClientInterface) *ExecutorEventPublisher {
 	return &ExecutorEventPublisher{
 		api: api,
 	}
@@ -0,0 +1,293 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	DefaultEventSinkBufferSize = 1000
+
+	eventSinkWebhookTimeout = 10 * time.Second
+)
+
+// fileEventSink appends events to a file as newline delimited JSON.
+type fileEventSink struct {
+	mutex   sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+type FileEventSinkDependencies struct {
+	Path string
+}
+
+func NewFileEventSink(deps FileEventSinkDependencies) (domain.EventSink, error) {
+	if err := os.MkdirAll(filepath.Dir(deps.Path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create event sink directory: %w", err)
+	}
+
+	file, err := os.OpenFile(deps.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event sink file: %w", err)
+	}
+
+	return &fileEventSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (s *fileEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.encoder.Encode(event); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fileEventSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.file.Close()
+}
+
+// webhookEventSink posts every event as JSON to a URL.
+type webhookEventSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+type WebhookEventSinkDependencies struct {
+	URL        string
+	HTTPClient *http.Client // Optional, a client with a 10 second timeout is used when nil
+}
+
+func NewWebhookEventSink(deps WebhookEventSinkDependencies) domain.EventSink {
+	httpClient := deps.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: eventSinkWebhookTimeout}
+	}
+
+	return &webhookEventSink{
+		url:        deps.URL,
+		httpClient: httpClient,
+	}
+}
+
+func (s *webhookEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *webhookEventSink) Close() error {
+	return nil
+}
+
+// kafkaEventSink writes events to a topic, keyed by execution ID so that the events
+// of an execution stay in order on one partition.
+type kafkaEventSink struct {
+	writer *kafka.Writer
+}
+
+type KafkaEventSinkDependencies struct {
+	Brokers []string
+	Topic   string
+}
+
+func NewKafkaEventSink(deps KafkaEventSinkDependencies) (domain.EventSink, error) {
+	if len(deps.Brokers) == 0 {
+		return nil, errors.New("kafka event sink requires at least one broker")
+	}
+
+	return &kafkaEventSink{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(deps.Brokers...),
+			Topic:                  deps.Topic,
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
+	}, nil
+}
+
+func (s *kafkaEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.ExecutionID),
+		Value: value,
+	}); err != nil {
+		return fmt.Errorf("failed to write kafka message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *kafkaEventSink) Close() error {
+	return s.writer.Close()
+}
+
+// natsEventSink publishes events to <subject>.<event type>, so subscribers can pick
+// the events they need with subject wildcards.
+type natsEventSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+type NATSEventSinkDependencies struct {
+	URL     string
+	Subject string
+}
+
+func NewNATSEventSink(deps NATSEventSinkDependencies) (domain.EventSink, error) {
+	conn, err := nats.Connect(deps.URL, nats.Name("flowbaker-executor"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	return &natsEventSink{
+		conn:    conn,
+		subject: deps.Subject,
+	}, nil
+}
+
+func (s *natsEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := s.conn.Publish(s.subject+"."+event.Type, data); err != nil {
+		return fmt.Errorf("failed to publish nats message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *natsEventSink) Close() error {
+	return s.conn.Drain()
+}
+
+// asyncEventSink sends events to a sink in the background, so a slow sink does not slow
+// down executions. Events are dropped when the buffer is full.
+type asyncEventSink struct {
+	name   string
+	sink   domain.EventSink
+	events chan domain.SinkEvent
+	done   chan struct{}
+
+	mutex    sync.Mutex
+	isClosed bool
+}
+
+type AsyncEventSinkDependencies struct {
+	Name       string // Used in logs
+	Sink       domain.EventSink
+	BufferSize int // DefaultEventSinkBufferSize when zero
+}
+
+func NewAsyncEventSink(deps AsyncEventSinkDependencies) domain.EventSink {
+	bufferSize := deps.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultEventSinkBufferSize
+	}
+
+	s := &asyncEventSink{
+		name:   deps.Name,
+		sink:   deps.Sink,
+		events: make(chan domain.SinkEvent, bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Send does not use the context of the caller, the event is sent after the node that
+// emitted it has finished.
+func (s *asyncEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.isClosed {
+		log.Warn().Str("sink", s.name).Str("event_type", event.Type).Msg("Event sink is closed, dropping event")
+
+		return nil
+	}
+
+	select {
+	case s.events <- event:
+	default:
+		log.Warn().Str("sink", s.name).Str("event_type", event.Type).Msg("Event sink buffer is full, dropping event")
+	}
+
+	return nil
+}
+
+// Close sends the buffered events and closes the underlying sink. The executions are
+// drained before the sinks are closed, events sent after Close are dropped.
+func (s *asyncEventSink) Close() error {
+	s.mutex.Lock()
+	if !s.isClosed {
+		s.isClosed = true
+		close(s.events)
+	}
+	s.mutex.Unlock()
+
+	<-s.done
+
+	return s.sink.Close()
+}
+
+func (s *asyncEventSink) run() {
+	defer close(s.done)
+
+	for event := range s.events {
+		if err := s.sink.Send(context.Background(), event); err != nil {
+			log.Warn().Err(err).Str("sink", s.name).Str("event_type", event.Type).Msg("Failed to send event to sink")
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package managers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+func TestFileEventSink_AppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.ndjson")
+
+	sink, err := NewFileEventSink(FileEventSinkDependencies{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileEventSink() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	for _, eventType := range []string{"node_execution_started", "node_executed"} {
+		if err := sink.Send(ctx, domain.SinkEvent{
+			Kind:        domain.SinkEventKindExecution,
+			Type:        eventType,
+			ExecutionID: "exec_1",
+		}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open event file: %v", err)
+	}
+	defer file.Close()
+
+	types := []string{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event domain.SinkEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not an event: %v", scanner.Text(), err)
+		}
+
+		types = append(types, event.Type)
+	}
+
+	if len(types) != 2 || types[0] != "node_execution_started" || types[1] != "node_executed" {
+		t.Errorf("types = %v, want the two events in order", types)
+	}
+}
+
+func TestWebhookEventSink(t *testing.T) {
+	received := make(chan domain.SinkEvent, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event domain.SinkEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received <- event
+
+		if event.Type == "node_failed" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookEventSink(WebhookEventSinkDependencies{URL: server.URL})
+
+	ctx := context.Background()
+
+	if err := sink.Send(ctx, domain.SinkEvent{Type: "node_executed", ExecutionID: "exec_1"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if event := <-received; event.ExecutionID != "exec_1" {
+		t.Errorf("received event = %+v, want the event of exec_1", event)
+	}
+
+	if err := sink.Send(ctx, domain.SinkEvent{Type: "node_failed"}); err == nil {
+		t.Error("Send() error = nil, want an error for a failed response")
+	}
+}
+
+func TestAsyncEventSink_CloseSendsBufferedEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	fileSink, err := NewFileEventSink(FileEventSinkDependencies{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileEventSink() error = %v", err)
+	}
+
+	sink := NewAsyncEventSink(AsyncEventSinkDependencies{Name: "file", Sink: fileSink})
+
+	for i := 0; i < 10; i++ {
+		if err := sink.Send(context.Background(), domain.SinkEvent{Type: "node_executed"}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read event file: %v", err)
+	}
+
+	lines := 0
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+
+	if lines != 10 {
+		t.Errorf("lines = %d, want 10", lines)
+	}
+}
+
+func TestAsyncEventSink_DropsEventsAfterClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	fileSink, err := NewFileEventSink(FileEventSinkDependencies{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileEventSink() error = %v", err)
+	}
+
+	sink := NewAsyncEventSink(AsyncEventSinkDependencies{Name: "file", Sink: fileSink})
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if err := sink.Send(context.Background(), domain.SinkEvent{Type: "node_executed"}); err != nil {
+		t.Fatalf("Send() after Close() error = %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Errorf("event file = %q, want the event sent after Close to be dropped", data)
+	}
+}
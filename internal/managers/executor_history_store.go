@@ -0,0 +1,226 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	historyRecordsBucket = []byte("records")
+	historyKeysBucket    = []byte("keys")
+)
+
+// BoltHistoryStore keeps execution records in a bbolt database. Records are keyed by
+// their start time followed by the execution ID, so they are iterated in start order,
+// and a second bucket maps execution IDs to those keys.
+type BoltHistoryStore struct {
+	db            *bolt.DB
+	retention     time.Duration
+	maxExecutions int
+}
+
+type BoltHistoryStoreDependencies struct {
+	Path          string
+	Retention     time.Duration // Records that started earlier are deleted, zero keeps them
+	MaxExecutions int           // The oldest records above the limit are deleted, zero keeps them
+}
+
+func NewBoltHistoryStore(deps BoltHistoryStoreDependencies) (*BoltHistoryStore, error) {
+	if err := os.MkdirAll(filepath.Dir(deps.Path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := bolt.Open(deps.Path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(historyRecordsBucket); err != nil {
+			return err
+		}
+
+		_, err := tx.CreateBucketIfNotExists(historyKeysBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history buckets: %w", err)
+	}
+
+	return &BoltHistoryStore{
+		db:            db,
+		retention:     deps.Retention,
+		maxExecutions: deps.MaxExecutions,
+	}, nil
+}
+
+func (s *BoltHistoryStore) Close() error {
+	return s.db.Close()
+}
+
+// Save stores the record, replacing an earlier record of the same execution, and
+// applies the retention settings.
+func (s *BoltHistoryStore) Save(ctx context.Context, record executor.ExecutionRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution record: %w", err)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		records := tx.Bucket(historyRecordsBucket)
+		keys := tx.Bucket(historyKeysBucket)
+
+		if previousKey := keys.Get([]byte(record.ExecutionID)); previousKey != nil {
+			if err := records.Delete(previousKey); err != nil {
+				return err
+			}
+		}
+
+		key := historyRecordKey(record.StartedAt, record.ExecutionID)
+
+		if err := records.Put(key, data); err != nil {
+			return err
+		}
+
+		if err := keys.Put([]byte(record.ExecutionID), key); err != nil {
+			return err
+		}
+
+		return s.prune(records, keys)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save execution record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *BoltHistoryStore) Get(ctx context.Context, executionID string) (executor.ExecutionRecord, error) {
+	var record executor.ExecutionRecord
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket(historyKeysBucket).Get([]byte(executionID))
+		if key == nil {
+			return executor.ErrExecutionRecordNotFound
+		}
+
+		data := tx.Bucket(historyRecordsBucket).Get(key)
+		if data == nil {
+			return executor.ErrExecutionRecordNotFound
+		}
+
+		return json.Unmarshal(data, &record)
+	})
+	if err != nil {
+		return executor.ExecutionRecord{}, fmt.Errorf("failed to get execution record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *BoltHistoryStore) List(ctx context.Context, filter executor.ExecutionHistoryFilter) ([]executor.ExecutionRecord, error) {
+	records := []executor.ExecutionRecord{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(historyRecordsBucket).Cursor()
+
+		for key, data := cursor.Last(); key != nil; key, data = cursor.Prev() {
+			if filter.Limit > 0 && len(records) >= filter.Limit {
+				break
+			}
+
+			if !filter.StartedAfter.IsZero() && historyRecordTime(key).Before(filter.StartedAfter) {
+				break
+			}
+
+			var record executor.ExecutionRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+
+			if !filter.Matches(record) {
+				continue
+			}
+
+			record.NodeExecutions = nil
+			record.HistoryEntries = nil
+
+			records = append(records, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list execution records: %w", err)
+	}
+
+	return records, nil
+}
+
+// prune deletes the records that started before the retention period and the oldest
+// records above the execution limit.
+func (s *BoltHistoryStore) prune(records, keys *bolt.Bucket) error {
+	count := 0
+	if s.maxExecutions > 0 {
+		if err := keys.ForEach(func(k, v []byte) error {
+			count++
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	var cutoff []byte
+	if s.retention > 0 {
+		cutoff = historyRecordKey(time.Now().Add(-s.retention), "")
+	}
+
+	cursor := records.Cursor()
+
+	for key, _ := cursor.First(); key != nil; key, _ = cursor.First() {
+		expired := cutoff != nil && bytes.Compare(key, cutoff) < 0
+		overLimit := s.maxExecutions > 0 && count > s.maxExecutions
+
+		if !expired && !overLimit {
+			break
+		}
+
+		if err := keys.Delete(bytes.Clone(historyRecordExecutionID(key))); err != nil {
+			return err
+		}
+
+		if err := cursor.Delete(); err != nil {
+			return err
+		}
+
+		count--
+	}
+
+	return nil
+}
+
+func historyRecordKey(startedAt time.Time, executionID string) []byte {
+	key := make([]byte, 8, 8+len(executionID))
+	binary.BigEndian.PutUint64(key, uint64(startedAt.UnixNano()))
+
+	return append(key, executionID...)
+}
+
+func historyRecordExecutionID(key []byte) []byte {
+	return key[8:]
+}
+
+func historyRecordTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
@@ -0,0 +1,108 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+func TestBoltHistoryStore(t *testing.T) {
+	store, err := NewBoltHistoryStore(BoltHistoryStoreDependencies{
+		Path:          filepath.Join(t.TempDir(), "history.db"),
+		Retention:     24 * time.Hour,
+		MaxExecutions: 3,
+	})
+	if err != nil {
+		t.Fatalf("NewBoltHistoryStore() error = %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	records := []executor.ExecutionRecord{
+		{ExecutionID: "expired", WorkspaceID: "ws_1", WorkflowID: "wf_1", Status: executor.ExecutionStatusSucceeded, StartedAt: now.Add(-48 * time.Hour)},
+		{ExecutionID: "exec_1", WorkspaceID: "ws_1", WorkflowID: "wf_1", Status: executor.ExecutionStatusSucceeded, StartedAt: now.Add(-4 * time.Hour)},
+		{ExecutionID: "exec_2", WorkspaceID: "ws_1", WorkflowID: "wf_1", Status: executor.ExecutionStatusFailed, StartedAt: now.Add(-3 * time.Hour)},
+		{ExecutionID: "exec_3", WorkspaceID: "ws_1", WorkflowID: "wf_2", Status: executor.ExecutionStatusSucceeded, StartedAt: now.Add(-2 * time.Hour)},
+		{ExecutionID: "exec_4", WorkspaceID: "ws_2", WorkflowID: "wf_3", Status: executor.ExecutionStatusPaused, StartedAt: now.Add(-1 * time.Hour)},
+	}
+
+	for _, record := range records {
+		if err := store.Save(ctx, record); err != nil {
+			t.Fatalf("Save(%s) error = %v", record.ExecutionID, err)
+		}
+	}
+
+	// A resumed execution is saved again with the same start time
+	resumed := records[4]
+	resumed.Status = executor.ExecutionStatusSucceeded
+	resumed.HistoryEntries = []domain.NodeExecutionEntry{
+		{NodeID: "wait", EventType: domain.NodeExecuted, ItemsByOutputIndex: domain.NewNodeItemsMap(0, "wait", []domain.Item{"done"})},
+	}
+
+	if err := store.Save(ctx, resumed); err != nil {
+		t.Fatalf("Save(resumed) error = %v", err)
+	}
+
+	for _, executionID := range []string{"expired", "exec_1"} {
+		if _, err := store.Get(ctx, executionID); !errors.Is(err, executor.ErrExecutionRecordNotFound) {
+			t.Errorf("Get(%s) error = %v, want ErrExecutionRecordNotFound", executionID, err)
+		}
+	}
+
+	got, err := store.Get(ctx, "exec_4")
+	if err != nil {
+		t.Fatalf("Get(exec_4) error = %v", err)
+	}
+
+	if got.Status != executor.ExecutionStatusSucceeded || len(got.HistoryEntries) != 1 {
+		t.Errorf("Get(exec_4) = %+v, want the resumed record", got)
+	}
+
+	tests := []struct {
+		name   string
+		filter executor.ExecutionHistoryFilter
+		want   []string
+	}{
+		{"workspace", executor.ExecutionHistoryFilter{WorkspaceID: "ws_1"}, []string{"exec_3", "exec_2"}},
+		{"workflow", executor.ExecutionHistoryFilter{WorkspaceID: "ws_1", WorkflowID: "wf_1"}, []string{"exec_2"}},
+		{"status", executor.ExecutionHistoryFilter{WorkspaceID: "ws_1", Status: executor.ExecutionStatusSucceeded}, []string{"exec_3"}},
+		{"started after", executor.ExecutionHistoryFilter{StartedAfter: now.Add(-150 * time.Minute)}, []string{"exec_4", "exec_3"}},
+		{"started before", executor.ExecutionHistoryFilter{StartedBefore: now.Add(-150 * time.Minute)}, []string{"exec_2"}},
+		{"limit", executor.ExecutionHistoryFilter{Limit: 1}, []string{"exec_4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			ids := []string{}
+			for _, record := range records {
+				ids = append(ids, record.ExecutionID)
+
+				if record.HistoryEntries != nil {
+					t.Errorf("List() returned history entries of %s", record.ExecutionID)
+				}
+			}
+
+			if len(ids) != len(tt.want) {
+				t.Fatalf("List() = %v, want %v", ids, tt.want)
+			}
+
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("List() = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package managers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+func TestMemoryNodeOutputCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryNodeOutputCache(MemoryNodeOutputCacheDependencies{MaxEntries: 2})
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b"} {
+		if err := cache.Set(ctx, key, domain.NewNodeItemsMap(0, "node", []domain.Item{key}), time.Hour); err != nil {
+			t.Fatalf("Set(%s) error = %v", key, err)
+		}
+	}
+
+	if _, ok, _ := cache.Get(ctx, "a"); !ok {
+		t.Fatalf("Get(a) missed, want hit")
+	}
+
+	if err := cache.Set(ctx, "c", domain.NewNodeItemsMap(0, "node", []domain.Item{"c"}), time.Hour); err != nil {
+		t.Fatalf("Set(c) error = %v", err)
+	}
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok, _ := cache.Get(ctx, key); ok != want {
+			t.Errorf("Get(%s) hit = %t, want %t", key, ok, want)
+		}
+	}
+}
+
+func TestNodeOutputCache_Expiry(t *testing.T) {
+	disk, err := NewDiskNodeOutputCache(DiskNodeOutputCacheDependencies{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewDiskNodeOutputCache() error = %v", err)
+	}
+
+	caches := map[string]executor.NodeOutputCache{
+		"memory": NewMemoryNodeOutputCache(MemoryNodeOutputCacheDependencies{}),
+		"disk":   disk,
+	}
+
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			output := domain.NewNodeItemsMap(0, "node", []domain.Item{map[string]any{"id": float64(1)}})
+
+			if err := cache.Set(ctx, "ws_1:fresh", output, time.Hour); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			if err := cache.Set(ctx, "ws_1:expired", output, -time.Second); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			got, ok, err := cache.Get(ctx, "ws_1:fresh")
+			if err != nil || !ok {
+				t.Fatalf("Get(fresh) = %t, %v, want hit", ok, err)
+			}
+
+			if item := got[0].Items[0].(map[string]any); item["id"] != float64(1) {
+				t.Errorf("Get(fresh) item = %v, want id 1", item)
+			}
+
+			if _, ok, err := cache.Get(ctx, "ws_1:expired"); err != nil || ok {
+				t.Errorf("Get(expired) = %t, %v, want miss", ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+)
+
+// diskPayloadStore writes spilled items to <dir>/<execution id>/<id>.json. The items are
+// only readable by this executor, so they are read back before the execution state is
+// sent to the platform.
+type diskPayloadStore struct {
+	dir string
+}
+
+type DiskPayloadStoreDependencies struct {
+	Dir       string
+	Retention time.Duration // Executions older than this are deleted on start, zero keeps them
+}
+
+func NewDiskPayloadStore(deps DiskPayloadStoreDependencies) (executor.PayloadStore, error) {
+	if err := os.MkdirAll(deps.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create payload directory: %w", err)
+	}
+
+	store := &diskPayloadStore{
+		dir: deps.Dir,
+	}
+
+	if deps.Retention > 0 {
+		if err := store.prune(time.Now().Add(-deps.Retention)); err != nil {
+			log.Warn().Err(err).Str("dir", deps.Dir).Msg("Failed to delete expired spilled payloads")
+		}
+	}
+
+	return store, nil
+}
+
+func (s *diskPayloadStore) Put(ctx context.Context, p executor.PutPayloadParams) (domain.ItemsRef, error) {
+	if !isPathSegment(p.ExecutionID) {
+		return domain.ItemsRef{}, fmt.Errorf("invalid execution id %q", p.ExecutionID)
+	}
+
+	executionDir := filepath.Join(s.dir, p.ExecutionID)
+
+	if err := os.MkdirAll(executionDir, 0700); err != nil {
+		return domain.ItemsRef{}, fmt.Errorf("failed to create payload directory: %w", err)
+	}
+
+	id := xid.New().String()
+
+	tempFile, err := os.CreateTemp(executionDir, "*.tmp")
+	if err != nil {
+		return domain.ItemsRef{}, fmt.Errorf("failed to create payload file: %w", err)
+	}
+
+	tempPath := tempFile.Name()
+
+	if _, err := tempFile.Write(p.Data); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+
+		return domain.ItemsRef{}, fmt.Errorf("failed to write payload file: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempPath)
+
+		return domain.ItemsRef{}, fmt.Errorf("failed to close payload file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filepath.Join(executionDir, id+".json")); err != nil {
+		os.Remove(tempPath)
+
+		return domain.ItemsRef{}, fmt.Errorf("failed to move payload file: %w", err)
+	}
+
+	return domain.ItemsRef{
+		ID:          p.ExecutionID + "/" + id,
+		WorkspaceID: p.WorkspaceID,
+		Count:       p.Count,
+		SizeInBytes: int64(len(p.Data)),
+	}, nil
+}
+
+func (s *diskPayloadStore) IsLocal() bool {
+	return true
+}
+
+func (s *diskPayloadStore) Get(ctx context.Context, ref domain.ItemsRef) ([]domain.Item, error) {
+	executionID, id, ok := strings.Cut(ref.ID, "/")
+	if !ok || !isPathSegment(executionID) || !isPathSegment(id) {
+		return nil, fmt.Errorf("invalid payload id %q", ref.ID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, executionID, id+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload file: %w", err)
+	}
+
+	var items []domain.Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse payload file: %w", err)
+	}
+
+	return items, nil
+}
+
+// prune deletes the payloads of executions that were last written before cutoff.
+func (s *diskPayloadStore) prune(cutoff time.Time) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().Before(cutoff) {
+			if err := os.RemoveAll(filepath.Join(s.dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isPathSegment(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+// storagePayloadStore uploads spilled items to the workspace storage of the platform,
+// where the platform can read them to show the execution.
+type storagePayloadStore struct {
+	storageManager domain.ExecutorStorageManager
+}
+
+type StoragePayloadStoreDependencies struct {
+	StorageManager domain.ExecutorStorageManager
+}
+
+func NewStoragePayloadStore(deps StoragePayloadStoreDependencies) executor.PayloadStore {
+	return &storagePayloadStore{
+		storageManager: deps.StorageManager,
+	}
+}
+
+func (s *storagePayloadStore) Put(ctx context.Context, p executor.PutPayloadParams) (domain.ItemsRef, error) {
+	file, err := s.storageManager.PutExecutionFile(ctx, domain.PutExecutionFileParams{
+		WorkspaceID:  p.WorkspaceID,
+		OriginalName: p.ExecutionID + "-items.json",
+		SizeInBytes:  int64(len(p.Data)),
+		ContentType:  "application/json",
+		Reader:       io.NopCloser(bytes.NewReader(p.Data)),
+	})
+	if err != nil {
+		return domain.ItemsRef{}, fmt.Errorf("failed to upload payload: %w", err)
+	}
+
+	return domain.ItemsRef{
+		ID:          file.FileID,
+		WorkspaceID: p.WorkspaceID,
+		Count:       p.Count,
+		SizeInBytes: file.SizeInBytes,
+	}, nil
+}
+
+func (s *storagePayloadStore) Get(ctx context.Context, ref domain.ItemsRef) ([]domain.Item, error) {
+	file, err := s.storageManager.GetExecutionFile(ctx, domain.GetExecutionFileParams{
+		WorkspaceID: ref.WorkspaceID,
+		UploadID:    ref.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download payload: %w", err)
+	}
+	defer file.Reader.Close()
+
+	var items []domain.Item
+	if err := json.NewDecoder(file.Reader).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,51 @@
+package managers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+func TestDiskPayloadStore(t *testing.T) {
+	store, err := NewDiskPayloadStore(DiskPayloadStoreDependencies{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewDiskPayloadStore() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	ref, err := store.Put(ctx, executor.PutPayloadParams{
+		WorkspaceID: "ws_1",
+		ExecutionID: "exec_1",
+		Data:        []byte(`[{"id":1},{"id":2}]`),
+		Count:       2,
+	})
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if ref.Count != 2 || ref.SizeInBytes != 19 || ref.WorkspaceID != "ws_1" {
+		t.Errorf("ref = %+v, want 2 items of 19 bytes in ws_1", ref)
+	}
+
+	items, err := store.Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if len(items) != 2 || items[1].(map[string]any)["id"] != float64(2) {
+		t.Errorf("items = %v, want the two stored items", items)
+	}
+
+	for _, id := range []string{"exec_1", "../exec_1/" + ref.ID, "exec_1/../../secret"} {
+		if _, err := store.Get(ctx, domain.ItemsRef{ID: id}); err == nil {
+			t.Errorf("Get(%q) error = nil, want invalid id", id)
+		}
+	}
+
+	if _, err := store.Put(ctx, executor.PutPayloadParams{ExecutionID: "../exec", Data: []byte(`[]`)}); err == nil {
+		t.Errorf("Put() with execution id outside the directory error = nil")
+	}
+}
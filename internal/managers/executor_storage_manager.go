@@ -13,11 +13,11 @@ import (
 )
 
 type executorStorageManager struct {
-	client *flowbaker.Client
+	client flowbaker.ClientInterface
 }
 
 type ExecutorStorageManagerDependencies struct {
-	Client *flowbaker.Client
+	Client flowbaker.ClientInterface
 }
 
 func NewExecutorStorageManager(deps ExecutorStorageManagerDependencies) domain.ExecutorStorageManager {
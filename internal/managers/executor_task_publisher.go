@@ -11,11 +11,11 @@ import (
 )
 
 type executorTaskPublisher struct {
-	client *flowbaker.Client
+	client flowbaker.ClientInterface
 }
 
 type ExecutorTaskPublisherDependencies struct {
-	Client *flowbaker.Client
+	Client flowbaker.ClientInterface
 }
 
 func NewExecutorTaskPublisher(deps ExecutorTaskPublisherDependencies) domain.ExecutorTaskPublisher {
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// BearerTokenMiddleware rejects requests whose Authorization header does not carry the
+// given bearer token.
+func BearerTokenMiddleware(token string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		requestToken, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid or missing bearer token",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/flowbaker/flowbaker/internal/controllers"
 	"github.com/flowbaker/flowbaker/internal/middlewares"
 	"github.com/flowbaker/flowbaker/internal/version"
+	executortypes "github.com/flowbaker/flowbaker/pkg/clients/flowbaker-executor"
 	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/metrics"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
@@ -20,7 +23,9 @@ import (
 type HTTPServerDependencies struct {
 	Config             domain.ExecutorConfig
 	ExecutorController *controllers.ExecutorController
+	ExecutorService    executor.WorkflowExecutorService
 	KeyProvider        middlewares.WorkspaceAPIKeyProvider
+	MetricsRegistry    *metrics.Registry // Optional, /metrics is not served when nil or disabled in Config
 }
 
 func NewHTTPServer(ctx context.Context, deps HTTPServerDependencies) *fiber.App {
@@ -34,14 +39,46 @@ func NewHTTPServer(ctx context.Context, deps HTTPServerDependencies) *fiber.App
 
 	// Health check endpoint (no authentication required)
 	router.Get("/health", func(c fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"status":    "healthy",
-			"service":   "flowbaker-executor",
-			"version":   version.GetVersion(),
-			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		status := deps.ExecutorService.AdmissionStatus()
+
+		// A draining executor reports unavailable so load balancers stop routing to it.
+		statusCode, healthStatus := fiber.StatusOK, "healthy"
+		if status.Draining {
+			statusCode, healthStatus = fiber.StatusServiceUnavailable, "draining"
+		}
+
+		return c.Status(statusCode).JSON(executortypes.HealthCheckResponse{
+			Status:    healthStatus,
+			Service:   "flowbaker-executor",
+			Version:   version.GetVersion(),
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Executions: &executortypes.ExecutionCapacity{
+				Running:                 status.Running,
+				Queued:                  status.Queued,
+				MaxConcurrentExecutions: status.MaxConcurrentExecutions,
+				MaxQueuedExecutions:     status.MaxQueuedExecutions,
+				Draining:                status.Draining,
+			},
 		})
 	})
 
+	// Metrics endpoint in the Prometheus text format, scrapers have to send the metrics
+	// token as a bearer token when one is configured.
+	if deps.MetricsRegistry != nil && deps.Config.EnableMetrics {
+		serveMetrics := func(c fiber.Ctx) error {
+			c.Set(fiber.HeaderContentType, metrics.ContentType)
+
+			return deps.MetricsRegistry.WriteText(c.Response().BodyWriter())
+		}
+
+		if deps.Config.MetricsToken != "" {
+			router.Get("/metrics", middlewares.BearerTokenMiddleware(deps.Config.MetricsToken), serveMetrics)
+		} else {
+			log.Warn().Msg("Metrics are served without authentication, set a metrics token to protect them")
+			router.Get("/metrics", serveMetrics)
+		}
+	}
+
 	if deps.Config.EnableWorkspaceRegistration {
 		workspaces := router.Group("/workspaces")
 
@@ -65,7 +102,9 @@ func NewHTTPServer(ctx context.Context, deps HTTPServerDependencies) *fiber.App
 		log.Fatal().Msg("No signature verification method available")
 	}
 
+	specificWorkspace.Get("/executions", deps.ExecutorController.ListExecutions)
 	specificWorkspace.Post("/executions", deps.ExecutorController.StartExecution)
+	specificWorkspace.Get("/executions/:executionID", deps.ExecutorController.GetExecution)
 	specificWorkspace.Delete("/executions/:executionID", deps.ExecutorController.StopExecution)
 	specificWorkspace.Post("/executions/:executionID/nodes/:nodeID", deps.ExecutorController.RerunNode)
 	specificWorkspace.Post("/executions/:executionID/nodes/:nodeID/run", deps.ExecutorController.RunNode)
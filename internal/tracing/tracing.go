@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/flowbaker/flowbaker/internal/version"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const serviceName = "flowbaker-executor"
+
+type SetupParams struct {
+	// Endpoint is the OTLP/HTTP endpoint URL, the OTEL_EXPORTER_OTLP_* variables are
+	// used when it is empty.
+	Endpoint   string
+	ExecutorID string
+}
+
+// ShutdownFunc flushes the spans that are not exported yet and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the W3C trace context propagator and, when an OTLP endpoint is
+// configured, a tracer provider exporting to it. Without an endpoint spans are not
+// recorded but incoming trace context is still propagated to outgoing requests.
+func Setup(ctx context.Context, p SetupParams) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !isEndpointConfigured(p.Endpoint) {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var options []otlptracehttp.Option
+	if p.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpointURL(p.Endpoint))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version.GetVersion()),
+			semconv.ServiceInstanceID(p.ExecutorID),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func isEndpointConfigured(endpoint string) bool {
+	if endpoint != "" {
+		return true
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
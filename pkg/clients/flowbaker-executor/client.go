@@ -8,6 +8,9 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/flowbaker/flowbaker/internal/auth"
@@ -25,6 +28,8 @@ type ClientInterface interface {
 	RerunNode(ctx context.Context, workspaceID string, req *RerunNodeRequest) (*RerunNodeResponse, error)
 	StopExecution(ctx context.Context, workspaceID string, req *StopExecutionRequest) (*StopExecutionResponse, error)
 	RunNode(ctx context.Context, workspaceID string, req *RunNodeRequest) (*RunNodeResponse, error)
+	ListExecutions(ctx context.Context, workspaceID string, req *ListExecutionsRequest) (*ListExecutionsResponse, error)
+	GetExecution(ctx context.Context, workspaceID string, executionID string) (*GetExecutionResponse, error)
 }
 
 // Client provides methods to interact with the executor service
@@ -226,9 +231,12 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 			req.Header.Set("User-Agent", c.config.UserAgent)
 		}
 
-		// Add API signature if signer is available
+		// Add API signature if signer is available, the executor verifies the path
+		// without the query string
 		if c.signer != nil {
-			signatureHeaders, err := c.signer.SignRequest(method, path, bodyBytes)
+			signedPath, _, _ := strings.Cut(path, "?")
+
+			signatureHeaders, err := c.signer.SignRequest(method, signedPath, bodyBytes)
 			if err != nil {
 				return nil, fmt.Errorf("failed to sign request: %w", err)
 			}
@@ -424,3 +432,71 @@ func (c *Client) StopExecution(ctx context.Context, workspaceID string, req *Sto
 
 	return &stopExecutionResponse, nil
 }
+
+// ListExecutions lists the executions kept in the local history of the executor,
+// newest first
+func (c *Client) ListExecutions(ctx context.Context, workspaceID string, req *ListExecutionsRequest) (*ListExecutionsResponse, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("workspace ID cannot be empty")
+	}
+
+	query := url.Values{}
+	if req != nil {
+		if req.WorkflowID != "" {
+			query.Set("workflow_id", req.WorkflowID)
+		}
+		if req.Status != "" {
+			query.Set("status", req.Status)
+		}
+		if !req.StartedAfter.IsZero() {
+			query.Set("started_after", req.StartedAfter.Format(time.RFC3339Nano))
+		}
+		if !req.StartedBefore.IsZero() {
+			query.Set("started_before", req.StartedBefore.Format(time.RFC3339Nano))
+		}
+		if req.Limit > 0 {
+			query.Set("limit", strconv.Itoa(req.Limit))
+		}
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/executions", workspaceID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	var listExecutionsResponse ListExecutionsResponse
+
+	if err := c.handleResponse(resp, &listExecutionsResponse); err != nil {
+		return nil, fmt.Errorf("failed to process list executions response: %w", err)
+	}
+
+	return &listExecutionsResponse, nil
+}
+
+// GetExecution returns an execution from the local history of the executor with the
+// items of every node
+func (c *Client) GetExecution(ctx context.Context, workspaceID string, executionID string) (*GetExecutionResponse, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("workspace ID cannot be empty")
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/executions/%s", workspaceID, executionID)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	var getExecutionResponse GetExecutionResponse
+
+	if err := c.handleResponse(resp, &getExecutionResponse); err != nil {
+		return nil, fmt.Errorf("failed to process get execution response: %w", err)
+	}
+
+	return &getExecutionResponse, nil
+}
@@ -9,10 +9,21 @@ import (
 
 // HealthCheckResponse represents the response from a health check
 type HealthCheckResponse struct {
-	Status    string `json:"status"`
-	Service   string `json:"service"`
-	Version   string `json:"version"`
-	Timestamp string `json:"timestamp"`
+	Status     string             `json:"status"`
+	Service    string             `json:"service"`
+	Version    string             `json:"version"`
+	Timestamp  string             `json:"timestamp"`
+	Executions *ExecutionCapacity `json:"executions,omitempty"`
+}
+
+// ExecutionCapacity reports the executions running and queued on an executor, limits
+// of zero are unlimited
+type ExecutionCapacity struct {
+	Running                 int  `json:"running"`
+	Queued                  int  `json:"queued"`
+	MaxConcurrentExecutions int  `json:"max_concurrent_executions"`
+	MaxQueuedExecutions     int  `json:"max_queued_executions"`
+	Draining                bool `json:"draining"`
 }
 
 // WorkflowType represents the type of workflow execution
@@ -51,6 +62,8 @@ type StartExecutionRequest struct {
 	Workflow              *Workflow                     `json:"workflow,omitempty"`
 	TestingWorkflow       *TestingWorkflow              `json:"testing_workflow,omitempty"`
 	ExecutorStateSnapshot *domain.ExecutorStateSnapshot `json:"executor_state_snapshot,omitempty"`
+	CallDepth             int                           `json:"call_depth,omitempty"` // From the ExecuteWorkflowTask that started the execution, rejected above domain.MaxWorkflowCallDepth
+	DryRun                bool                          `json:"dry_run,omitempty"`
 }
 
 type StopExecutionRequest struct {
@@ -61,6 +74,37 @@ type StopExecutionResponse struct {
 	Success bool `json:"success"`
 }
 
+// ListExecutionsRequest filters the executions kept in the local history of an
+// executor, zero fields match any value
+type ListExecutionsRequest struct {
+	WorkflowID    string
+	Status        string
+	StartedAfter  time.Time
+	StartedBefore time.Time
+	Limit         int
+}
+
+// ExecutionSummary describes an execution kept in the local history of an executor
+type ExecutionSummary struct {
+	ExecutionID   string    `json:"execution_id"`
+	WorkflowID    string    `json:"workflow_id"`
+	TriggerNodeID string    `json:"trigger_node_id"`
+	Status        string    `json:"status"`
+	IsTesting     bool      `json:"is_testing"`
+	StartedAt     time.Time `json:"started_at"`
+	EndedAt       time.Time `json:"ended_at"`
+}
+
+type ListExecutionsResponse struct {
+	Executions []ExecutionSummary `json:"executions"`
+}
+
+type GetExecutionResponse struct {
+	Execution      ExecutionSummary         `json:"execution"`
+	NodeExecutions []api.NodeExecution      `json:"node_executions"`
+	HistoryEntries []api.NodeExecutionEntry `json:"history_entries"`
+}
+
 // TestingWorkflow represents a testing workflow that references a parent workflow
 type TestingWorkflow struct {
 	ParentWorkflowID string    `json:"parent_workflow_id"`
@@ -78,8 +122,19 @@ type ExecutionResult struct {
 	Payload    []byte              `json:"payload,omitempty"`
 	Headers    map[string][]string `json:"headers"`
 	StatusCode int                 `json:"status_code"`
+	Status     ExecutionStatus     `json:"status,omitempty"`
 }
 
+// ExecutionStatus tells how a started execution was handled, executions that ran to
+// completion have no status
+type ExecutionStatus string
+
+const (
+	// ExecutionStatusQueued means the execution waits for a free slot on the executor
+	// and reports its result through the platform once it ran
+	ExecutionStatusQueued ExecutionStatus = "queued"
+)
+
 // Workspace represents a workspace in the executor context
 type Workspace struct {
 	ID          string         `json:"id"`
@@ -122,33 +177,38 @@ type Workflow struct {
 }
 
 type WorkflowSettings struct {
-	NodeExecutionLimit int `json:"node_execution_limit"`
+	NodeExecutionLimit int   `json:"node_execution_limit"`
+	MaxDurationMs      int64 `json:"max_duration_ms,omitempty"`
+	MaxConcurrency     int   `json:"max_concurrency,omitempty"`
+	StrictTemplates    bool  `json:"strict_templates,omitempty"`
 }
 
 type NodeType string
 
 const (
-	NodeTypeAction  NodeType = "action"
-	NodeTypeTrigger NodeType = "trigger"
+	NodeTypeAction    NodeType = "action"
+	NodeTypeTrigger   NodeType = "trigger"
+	NodeTypeContainer NodeType = "container"
 )
 
 // WorkflowNode represents a node in a workflow
 type WorkflowNode struct {
-	ID                           string          `json:"id"`
-	WorkflowID                   string          `json:"workflow_id"`
-	Name                         string          `json:"name"`
-	Type                         NodeType        `json:"type"`
-	IntegrationType              IntegrationType `json:"integration_type"`
-	IntegrationSettings          map[string]any  `json:"integration_settings"`
-	Settings                     NodeSettings    `json:"common_settings"`
-	ExpressionSelectedProperties []string        `json:"expression_selected_properties"`
-	ProvidedByAgent              []string        `json:"provided_by_agent"`
-	XPosition                    float64         `json:"x_position"`
-	YPosition                    float64         `json:"y_position"`
-	UsageContext                 string          `json:"usage_context,omitempty"`
-	ParentID                     string          `json:"parent_id,omitempty"`
-	ActionNodeOpts               ActionNodeOpts  `json:"action_node_opts,omitempty"`
-	TriggerNodeOpts              TriggerNodeOpts `json:"trigger_node_opts,omitempty"`
+	ID                           string            `json:"id"`
+	WorkflowID                   string            `json:"workflow_id"`
+	Name                         string            `json:"name"`
+	Type                         NodeType          `json:"type"`
+	IntegrationType              IntegrationType   `json:"integration_type"`
+	IntegrationSettings          map[string]any    `json:"integration_settings"`
+	Settings                     NodeSettings      `json:"common_settings"`
+	ExpressionSelectedProperties []string          `json:"expression_selected_properties"`
+	ProvidedByAgent              []string          `json:"provided_by_agent"`
+	XPosition                    float64           `json:"x_position"`
+	YPosition                    float64           `json:"y_position"`
+	UsageContext                 string            `json:"usage_context,omitempty"`
+	ParentID                     string            `json:"parent_id,omitempty"`
+	ActionNodeOpts               ActionNodeOpts    `json:"action_node_opts,omitempty"`
+	TriggerNodeOpts              TriggerNodeOpts   `json:"trigger_node_opts,omitempty"`
+	ContainerNodeOpts            ContainerNodeOpts `json:"container_node_opts,omitempty"`
 }
 
 type ActionNodeOpts struct {
@@ -159,10 +219,30 @@ type TriggerNodeOpts struct {
 	EventType IntegrationTriggerEventType `json:"event_type"`
 }
 
+type ContainerNodeOpts struct {
+	ContainerType string `json:"container_type"`
+}
+
 type NodeSettings struct {
-	ReturnErrorAsItem       bool `json:"return_error_as_item"`
-	OverwriteExecutionLimit bool `json:"overwrite_execution_limit"`
-	ExecutionLimit          int  `json:"execution_limit"`
+	ReturnErrorAsItem       bool        `json:"return_error_as_item"`
+	OverwriteExecutionLimit bool        `json:"overwrite_execution_limit"`
+	ExecutionLimit          int         `json:"execution_limit"`
+	RetryPolicy             RetryPolicy `json:"retry_policy"`
+	TimeoutMs               int64       `json:"timeout_ms,omitempty"`
+	ItemConcurrency         int         `json:"item_concurrency,omitempty"`
+	ItemRateLimit           float64     `json:"item_rate_limit,omitempty"`
+	ContinueOnItemError     bool        `json:"continue_on_item_error,omitempty"`
+	CacheOutput             bool        `json:"cache_output,omitempty"`
+	CacheTTLMs              int64       `json:"cache_ttl_ms,omitempty"`
+}
+
+type RetryPolicy struct {
+	MaxAttempts    int      `json:"max_attempts"`
+	BackoffType    string   `json:"backoff_type"`
+	InitialDelayMs int64    `json:"initial_delay_ms"`
+	MaxDelayMs     int64    `json:"max_delay_ms"`
+	Jitter         bool     `json:"jitter"`
+	RetryOn        []string `json:"retry_on,omitempty"`
 }
 
 // PollingEventRequest represents a request to handle a polling event
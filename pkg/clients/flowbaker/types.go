@@ -13,8 +13,18 @@ type Item any
 
 // NodeItems represents items from a specific node
 type NodeItems struct {
-	FromNodeID string `json:"from_node_id"`
-	Items      []Item `json:"items"`
+	FromNodeID string    `json:"from_node_id"`
+	Items      []Item    `json:"items"`
+	Ref        *ItemsRef `json:"ref,omitempty"`
+}
+
+// ItemsRef points to items the executor spilled out of memory. When the executor spills
+// to workspace storage, ID is the upload ID of a JSON array with the items.
+type ItemsRef struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	Count       int    `json:"count"`
+	SizeInBytes int64  `json:"size_in_bytes"`
 }
 
 // EventType represents the type of execution event
@@ -24,6 +34,7 @@ const (
 	EventTypeNodeExecutionStarted       EventType = "node_execution_started"
 	EventTypeNodeExecuted               EventType = "node_executed"
 	EventTypeNodeFailed                 EventType = "node_failed"
+	EventTypeNodeRetried                EventType = "node_retried"
 	EventTypeWorkflowExecutionCompleted EventType = "workflow_execution_completed"
 )
 
@@ -51,6 +62,8 @@ type NodeExecutionEntry struct {
 	Error              string            `json:"error,omitempty"`
 	Timestamp          int64             `json:"timestamp"`
 	ExecutionOrder     int               `json:"execution_order"`
+	Attempt            int               `json:"attempt,omitempty"`
+	IsCached           bool              `json:"is_cached,omitempty"`
 }
 
 // PublishEventRequest represents the request to publish an execution event
@@ -92,6 +105,7 @@ type PauseExecutionRequest struct {
 	NodeExecutions    []NodeExecution      `json:"node_executions"`
 	HistoryEntries    []NodeExecutionEntry `json:"history_entries"`
 	IsTestingWorkflow bool                 `json:"is_testing_workflow"`
+	DryRun            bool                 `json:"dry_run,omitempty"`
 	ResumeStateJSON   []byte               `json:"resume_state_json"`
 }
 
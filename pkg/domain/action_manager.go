@@ -181,14 +181,16 @@ func (m *IntegrationActionManager) RunPerItem(ctx context.Context, actionType In
 
 	allItems := params.GetAllItems()
 
-	outputs := make([]Item, 0)
+	results, err := forEachItem(ctx, params.PerItemOptions, allItems, func(ctx context.Context, item Item) (Item, error) {
+		return actionFuncPerItem(ctx, params, item)
+	})
+	if err != nil {
+		return IntegrationOutput{}, err
+	}
 
-	for _, item := range allItems {
-		output, err := actionFuncPerItem(ctx, params, item)
-		if err != nil {
-			return IntegrationOutput{}, err
-		}
+	outputs := make([]Item, 0)
 
+	for _, output := range results.Succeeded() {
 		if output == nil {
 			continue
 		}
@@ -209,7 +211,7 @@ func (m *IntegrationActionManager) RunPerItem(ctx context.Context, actionType In
 	}
 
 	return IntegrationOutput{
-		ItemsByOutputIndex: NewNodeItemsMap(0, params.NodeID, outputs),
+		ItemsByOutputIndex: results.SetFailedItems(NewNodeItemsMap(0, params.NodeID, outputs), params.NodeID, allItems),
 	}, nil
 }
 
@@ -221,14 +223,16 @@ func (m *IntegrationActionManager) RunPerItemMulti(ctx context.Context, actionTy
 
 	allItems := params.GetAllItems()
 
-	outputs := make([]Item, 0)
+	results, err := forEachItem(ctx, params.PerItemOptions, allItems, func(ctx context.Context, item Item) ([]Item, error) {
+		return actionFuncPerItemMulti(ctx, params, item)
+	})
+	if err != nil {
+		return IntegrationOutput{}, err
+	}
 
-	for _, item := range allItems {
-		outputItems, err := actionFuncPerItemMulti(ctx, params, item)
-		if err != nil {
-			return IntegrationOutput{}, err
-		}
+	outputs := make([]Item, 0)
 
+	for _, outputItems := range results.Succeeded() {
 		if len(outputItems) == 0 {
 			continue
 		}
@@ -259,7 +263,7 @@ func (m *IntegrationActionManager) RunPerItemMulti(ctx context.Context, actionTy
 	}
 
 	return IntegrationOutput{
-		ItemsByOutputIndex: NewNodeItemsMap(0, params.NodeID, outputs),
+		ItemsByOutputIndex: results.SetFailedItems(NewNodeItemsMap(0, params.NodeID, outputs), params.NodeID, allItems),
 	}, nil
 }
 
@@ -275,14 +279,16 @@ func (m *IntegrationActionManager) RunPerItemWithFile(ctx context.Context, actio
 
 	allItems := params.GetAllItems()
 
-	outputs := make([]Item, 0)
+	results, err := forEachItem(ctx, params.PerItemOptions, allItems, func(ctx context.Context, item Item) (ItemWithFile, error) {
+		return actionFuncPerItemWithFile(ctx, params, item)
+	})
+	if err != nil {
+		return IntegrationOutput{}, err
+	}
 
-	for _, item := range allItems {
-		output, err := actionFuncPerItemWithFile(ctx, params, item)
-		if err != nil {
-			return IntegrationOutput{}, err
-		}
+	outputs := make([]Item, 0)
 
+	for _, output := range results.Succeeded() {
 		if output.Item == nil {
 			continue
 		}
@@ -318,7 +324,7 @@ func (m *IntegrationActionManager) RunPerItemWithFile(ctx context.Context, actio
 	}
 
 	return IntegrationOutput{
-		ItemsByOutputIndex: NewNodeItemsMap(0, params.NodeID, outputs),
+		ItemsByOutputIndex: results.SetFailedItems(NewNodeItemsMap(0, params.NodeID, outputs), params.NodeID, allItems),
 	}, nil
 }
 
@@ -372,14 +378,16 @@ func (m *IntegrationActionManager) RunPerItemRoutable(ctx context.Context, actio
 
 	allItems := params.GetAllItems()
 
-	outputs := make([]RoutableOutput, 0)
+	results, err := forEachItem(ctx, params.PerItemOptions, allItems, func(ctx context.Context, item Item) (RoutableOutput, error) {
+		return actionFuncPerItemRoutable(ctx, params, item)
+	})
+	if err != nil {
+		return IntegrationOutput{}, err
+	}
 
-	for _, item := range allItems {
-		output, err := actionFuncPerItemRoutable(ctx, params, item)
-		if err != nil {
-			return IntegrationOutput{}, err
-		}
+	outputs := make([]RoutableOutput, 0)
 
+	for _, output := range results.Succeeded() {
 		if output.Item == nil {
 			continue
 		}
@@ -411,7 +419,7 @@ func (m *IntegrationActionManager) RunPerItemRoutable(ctx context.Context, actio
 	}
 
 	return IntegrationOutput{
-		ItemsByOutputIndex: nodeItemsByOutputIndex,
+		ItemsByOutputIndex: results.SetFailedItems(nodeItemsByOutputIndex, params.NodeID, allItems),
 	}, nil
 }
 
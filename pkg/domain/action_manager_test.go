@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testActionType IntegrationActionType = "test_action"
+
+func newPerItemInput(items []Item, options PerItemOptions) IntegrationInput {
+	return IntegrationInput{
+		NodeID:            "node",
+		ItemsByInputIndex: NewNodeItemsMap(0, "previous", items),
+		ActionType:        testActionType,
+		PerItemOptions:    options,
+	}
+}
+
+func TestIntegrationActionManager_RunPerItem_PreservesOrder(t *testing.T) {
+	var running, maxRunning atomic.Int32
+
+	manager := NewIntegrationActionManager().AddPerItem(testActionType, func(ctx context.Context, params IntegrationInput, item Item) (Item, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+
+		index := item.(int)
+
+		// Later items finish first to make sure the output does not follow completion order.
+		time.Sleep(time.Duration(10-index) * time.Millisecond)
+
+		return index * 2, nil
+	})
+
+	items := []Item{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	output, err := manager.Run(context.Background(), testActionType, newPerItemInput(items, PerItemOptions{Concurrency: 4}))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	outputItems := output.ItemsByOutputIndex[0].Items
+	if len(outputItems) != len(items) {
+		t.Fatalf("got %d items, want %d", len(outputItems), len(items))
+	}
+
+	for i, outputItem := range outputItems {
+		if outputItem != i*2 {
+			t.Errorf("item %d = %v, want %v", i, outputItem, i*2)
+		}
+	}
+
+	if maxRunning.Load() > 4 {
+		t.Errorf("max concurrent items = %d, want at most 4", maxRunning.Load())
+	}
+
+	if maxRunning.Load() < 2 {
+		t.Errorf("max concurrent items = %d, want items to run concurrently", maxRunning.Load())
+	}
+}
+
+func TestIntegrationActionManager_RunPerItem_ReturnsError(t *testing.T) {
+	itemErr := errors.New("item failed")
+
+	manager := NewIntegrationActionManager().AddPerItemRoutable(testActionType, func(ctx context.Context, params IntegrationInput, item Item) (RoutableOutput, error) {
+		if item.(int) == 3 {
+			return RoutableOutput{}, itemErr
+		}
+
+		return RoutableOutput{Item: item}, nil
+	})
+
+	items := []Item{0, 1, 2, 3, 4, 5}
+
+	for _, concurrency := range []int{1, 3} {
+		_, err := manager.Run(context.Background(), testActionType, newPerItemInput(items, PerItemOptions{Concurrency: concurrency}))
+		if !errors.Is(err, itemErr) {
+			t.Errorf("concurrency %d: Run() error = %v, want %v", concurrency, err, itemErr)
+		}
+	}
+}
+
+func TestIntegrationActionManager_RunPerItem_RateLimit(t *testing.T) {
+	manager := NewIntegrationActionManager().AddPerItemMulti(testActionType, func(ctx context.Context, params IntegrationInput, item Item) ([]Item, error) {
+		return []Item{item, item}, nil
+	})
+
+	items := []Item{0, 1, 2, 3, 4}
+
+	startedAt := time.Now()
+
+	output, err := manager.Run(context.Background(), testActionType, newPerItemInput(items, PerItemOptions{Concurrency: 5, RateLimit: 50}))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// The first item starts right away, the remaining four wait 20ms each.
+	if elapsed := time.Since(startedAt); elapsed < 70*time.Millisecond {
+		t.Errorf("Run() took %v, want rate limit to slow it down", elapsed)
+	}
+
+	if got := len(output.ItemsByOutputIndex[0].Items); got != 10 {
+		t.Errorf("got %d items, want 10", got)
+	}
+}
+
+func TestIntegrationActionManager_RunPerItem_ContinueOnError(t *testing.T) {
+	manager := NewIntegrationActionManager().AddPerItem(testActionType, func(ctx context.Context, params IntegrationInput, item Item) (Item, error) {
+		if item.(int)%2 == 1 {
+			return nil, errors.New("odd item")
+		}
+
+		return item, nil
+	})
+
+	items := []Item{0, 1, 2, 3, 4}
+
+	for _, concurrency := range []int{1, 3} {
+		output, err := manager.Run(context.Background(), testActionType, newPerItemInput(items, PerItemOptions{
+			Concurrency:     concurrency,
+			ContinueOnError: true,
+		}))
+		if err != nil {
+			t.Fatalf("concurrency %d: Run() error = %v", concurrency, err)
+		}
+
+		succeeded := output.ItemsByOutputIndex[0].Items
+		if len(succeeded) != 3 || succeeded[0] != 0 || succeeded[1] != 2 || succeeded[2] != 4 {
+			t.Errorf("concurrency %d: succeeded items = %v, want [0 2 4]", concurrency, succeeded)
+		}
+
+		failed := output.ItemsByOutputIndex[ItemErrorOutputIndex].Items
+		if len(failed) != 2 {
+			t.Fatalf("concurrency %d: got %d failed items, want 2", concurrency, len(failed))
+		}
+
+		for i, want := range []int{1, 3} {
+			failedItem, ok := failed[i].(FailedItem)
+			if !ok {
+				t.Fatalf("concurrency %d: failed item is %T, want FailedItem", concurrency, failed[i])
+			}
+
+			if failedItem.Item != want || failedItem.ErrorMessage != "odd item" {
+				t.Errorf("concurrency %d: failed item = %+v, want item %d with error message", concurrency, failedItem, want)
+			}
+		}
+	}
+}
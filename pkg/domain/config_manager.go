@@ -36,6 +36,74 @@ type ExecutorConfig struct {
 	SkipWorkspaceAssignments    bool                  `mapstructure:"skip_workspace_assignments"`
 
 	LastConnected string `mapstructure:"last_connected"`
+
+	// Saves the state of running executions after every node so they can be resumed
+	// after a restart. Checkpoints are kept in CheckpointDir, which is
+	// $HOME/.flowbaker/checkpoints when empty.
+	EnableCheckpoints bool   `mapstructure:"enable_checkpoints"`
+	CheckpointDir     string `mapstructure:"checkpoint_dir"`
+
+	// OTLP/HTTP endpoint traces are exported to, e.g. http://localhost:4318. The standard
+	// OTEL_EXPORTER_OTLP_* variables are used when empty, tracing is off without either.
+	TracingEndpoint string `mapstructure:"tracing_endpoint"`
+
+	// Keeps finished executions with their item payloads in a local database that can be
+	// queried through the executor API. The database is $HOME/.flowbaker/history.db when
+	// HistoryPath is empty, retention settings of zero keep executions forever.
+	EnableLocalHistory   bool   `mapstructure:"enable_local_history"`
+	HistoryPath          string `mapstructure:"history_path"`
+	HistoryRetentionDays int    `mapstructure:"history_retention_days"`
+	HistoryMaxExecutions int    `mapstructure:"history_max_executions"`
+
+	// Limits of executions running at the same time, zero is unlimited. Executions over
+	// a limit wait in a queue of MaxQueuedExecutions and are rejected when it is full.
+	MaxConcurrentExecutions             int `mapstructure:"max_concurrent_executions"`
+	MaxConcurrentExecutionsPerWorkspace int `mapstructure:"max_concurrent_executions_per_workspace"`
+	MaxConcurrentExecutionsPerWorkflow  int `mapstructure:"max_concurrent_executions_per_workflow"`
+	MaxQueuedExecutions                 int `mapstructure:"max_queued_executions"`
+
+	// Seconds the executor waits on shutdown for running executions to finish before it
+	// hands the remaining ones off to the platform.
+	ShutdownGracePeriodSeconds int `mapstructure:"shutdown_grace_period_seconds"`
+
+	// Store for outputs of nodes with caching enabled, one of "memory", "disk" or
+	// "redis". Caching is off when empty. NodeOutputCacheSize limits the entries kept in
+	// memory and NodeOutputCacheDir is $HOME/.flowbaker/cache when empty.
+	NodeOutputCache         string `mapstructure:"node_output_cache"`
+	NodeOutputCacheSize     int    `mapstructure:"node_output_cache_size"`
+	NodeOutputCacheDir      string `mapstructure:"node_output_cache_dir"`
+	NodeOutputCacheRedisURL string `mapstructure:"node_output_cache_redis_url"`
+
+	// Where node outputs larger than PayloadSpillThresholdBytes are written instead of
+	// being kept in memory, "disk" or "storage" for the workspace storage of the platform.
+	// Outputs stay in memory when empty. PayloadSpillDir is $HOME/.flowbaker/payloads when
+	// empty, spilled payloads on disk are deleted after PayloadSpillRetentionDays.
+	PayloadSpill               string `mapstructure:"payload_spill"`
+	PayloadSpillThresholdBytes int64  `mapstructure:"payload_spill_threshold_bytes"`
+	PayloadSpillDir            string `mapstructure:"payload_spill_dir"`
+	PayloadSpillRetentionDays  int    `mapstructure:"payload_spill_retention_days"`
+
+	// Event sinks receive the execution and stream events of every execution next to the
+	// platform, each sink is off when its destination is empty. EventSinkFile is a path
+	// events are appended to as newline delimited JSON, Kafka brokers are comma separated
+	// in the environment and NATS events are published to <subject>.<event type>.
+	EventSinkFile         string   `mapstructure:"event_sink_file"`
+	EventSinkWebhookURL   string   `mapstructure:"event_sink_webhook_url"`
+	EventSinkKafkaBrokers []string `mapstructure:"event_sink_kafka_brokers"`
+	EventSinkKafkaTopic   string   `mapstructure:"event_sink_kafka_topic"`
+	EventSinkNATSURL      string   `mapstructure:"event_sink_nats_url"`
+	EventSinkNATSSubject  string   `mapstructure:"event_sink_nats_subject"`
+
+	// Serves executor metrics on /metrics in the Prometheus text format, scrapers have to
+	// send MetricsToken as a bearer token when it is set. Node metrics are labeled by
+	// workflow and integration, MetricsNodeLabels adds the node ID. Series that were not
+	// updated for MetricsSeriesTTLMinutes are dropped and each metric keeps at most
+	// MetricsMaxSeries, zero is unlimited for both.
+	EnableMetrics           bool   `mapstructure:"enable_metrics"`
+	MetricsToken            string `mapstructure:"metrics_token"`
+	MetricsNodeLabels       bool   `mapstructure:"metrics_node_labels"`
+	MetricsSeriesTTLMinutes int    `mapstructure:"metrics_series_ttl_minutes"`
+	MetricsMaxSeries        int    `mapstructure:"metrics_max_series"`
 }
 
 func (c ExecutorConfig) Keys() CryptoKeys {
@@ -89,20 +157,51 @@ func NewConfigManager() (ConfigManager, error) {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
 	envMappings := map[string]string{
-		"executor_id":                     "FLOWBAKER_EXECUTOR_ID",
-		"executor_name":                   "FLOWBAKER_EXECUTOR_NAME",
-		"address":                         "FLOWBAKER_EXECUTOR_ADDRESS",
-		"api_base_url":                    "FLOWBAKER_API_URL",
-		"x25519_private_key":              "FLOWBAKER_X25519_PRIVATE_KEY",
-		"x25519_public_key":               "FLOWBAKER_X25519_PUBLIC_KEY",
-		"ed25519_private_key":             "FLOWBAKER_ED25519_PRIVATE_KEY",
-		"ed25519_public_key":              "FLOWBAKER_ED25519_PUBLIC_KEY",
-		"static_api_signature_public_key": "STATIC_API_SIGNATURE_PUBLIC_KEY",
-		"setup_complete":                  "FLOWBAKER_SETUP_COMPLETE",
-		"enable_workspace_registration":   "FLOWBAKER_ENABLE_WORKSPACE_REGISTRATION",
-		"enable_static_passcode":          "FLOWBAKER_ENABLE_STATIC_PASSCODE",
-		"static_passcode":                 "FLOWBAKER_STATIC_PASSCODE",
-		"skip_workspace_assignments":      "FLOWBAKER_SKIP_WORKSPACE_ASSIGNMENTS",
+		"executor_id":                             "FLOWBAKER_EXECUTOR_ID",
+		"executor_name":                           "FLOWBAKER_EXECUTOR_NAME",
+		"address":                                 "FLOWBAKER_EXECUTOR_ADDRESS",
+		"api_base_url":                            "FLOWBAKER_API_URL",
+		"x25519_private_key":                      "FLOWBAKER_X25519_PRIVATE_KEY",
+		"x25519_public_key":                       "FLOWBAKER_X25519_PUBLIC_KEY",
+		"ed25519_private_key":                     "FLOWBAKER_ED25519_PRIVATE_KEY",
+		"ed25519_public_key":                      "FLOWBAKER_ED25519_PUBLIC_KEY",
+		"static_api_signature_public_key":         "STATIC_API_SIGNATURE_PUBLIC_KEY",
+		"setup_complete":                          "FLOWBAKER_SETUP_COMPLETE",
+		"enable_workspace_registration":           "FLOWBAKER_ENABLE_WORKSPACE_REGISTRATION",
+		"enable_static_passcode":                  "FLOWBAKER_ENABLE_STATIC_PASSCODE",
+		"static_passcode":                         "FLOWBAKER_STATIC_PASSCODE",
+		"skip_workspace_assignments":              "FLOWBAKER_SKIP_WORKSPACE_ASSIGNMENTS",
+		"enable_checkpoints":                      "FLOWBAKER_ENABLE_CHECKPOINTS",
+		"checkpoint_dir":                          "FLOWBAKER_CHECKPOINT_DIR",
+		"tracing_endpoint":                        "FLOWBAKER_TRACING_ENDPOINT",
+		"enable_local_history":                    "FLOWBAKER_ENABLE_LOCAL_HISTORY",
+		"history_path":                            "FLOWBAKER_HISTORY_PATH",
+		"history_retention_days":                  "FLOWBAKER_HISTORY_RETENTION_DAYS",
+		"history_max_executions":                  "FLOWBAKER_HISTORY_MAX_EXECUTIONS",
+		"max_concurrent_executions":               "FLOWBAKER_MAX_CONCURRENT_EXECUTIONS",
+		"max_concurrent_executions_per_workspace": "FLOWBAKER_MAX_CONCURRENT_EXECUTIONS_PER_WORKSPACE",
+		"max_concurrent_executions_per_workflow":  "FLOWBAKER_MAX_CONCURRENT_EXECUTIONS_PER_WORKFLOW",
+		"max_queued_executions":                   "FLOWBAKER_MAX_QUEUED_EXECUTIONS",
+		"shutdown_grace_period_seconds":           "FLOWBAKER_SHUTDOWN_GRACE_PERIOD_SECONDS",
+		"node_output_cache":                       "FLOWBAKER_NODE_OUTPUT_CACHE",
+		"node_output_cache_size":                  "FLOWBAKER_NODE_OUTPUT_CACHE_SIZE",
+		"node_output_cache_dir":                   "FLOWBAKER_NODE_OUTPUT_CACHE_DIR",
+		"node_output_cache_redis_url":             "FLOWBAKER_NODE_OUTPUT_CACHE_REDIS_URL",
+		"payload_spill":                           "FLOWBAKER_PAYLOAD_SPILL",
+		"payload_spill_threshold_bytes":           "FLOWBAKER_PAYLOAD_SPILL_THRESHOLD_BYTES",
+		"payload_spill_dir":                       "FLOWBAKER_PAYLOAD_SPILL_DIR",
+		"payload_spill_retention_days":            "FLOWBAKER_PAYLOAD_SPILL_RETENTION_DAYS",
+		"event_sink_file":                         "FLOWBAKER_EVENT_SINK_FILE",
+		"event_sink_webhook_url":                  "FLOWBAKER_EVENT_SINK_WEBHOOK_URL",
+		"event_sink_kafka_brokers":                "FLOWBAKER_EVENT_SINK_KAFKA_BROKERS",
+		"event_sink_kafka_topic":                  "FLOWBAKER_EVENT_SINK_KAFKA_TOPIC",
+		"event_sink_nats_url":                     "FLOWBAKER_EVENT_SINK_NATS_URL",
+		"event_sink_nats_subject":                 "FLOWBAKER_EVENT_SINK_NATS_SUBJECT",
+		"enable_metrics":                          "FLOWBAKER_ENABLE_METRICS",
+		"metrics_token":                           "FLOWBAKER_METRICS_TOKEN",
+		"metrics_node_labels":                     "FLOWBAKER_METRICS_NODE_LABELS",
+		"metrics_series_ttl_minutes":              "FLOWBAKER_METRICS_SERIES_TTL_MINUTES",
+		"metrics_max_series":                      "FLOWBAKER_METRICS_MAX_SERIES",
 	}
 
 	for configKey, envVar := range envMappings {
@@ -175,6 +274,37 @@ func (m *configManager) SaveConfig(ctx context.Context, config ExecutorConfig) e
 	m.viper.Set("static_passcode", config.StaticPasscode)
 	m.viper.Set("skip_workspace_assignments", config.SkipWorkspaceAssignments)
 	m.viper.Set("last_connected", config.LastConnected)
+	m.viper.Set("enable_checkpoints", config.EnableCheckpoints)
+	m.viper.Set("checkpoint_dir", config.CheckpointDir)
+	m.viper.Set("tracing_endpoint", config.TracingEndpoint)
+	m.viper.Set("enable_local_history", config.EnableLocalHistory)
+	m.viper.Set("history_path", config.HistoryPath)
+	m.viper.Set("history_retention_days", config.HistoryRetentionDays)
+	m.viper.Set("history_max_executions", config.HistoryMaxExecutions)
+	m.viper.Set("max_concurrent_executions", config.MaxConcurrentExecutions)
+	m.viper.Set("max_concurrent_executions_per_workspace", config.MaxConcurrentExecutionsPerWorkspace)
+	m.viper.Set("max_concurrent_executions_per_workflow", config.MaxConcurrentExecutionsPerWorkflow)
+	m.viper.Set("max_queued_executions", config.MaxQueuedExecutions)
+	m.viper.Set("shutdown_grace_period_seconds", config.ShutdownGracePeriodSeconds)
+	m.viper.Set("node_output_cache", config.NodeOutputCache)
+	m.viper.Set("node_output_cache_size", config.NodeOutputCacheSize)
+	m.viper.Set("node_output_cache_dir", config.NodeOutputCacheDir)
+	m.viper.Set("node_output_cache_redis_url", config.NodeOutputCacheRedisURL)
+	m.viper.Set("payload_spill", config.PayloadSpill)
+	m.viper.Set("payload_spill_threshold_bytes", config.PayloadSpillThresholdBytes)
+	m.viper.Set("payload_spill_dir", config.PayloadSpillDir)
+	m.viper.Set("payload_spill_retention_days", config.PayloadSpillRetentionDays)
+	m.viper.Set("event_sink_file", config.EventSinkFile)
+	m.viper.Set("event_sink_webhook_url", config.EventSinkWebhookURL)
+	m.viper.Set("event_sink_kafka_brokers", config.EventSinkKafkaBrokers)
+	m.viper.Set("event_sink_kafka_topic", config.EventSinkKafkaTopic)
+	m.viper.Set("event_sink_nats_url", config.EventSinkNATSURL)
+	m.viper.Set("event_sink_nats_subject", config.EventSinkNATSSubject)
+	m.viper.Set("enable_metrics", config.EnableMetrics)
+	m.viper.Set("metrics_token", config.MetricsToken)
+	m.viper.Set("metrics_node_labels", config.MetricsNodeLabels)
+	m.viper.Set("metrics_series_ttl_minutes", config.MetricsSeriesTTLMinutes)
+	m.viper.Set("metrics_max_series", config.MetricsMaxSeries)
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -222,4 +352,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("enable_workspace_registration", true)
 	v.SetDefault("enable_static_passcode", false)
 	v.SetDefault("skip_workspace_assignments", false)
+	v.SetDefault("enable_checkpoints", true)
+	v.SetDefault("shutdown_grace_period_seconds", 30)
+	v.SetDefault("payload_spill_retention_days", 7)
+	v.SetDefault("event_sink_kafka_topic", "flowbaker.events")
+	v.SetDefault("event_sink_nats_subject", "flowbaker.events")
+	v.SetDefault("metrics_series_ttl_minutes", 60)
+	v.SetDefault("metrics_max_series", 10000)
 }
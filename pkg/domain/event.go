@@ -76,6 +76,7 @@ type EventType string
 const (
 	NodeExecuted               EventType = "node_executed"
 	NodeFailed                 EventType = "node_failed"
+	NodeRetried                EventType = "node_retried"
 	NodeExecutionStarted       EventType = "node_execution_started"
 	WorkflowExecutionStarted   EventType = "workflow_execution_started"
 	WorkflowExecutionCompleted EventType = "workflow_execution_completed"
@@ -140,6 +141,7 @@ type NodeFailedEvent struct {
 	IsReExecution       bool   `json:"is_re_execution"`
 	IsTesting           bool   `json:"is_testing"`
 	IsFromErrorTrigger  bool   `json:"is_from_error_trigger"`
+	IsTimeout           bool   `json:"is_timeout,omitempty"`
 }
 
 func (e *NodeFailedEvent) GetType() EventType {
@@ -269,12 +271,17 @@ func NewStreamEventPublisher(ctx context.Context, workspaceID string, client flo
 		return nil, errors.New("stream event publisher: client is required")
 	}
 
+	flowbakerClient, ok := client.(*flowbaker.Client)
+	if !ok {
+		return noopStreamEventPublisher{}, nil
+	}
+
 	streamCtx, cancelCtx := context.WithCancel(ctx)
 
 	pipeReader, pipeWriter := io.Pipe()
 
 	writer, err := flowbaker.NewEventStreamWriter(flowbaker.NewEventStreamWriterParams{
-		Client:      client.(*flowbaker.Client),
+		Client:      flowbakerClient,
 		WorkspaceID: workspaceID,
 		Ctx:         streamCtx,
 		CancelCtx:   cancelCtx,
@@ -313,3 +320,19 @@ func (p *streamEventPublisher) Close() error {
 
 	return nil
 }
+
+// noopStreamEventPublisher drops stream events, it is used when the client cannot open
+// an event stream to the platform.
+type noopStreamEventPublisher struct{}
+
+func (noopStreamEventPublisher) Initialize() error {
+	return nil
+}
+
+func (noopStreamEventPublisher) PublishStreamEvent(ctx context.Context, event StreamEvent) error {
+	return nil
+}
+
+func (noopStreamEventPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventSink receives the events of every execution of the executor, independent of the
+// platform. Sinks are used to feed executions into systems like data warehouses or
+// alerting, so a failed send is logged and never fails the execution.
+type EventSink interface {
+	Send(ctx context.Context, event SinkEvent) error
+	Close() error
+}
+
+type SinkEventKind string
+
+const (
+	SinkEventKindExecution SinkEventKind = "execution"
+	SinkEventKindStream    SinkEventKind = "stream"
+)
+
+type SinkEvent struct {
+	Kind        SinkEventKind `json:"kind"`
+	Type        string        `json:"type"`
+	WorkspaceID string        `json:"workspace_id"`
+	WorkflowID  string        `json:"workflow_id"`
+	ExecutionID string        `json:"execution_id"`
+	Timestamp   time.Time     `json:"timestamp"`
+	Data        any           `json:"data"`
+}
@@ -3,12 +3,42 @@ package domain
 import "time"
 
 type NodeItems struct {
-	FromNodeID string `json:"from_node_id"`
-	Items      []Item `json:"items"`
+	FromNodeID string    `json:"from_node_id"`
+	Items      []Item    `json:"items"`
+	Ref        *ItemsRef `json:"ref,omitempty"` // Set instead of Items when the items were spilled
+}
+
+// ItemsRef points to items that were too large to keep in memory and were written to a
+// payload store instead. Count and SizeInBytes describe the items without loading them.
+type ItemsRef struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	Count       int    `json:"count"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+}
+
+// Count returns the number of items, also when they were spilled.
+func (n NodeItems) Count() int {
+	if n.Ref != nil {
+		return n.Ref.Count
+	}
+
+	return len(n.Items)
 }
 
 type NodeItemsMap map[int]NodeItems
 
+// HasRefs reports whether the items of any index were spilled.
+func (m NodeItemsMap) HasRefs() bool {
+	for _, nodeItems := range m {
+		if nodeItems.Ref != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func NewNodeItemsMap(index int, fromNodeID string, items []Item) NodeItemsMap {
 	return NodeItemsMap{
 		index: {FromNodeID: fromNodeID, Items: items},
@@ -24,6 +54,13 @@ type ErrorItem struct {
 	ErrorMessage string `json:"error_message"`
 }
 
+// FailedItem is sent to ItemErrorOutputIndex for every item a per item action failed
+// for when the node continues on item errors.
+type FailedItem struct {
+	Item         Item   `json:"item"`
+	ErrorMessage string `json:"error_message"`
+}
+
 func NewErrorIntegrationOutput(err error) IntegrationOutput {
 	return IntegrationOutput{
 		ItemsByOutputIndex: NewNodeItemsMap(0, "", []Item{ErrorItem{
@@ -40,6 +77,8 @@ type NodeExecutionEntry struct {
 	Error              string
 	Timestamp          int64
 	ExecutionOrder     int
+	Attempt            int
+	IsCached           bool
 }
 
 type NodeExecution struct {
@@ -72,12 +111,14 @@ type QueuedTaskSnapshot struct {
 }
 
 type ExecutorStateSnapshot struct {
-	PauseNodeID            string                `json:"pause_node_id"`
-	TriggerNodeID          string                `json:"trigger_node_id"`
-	PauseNodeOutput        NodeItemsMap          `json:"pause_node_output"`
-	WaitingTasks           []WaitingTaskSnapshot `json:"waiting_tasks"`
-	QueuedTasks            []QueuedTaskSnapshot  `json:"queued_tasks"`
-	ExecutedNodes          []string              `json:"executed_nodes"`
-	ExecutionCountByNodeID map[string]int        `json:"execution_count_by_node_id"`
-	LastEventOrder         int                   `json:"last_event_order"`
+	PauseNodeID            string                  `json:"pause_node_id"`
+	TriggerNodeID          string                  `json:"trigger_node_id"`
+	PauseNodeOutput        NodeItemsMap            `json:"pause_node_output"`
+	WaitingTasks           []WaitingTaskSnapshot   `json:"waiting_tasks"`
+	QueuedTasks            []QueuedTaskSnapshot    `json:"queued_tasks"`
+	ExecutedNodes          []string                `json:"executed_nodes"`
+	ExecutionCountByNodeID map[string]int          `json:"execution_count_by_node_id"`
+	LastEventOrder         int                     `json:"last_event_order"`
+	DryRun                 bool                    `json:"dry_run,omitempty"`
+	NodeOutputs            map[string]NodeItemsMap `json:"node_outputs,omitempty"` // Last output of each executed node, read by expressions
 }
@@ -2,11 +2,19 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 )
 
 type WorkflowExecutionContextKey struct{}
 
+// MaxWorkflowCallDepth is the number of workflows that can be called from one another
+// in a chain, it stops workflows that call themselves from running forever.
+const MaxWorkflowCallDepth = 10
+
+var ErrMaxCallDepthExceeded = fmt.Errorf("workflows can call each other at most %d levels deep", MaxWorkflowCallDepth)
+
 type SignalType string
 
 const (
@@ -40,31 +48,141 @@ type WorkflowExecutionContext struct {
 	IsFromErrorTrigger  bool
 	IsTesting           bool
 	TriggerNode         WorkflowNode
-	signals             []ExecutionSignal
+	CallDepth           int // Number of workflows the execution was called through, zero when started by a trigger
+	WorkflowName        string
+	StartedAt           time.Time
+	NodeOutputs         NodeOutputReader // Optional, expressions can not read the outputs of other nodes when nil
+	StrictTemplates     bool             // Failed {{ }} expressions fail the binding instead of being treated leniently
+	signals             []nodeSignal
+	mutex               sync.Mutex
+}
+
+type nodeSignal struct {
+	nodeID string
+	signal ExecutionSignal
 }
 
 func (c *WorkflowExecutionContext) EmitSignal(s ExecutionSignal) {
-	c.signals = append(c.signals, s)
+	c.EmitNodeSignal("", s)
+}
+
+// EmitNodeSignal records a signal on behalf of a node, so that the executor can tell
+// which node emitted it when several nodes run at the same time.
+func (c *WorkflowExecutionContext) EmitNodeSignal(nodeID string, s ExecutionSignal) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.signals = append(c.signals, nodeSignal{nodeID: nodeID, signal: s})
 }
 
 func (c *WorkflowExecutionContext) DrainSignals() []ExecutionSignal {
-	out := c.signals
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	out := make([]ExecutionSignal, 0, len(c.signals))
+	for _, s := range c.signals {
+		out = append(out, s.signal)
+	}
+
 	c.signals = nil
+
+	return out
+}
+
+// DrainNodeSignals returns the signals emitted by the given node and the signals that
+// were emitted without a node.
+func (c *WorkflowExecutionContext) DrainNodeSignals(nodeID string) []ExecutionSignal {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	out := []ExecutionSignal{}
+	remaining := []nodeSignal{}
+
+	for _, s := range c.signals {
+		if s.nodeID == "" || s.nodeID == nodeID {
+			out = append(out, s.signal)
+			continue
+		}
+
+		remaining = append(remaining, s)
+	}
+
+	c.signals = remaining
+
 	return out
 }
 
 func (c *WorkflowExecutionContext) SetResponsePayload(payload Payload) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.ResponsePayload = payload
 }
 
 func (c *WorkflowExecutionContext) SetResponseHeaders(headers map[string][]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.ResponseHeaders = headers
 }
 
 func (c *WorkflowExecutionContext) SetResponseStatusCode(statusCode int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.ResponseStatusCode = statusCode
 }
 
+// NodeOutputReader reads the outputs of nodes that already executed in the execution,
+// so that expressions can use them without passing them through every node in between.
+type NodeOutputReader interface {
+	ReadNodeOutput(ctx context.Context, nameOrID string) (NodeOutput, bool, error)
+}
+
+// NodeOutput is the output of the last run of a node.
+type NodeOutput struct {
+	NodeID             string
+	NodeName           string
+	ItemsByOutputIndex NodeItemsMap
+}
+
+type NodeOutputCacheKey struct{}
+
+// NodeOutputCache keeps the node outputs expressions read while a node runs, so that
+// they are resolved once per node run instead of once for every item.
+type NodeOutputCache struct {
+	mutex   sync.Mutex
+	outputs map[string]any
+}
+
+func NewContextWithNodeOutputCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, NodeOutputCacheKey{}, &NodeOutputCache{
+		outputs: map[string]any{},
+	})
+}
+
+func GetNodeOutputCache(ctx context.Context) (*NodeOutputCache, bool) {
+	cache, ok := ctx.Value(NodeOutputCacheKey{}).(*NodeOutputCache)
+
+	return cache, ok
+}
+
+func (c *NodeOutputCache) Get(nameOrID string) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	output, ok := c.outputs[nameOrID]
+
+	return output, ok
+}
+
+func (c *NodeOutputCache) Set(nameOrID string, output any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.outputs[nameOrID] = output
+}
+
 type NewContextWithWorkflowExecutionContextParams struct {
 	UserID              *string
 	InputPayload        Payload
@@ -77,6 +195,11 @@ type NewContextWithWorkflowExecutionContextParams struct {
 	IsFromErrorTrigger  bool
 	IsTesting           bool
 	TriggerNode         WorkflowNode
+	CallDepth           int
+	WorkflowName        string
+	StartedAt           time.Time
+	NodeOutputs         NodeOutputReader
+	StrictTemplates     bool
 }
 
 func NewContextWithWorkflowExecutionContext(ctx context.Context, params NewContextWithWorkflowExecutionContextParams) context.Context {
@@ -95,6 +218,11 @@ func NewContextWithWorkflowExecutionContext(ctx context.Context, params NewConte
 		IsFromErrorTrigger:  params.IsFromErrorTrigger,
 		IsTesting:           params.IsTesting,
 		TriggerNode:         params.TriggerNode,
+		CallDepth:           params.CallDepth,
+		WorkflowName:        params.WorkflowName,
+		StartedAt:           params.StartedAt,
+		NodeOutputs:         params.NodeOutputs,
+		StrictTemplates:     params.StrictTemplates,
 	}
 
 	return context.WithValue(ctx, WorkflowExecutionContextKey{}, workflowExecutionContext)
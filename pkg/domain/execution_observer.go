@@ -23,6 +23,7 @@ const (
 	ExecutionEventTypeNodeExecutionStarted       ExecutionEventType = "node_execution_started"
 	ExecutionEventTypeNodeExecutionCompleted     ExecutionEventType = "node_execution_completed"
 	ExecutionEventTypeNodeExecutionFailed        ExecutionEventType = "node_execution_failed"
+	ExecutionEventTypeNodeExecutionRetried       ExecutionEventType = "node_execution_retried"
 	ExecutionEventTypeWorkflowExecutionStarted   ExecutionEventType = "workflow_execution_started"
 	ExecutionEventTypeWorkflowExecutionCompleted ExecutionEventType = "workflow_execution_completed"
 	ExecutionEventTypeWorkflowExecutionPaused    ExecutionEventType = "workflow_execution_paused"
@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// AdmissionLimits bound the number of executions running at the same time, a limit of
+// zero is unlimited.
+type AdmissionLimits struct {
+	MaxConcurrentExecutions   int
+	MaxConcurrentPerWorkspace int
+	MaxConcurrentPerWorkflow  int
+	// MaxQueuedExecutions is the number of executions that wait for a free slot, with
+	// zero an execution over a limit is rejected right away.
+	MaxQueuedExecutions int
+}
+
+// ErrExecutorAtCapacity is returned for executions that are over a limit while the
+// queue is full.
+var ErrExecutorAtCapacity = errors.New("executor is at capacity")
+
+type AdmissionStatus struct {
+	Running                 int
+	Queued                  int
+	MaxConcurrentExecutions int
+	MaxQueuedExecutions     int
+	Draining                bool
+}
+
+type queuedExecution struct {
+	execution ActiveExecution
+	isTesting bool
+	admitted  chan bool
+}
+
+// admissionController registers executions in the registry while they are within the
+// limits and queues the others. Testing runs are queued ahead of the other executions,
+// since someone is waiting for them in the editor.
+type admissionController struct {
+	limits   AdmissionLimits
+	registry *ExecutionRegistry
+
+	mutex    sync.Mutex
+	queue    []*queuedExecution
+	draining bool
+}
+
+func newAdmissionController(limits AdmissionLimits, registry *ExecutionRegistry) *admissionController {
+	return &admissionController{
+		limits:   limits,
+		registry: registry,
+	}
+}
+
+// Admit registers the execution and returns a nil channel when it can start right
+// away. Otherwise the execution is queued and the returned channel receives true once
+// it has been registered, or false when it was removed from the queue.
+func (a *admissionController) Admit(execution ActiveExecution, isTesting bool) (<-chan bool, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.draining {
+		return nil, ErrExecutorDraining
+	}
+
+	// Queued executions never fit, dispatch starts them as soon as they do, so an
+	// execution that fits does not overtake anyone.
+	if a.fits(execution) {
+		a.registry.RegisterExecution(execution)
+		return nil, nil
+	}
+
+	if len(a.queue) >= a.limits.MaxQueuedExecutions {
+		return nil, ErrExecutorAtCapacity
+	}
+
+	queued := &queuedExecution{
+		execution: execution,
+		isTesting: isTesting,
+		admitted:  make(chan bool, 1),
+	}
+
+	position := len(a.queue)
+	if isTesting {
+		position = 0
+		for position < len(a.queue) && a.queue[position].isTesting {
+			position++
+		}
+	}
+
+	a.queue = slices.Insert(a.queue, position, queued)
+
+	return queued.admitted, nil
+}
+
+// Release unregisters a finished execution and starts the queued executions that fit
+// in the freed slot.
+func (a *admissionController) Release(executionID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.registry.UnregisterExecution(executionID)
+	a.dispatch()
+}
+
+// Dequeue removes an execution that has not started yet from the queue.
+func (a *admissionController) Dequeue(executionID string) (ActiveExecution, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for i, queued := range a.queue {
+		if queued.execution.ExecutionID == executionID {
+			a.queue = slices.Delete(a.queue, i, i+1)
+			queued.admitted <- false
+
+			return queued.execution, true
+		}
+	}
+
+	return ActiveExecution{}, false
+}
+
+// StartDraining makes Admit reject every execution from now on.
+func (a *admissionController) StartDraining() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.draining = true
+}
+
+// AdmitQueued registers all queued executions regardless of the limits.
+func (a *admissionController) AdmitQueued() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, queued := range a.queue {
+		a.registry.RegisterExecution(queued.execution)
+		queued.admitted <- true
+	}
+
+	a.queue = nil
+}
+
+func (a *admissionController) Status() AdmissionStatus {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return AdmissionStatus{
+		Running:                 a.registry.Count(),
+		Queued:                  len(a.queue),
+		MaxConcurrentExecutions: a.limits.MaxConcurrentExecutions,
+		MaxQueuedExecutions:     a.limits.MaxQueuedExecutions,
+		Draining:                a.draining,
+	}
+}
+
+// dispatch registers the queued executions that fit, in queue order. Executions blocked
+// by a workspace or workflow limit are skipped so they do not hold up the others.
+func (a *admissionController) dispatch() {
+	remaining := a.queue[:0]
+
+	for _, queued := range a.queue {
+		if !a.fits(queued.execution) {
+			remaining = append(remaining, queued)
+			continue
+		}
+
+		a.registry.RegisterExecution(queued.execution)
+		queued.admitted <- true
+	}
+
+	clear(a.queue[len(remaining):])
+	a.queue = remaining
+}
+
+func (a *admissionController) fits(execution ActiveExecution) bool {
+	if limit := a.limits.MaxConcurrentExecutions; limit > 0 && a.registry.Count() >= limit {
+		return false
+	}
+
+	if limit := a.limits.MaxConcurrentPerWorkspace; limit > 0 && a.registry.CountByWorkspace(execution.WorkspaceID) >= limit {
+		return false
+	}
+
+	if limit := a.limits.MaxConcurrentPerWorkflow; limit > 0 && a.registry.CountByWorkflow(execution.WorkflowID) >= limit {
+		return false
+	}
+
+	return true
+}
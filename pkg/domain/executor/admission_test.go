@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAdmissionController(t *testing.T) {
+	registry := NewExecutionRegistry()
+	admission := newAdmissionController(AdmissionLimits{
+		MaxConcurrentExecutions:  2,
+		MaxConcurrentPerWorkflow: 1,
+		MaxQueuedExecutions:      3,
+	}, &registry)
+
+	execution := func(executionID, workflowID string) ActiveExecution {
+		return ActiveExecution{ExecutionID: executionID, WorkflowID: workflowID, WorkspaceID: "ws_1"}
+	}
+
+	admit := func(executionID, workflowID string, isTesting bool) <-chan bool {
+		t.Helper()
+
+		admitted, err := admission.Admit(execution(executionID, workflowID), isTesting)
+		if err != nil {
+			t.Fatalf("Admit(%s) error = %v", executionID, err)
+		}
+
+		return admitted
+	}
+
+	isAdmitted := func(admitted <-chan bool) bool {
+		select {
+		case ok := <-admitted:
+			return ok
+		default:
+			return false
+		}
+	}
+
+	if admit("running_a", "wf_a", false) != nil {
+		t.Fatal("running_a was queued, want it admitted")
+	}
+
+	sameWorkflow := admit("queued_a", "wf_a", false)
+	if sameWorkflow == nil {
+		t.Fatal("queued_a was admitted over the workflow limit")
+	}
+
+	if admit("running_b", "wf_b", false) != nil {
+		t.Fatal("running_b was queued, want it admitted")
+	}
+
+	background := admit("queued_c", "wf_c", false)
+	testingRun := admit("queued_d", "wf_d", true)
+
+	if _, err := admission.Admit(execution("rejected", "wf_e"), false); !errors.Is(err, ErrExecutorAtCapacity) {
+		t.Fatalf("Admit() over the queue limit error = %v, want ErrExecutorAtCapacity", err)
+	}
+
+	if status := admission.Status(); status.Running != 2 || status.Queued != 3 {
+		t.Fatalf("Status() = %+v, want 2 running and 3 queued", status)
+	}
+
+	// The testing run is ahead of the queue, queued_a is still blocked by running_a.
+	admission.Release("running_b")
+
+	if !isAdmitted(testingRun) || isAdmitted(sameWorkflow) || isAdmitted(background) {
+		t.Fatal("Release() did not admit the testing run first")
+	}
+
+	// Freeing wf_a admits queued_a, which was queued before queued_c.
+	admission.Release("running_a")
+
+	if !isAdmitted(sameWorkflow) || isAdmitted(background) {
+		t.Fatal("Release() did not admit queued_a")
+	}
+
+	if _, ok := admission.Dequeue("queued_c"); !ok {
+		t.Fatal("Dequeue() did not find queued_c")
+	}
+
+	if ok, received := <-background; ok || !received {
+		t.Fatal("dequeued execution was not told it will not run")
+	}
+
+	if status := admission.Status(); status.Running != 2 || status.Queued != 0 {
+		t.Fatalf("Status() = %+v, want 2 running and none queued", status)
+	}
+
+	admission.StartDraining()
+
+	if _, err := admission.Admit(execution("draining", "wf_f"), false); !errors.Is(err, ErrExecutorDraining) {
+		t.Fatalf("Admit() while draining error = %v, want ErrExecutorDraining", err)
+	}
+}
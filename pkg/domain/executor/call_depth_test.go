@@ -0,0 +1,120 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+func TestWorkflowExecutorService_CalledWorkflowResponse(t *testing.T) {
+	callDepths := map[string]int{}
+
+	double := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		executionContext, _ := domain.GetWorkflowExecutionContext(ctx)
+		callDepths[executionContext.WorkflowExecutionID] = executionContext.CallDepth
+
+		items := []domain.Item{}
+		for _, item := range input.GetAllItems() {
+			items = append(items, item, item)
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("double", integrationFunc(double))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_called",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "double"},
+			{ID: "double", Type: domain.NodeTypeAction, IntegrationType: "double"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "double"},
+		},
+	}
+
+	tests := []struct {
+		executionID string
+		callDepth   int
+		wantPayload string
+	}{
+		{"exec_triggered", 0, ""},
+		{"exec_called", 2, `[{"id":1},{"id":1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.executionID, func(t *testing.T) {
+			result, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: tt.executionID,
+				Workflow:    workflow,
+				EventName:   "trigger",
+				PayloadJSON: `[{"id": 1}]`,
+				CallDepth:   tt.callDepth,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if callDepths[tt.executionID] != tt.callDepth {
+				t.Errorf("call depth = %d, want %d", callDepths[tt.executionID], tt.callDepth)
+			}
+
+			if string(result.Payload) != tt.wantPayload {
+				t.Errorf("payload = %q, want %q", result.Payload, tt.wantPayload)
+			}
+		})
+	}
+}
+
+func TestWorkflowExecutorService_RejectsCallDepthAboveMax(t *testing.T) {
+	ran := false
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("called", integrationFunc(func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		ran = true
+
+		return domain.IntegrationOutput{}, nil
+	}))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+	})
+
+	_, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_too_deep",
+		Workflow: domain.Workflow{
+			ID: "wf_called",
+			Nodes: []domain.WorkflowNode{
+				{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "called"},
+				{ID: "action", Type: domain.NodeTypeAction, IntegrationType: "called"},
+			},
+			Edges: []domain.WorkflowEdge{
+				{SourceNodeID: "trigger", TargetNodeID: "action"},
+			},
+		},
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+		CallDepth:   domain.MaxWorkflowCallDepth + 1,
+	})
+	if !errors.Is(err, domain.ErrMaxCallDepthExceeded) {
+		t.Errorf("Execute() error = %v, want %v", err, domain.ErrMaxCallDepthExceeded)
+	}
+
+	if ran {
+		t.Error("the workflow ran despite exceeding the call depth")
+	}
+}
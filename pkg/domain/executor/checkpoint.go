@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	executortypes "github.com/flowbaker/flowbaker/pkg/clients/flowbaker-executor"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/mappers"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExecutionCheckpoint is the state of an in-flight execution saved after each node, it
+// holds everything needed to continue the execution after the executor restarts. The
+// node that was running when the process died runs again on resume.
+type ExecutionCheckpoint struct {
+	ExecutionID       string                       `json:"execution_id"`
+	Workflow          executortypes.Workflow       `json:"workflow"`
+	TriggerNodeID     string                       `json:"trigger_node_id"`
+	PayloadJSON       string                       `json:"payload_json"`
+	UserID            *string                      `json:"user_id,omitempty"`
+	EnableEvents      bool                         `json:"enable_events"`
+	IsTestingWorkflow bool                         `json:"is_testing_workflow"`
+	CallDepth         int                          `json:"call_depth,omitempty"`
+	DryRun            bool                         `json:"dry_run,omitempty"`
+	StartedAt         time.Time                    `json:"started_at"`
+	UpdatedAt         time.Time                    `json:"updated_at"`
+	Snapshot          domain.ExecutorStateSnapshot `json:"snapshot"`
+}
+
+// ExecuteParams returns the parameters that resume the execution from the checkpoint.
+func (c ExecutionCheckpoint) ExecuteParams() ExecuteParams {
+	snapshot := c.Snapshot
+
+	return ExecuteParams{
+		ExecutionID:           c.ExecutionID,
+		UserID:                c.UserID,
+		Workflow:              mappers.ExecutorWorkflowToDomain(&c.Workflow),
+		EventName:             c.TriggerNodeID,
+		PayloadJSON:           c.PayloadJSON,
+		EnableEvents:          c.EnableEvents,
+		IsTestingWorkflow:     c.IsTestingWorkflow,
+		ExecutorStateSnapshot: &snapshot,
+		CallDepth:             c.CallDepth,
+		DryRun:                c.DryRun,
+	}
+}
+
+// ErrExecutionStopped is the cancellation cause of executions stopped by a user, other
+// cancellations mean the executor is going away and leave the checkpoint in place.
+var ErrExecutionStopped = errors.New("execution stopped")
+
+type CheckpointStore interface {
+	Save(ctx context.Context, checkpoint ExecutionCheckpoint) error
+	Delete(ctx context.Context, executionID string) error
+	List(ctx context.Context) ([]ExecutionCheckpoint, error)
+}
+
+// SaveCheckpoint stores the state of the execution between two nodes. Nothing is saved
+// while a container body runs since its scope is not part of the snapshot, or once a
+// node paused the workflow, the previous checkpoint is kept instead.
+func (w *WorkflowExecutor) SaveCheckpoint(ctx context.Context) {
+	if w.checkpointStore == nil || w.checkpoint == nil || len(w.containerScopes) > 0 || w.pauseResult != nil {
+		return
+	}
+
+	w.checkpoint.Snapshot = *w.buildExecutorStateSnapshot(ctx, w.checkpoint.TriggerNodeID)
+	w.checkpoint.UpdatedAt = time.Now()
+
+	if err := w.checkpointStore.Save(ctx, *w.checkpoint); err != nil {
+		log.Warn().Err(err).Str("execution_id", w.executionID).Msg("Failed to save execution checkpoint")
+	}
+}
+
+// DeleteCheckpoint removes the checkpoint once the execution has completed, failed or
+// been handed to the platform as paused.
+func (w *WorkflowExecutor) DeleteCheckpoint(ctx context.Context) {
+	if w.checkpointStore == nil || w.checkpoint == nil {
+		return
+	}
+
+	if err := w.checkpointStore.Delete(ctx, w.executionID); err != nil {
+		log.Warn().Err(err).Str("execution_id", w.executionID).Msg("Failed to delete execution checkpoint")
+	}
+}
+
+// IsInterrupted reports whether the execution was cancelled without being stopped while
+// its state is checkpointed, it is then left to be resumed instead of being completed.
+func (w *WorkflowExecutor) IsInterrupted(ctx context.Context) bool {
+	if w.checkpointStore == nil || ctx.Err() == nil {
+		return false
+	}
+
+	cause := context.Cause(ctx)
+
+	return !errors.Is(cause, ErrExecutionStopped) && !IsWorkflowTimeoutError(cause)
+}
+
+func (w *WorkflowExecutor) newCheckpoint(triggerNodeID string, inputPayload []byte) *ExecutionCheckpoint {
+	if w.checkpointStore == nil {
+		return nil
+	}
+
+	if w.executorStateSnapshot != nil && w.executorStateSnapshot.TriggerNodeID != "" {
+		triggerNodeID = w.executorStateSnapshot.TriggerNodeID
+	}
+
+	return &ExecutionCheckpoint{
+		ExecutionID:       w.executionID,
+		Workflow:          mappers.DomainWorkflowToExecutor(w.workflow),
+		TriggerNodeID:     triggerNodeID,
+		PayloadJSON:       string(inputPayload),
+		UserID:            w.userID,
+		EnableEvents:      w.enableEvents,
+		IsTestingWorkflow: w.IsTestingWorkflow,
+		CallDepth:         w.callDepth,
+		DryRun:            w.dryRun,
+		StartedAt:         w.WorkflowExecutionStartedAt,
+	}
+}
@@ -0,0 +1,270 @@
+package executor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type memoryCheckpointStore struct {
+	mutex       sync.Mutex
+	checkpoints map[string]executor.ExecutionCheckpoint
+	saved       []executor.ExecutionCheckpoint
+}
+
+func (s *memoryCheckpointStore) Save(ctx context.Context, checkpoint executor.ExecutionCheckpoint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.checkpoints[checkpoint.ExecutionID] = checkpoint
+	s.saved = append(s.saved, checkpoint)
+
+	return nil
+}
+
+func (s *memoryCheckpointStore) Delete(ctx context.Context, executionID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.checkpoints, executionID)
+
+	return nil
+}
+
+func (s *memoryCheckpointStore) List(ctx context.Context) ([]executor.ExecutionCheckpoint, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoints := []executor.ExecutionCheckpoint{}
+	for _, checkpoint := range s.checkpoints {
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, nil
+}
+
+func TestWorkflowExecutorService_ResumeFromCheckpoint(t *testing.T) {
+	runs := map[string]int{}
+
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		runs[input.NodeID]++
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("passthrough", integrationFunc(passthrough))
+
+	store := &memoryCheckpointStore{checkpoints: map[string]executor.ExecutionCheckpoint{}}
+	client := local.NewClient()
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+		CheckpointStore:     store,
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_checkpoint",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "passthrough"},
+			{ID: "first", Type: domain.NodeTypeAction, IntegrationType: "passthrough"},
+			{ID: "second", Type: domain.NodeTypeAction, IntegrationType: "passthrough"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "first"},
+			{SourceNodeID: "first", TargetNodeID: "second"},
+		},
+	}
+
+	_, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_1",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if checkpoints, _ := store.List(context.Background()); len(checkpoints) != 0 {
+		t.Fatalf("got %d checkpoints after completion, want none", len(checkpoints))
+	}
+
+	// Save() was called once before the trigger and once after every node, the third
+	// checkpoint is what a crash while the second node runs leaves behind.
+	if len(store.saved) != 4 {
+		t.Fatalf("got %d saved checkpoints, want 4", len(store.saved))
+	}
+
+	interrupted := store.saved[2]
+	if queued := interrupted.Snapshot.QueuedTasks; len(queued) != 1 || queued[0].NodeID != "second" {
+		t.Fatalf("interrupted checkpoint queue = %v, want node second", queued)
+	}
+
+	runs = map[string]int{}
+
+	result, err := service.Execute(context.Background(), interrupted.ExecuteParams())
+	if err != nil {
+		t.Fatalf("Execute() from checkpoint error = %v", err)
+	}
+
+	want := map[string]int{"trigger": 0, "first": 0, "second": 1}
+	for nodeID, count := range want {
+		if runs[nodeID] != count {
+			t.Errorf("node %s ran %d times on resume, want %d", nodeID, runs[nodeID], count)
+		}
+	}
+
+	if entries := result.NodeExecutionResults; len(entries) == 0 || entries[len(entries)-1].NodeID != "second" {
+		t.Errorf("resumed execution history = %v, want it to end with node second", entries)
+	}
+
+	if completed := client.GetCompletedExecutions(); len(completed) != 2 || completed[1].ExecutionID != "exec_1" {
+		t.Errorf("completed executions = %v, want exec_1 completed again", completed)
+	}
+}
+
+func TestWorkflowExecutorService_KeepsCheckpointWhenInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("shutdown", integrationFunc(func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		cancel()
+
+		return domain.IntegrationOutput{}, ctx.Err()
+	}))
+
+	store := &memoryCheckpointStore{checkpoints: map[string]executor.ExecutionCheckpoint{}}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		CheckpointStore:     store,
+	})
+
+	_, err := service.Execute(ctx, executor.ExecuteParams{
+		ExecutionID: "exec_1",
+		Workflow: domain.Workflow{
+			Nodes: []domain.WorkflowNode{
+				{ID: "trigger", Type: domain.NodeTypeTrigger},
+				{ID: "shutdown", Type: domain.NodeTypeAction, IntegrationType: "shutdown"},
+			},
+			Edges: []domain.WorkflowEdge{
+				{SourceNodeID: "trigger", TargetNodeID: "shutdown"},
+			},
+		},
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	})
+	if err == nil {
+		t.Fatal("Execute() error = nil, want the interruption")
+	}
+
+	checkpoints, _ := store.List(context.Background())
+	if len(checkpoints) != 1 {
+		t.Fatalf("got %d checkpoints, want the interrupted execution", len(checkpoints))
+	}
+
+	if queued := checkpoints[0].Snapshot.QueuedTasks; len(queued) != 1 || queued[0].NodeID != "shutdown" {
+		t.Errorf("checkpoint queue = %v, want the interrupted node to run again", queued)
+	}
+}
+
+// stoppingCheckpointStore stops the execution once the given node is queued, which is
+// between two nodes since checkpoints are saved after each node.
+type stoppingCheckpointStore struct {
+	*memoryCheckpointStore
+	nodeID string
+	stop   func()
+}
+
+func (s *stoppingCheckpointStore) Save(ctx context.Context, checkpoint executor.ExecutionCheckpoint) error {
+	for _, task := range checkpoint.Snapshot.QueuedTasks {
+		if task.NodeID == s.nodeID {
+			s.stop()
+		}
+	}
+
+	return s.memoryCheckpointStore.Save(ctx, checkpoint)
+}
+
+func TestWorkflowExecutorService_DeletesCheckpointWhenStopped(t *testing.T) {
+	var service executor.WorkflowExecutorService
+
+	secondRan := false
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("stop", integrationFunc(func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		if input.NodeID == "second" {
+			secondRan = true
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}))
+
+	checkpoints := &stoppingCheckpointStore{
+		memoryCheckpointStore: &memoryCheckpointStore{checkpoints: map[string]executor.ExecutionCheckpoint{}},
+		nodeID:                "second",
+		stop: func() {
+			if err := service.Stop(context.Background(), "exec_stop"); err != nil {
+				t.Errorf("Stop() error = %v", err)
+			}
+		},
+	}
+	history := &memoryHistoryStore{records: map[string]executor.ExecutionRecord{}}
+
+	service = executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector:   selector,
+		FlowbakerClient:       local.NewClient(),
+		CheckpointStore:       checkpoints,
+		HistoryStore:          history,
+		OrderedEventPublisher: nopEventPublisher{},
+	})
+
+	_, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_stop",
+		Workflow: domain.Workflow{
+			Nodes: []domain.WorkflowNode{
+				{ID: "trigger", Type: domain.NodeTypeTrigger},
+				{ID: "first", Type: domain.NodeTypeAction, IntegrationType: "stop"},
+				{ID: "second", Type: domain.NodeTypeAction, IntegrationType: "stop"},
+			},
+			Edges: []domain.WorkflowEdge{
+				{SourceNodeID: "trigger", TargetNodeID: "first"},
+				{SourceNodeID: "first", TargetNodeID: "second"},
+			},
+		},
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if secondRan {
+		t.Error("second ran after the execution was stopped")
+	}
+
+	if remaining, _ := checkpoints.List(context.Background()); len(remaining) != 0 {
+		t.Errorf("got %d checkpoints, want the stopped execution to be removed", len(remaining))
+	}
+
+	record, err := history.Get(context.Background(), "exec_stop")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if record.Status != executor.ExecutionStatusStopped {
+		t.Errorf("status = %s, want %s", record.Status, executor.ExecutionStatusStopped)
+	}
+}
@@ -0,0 +1,230 @@
+package executor
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+const (
+	MaxWorkflowConcurrency = 32
+)
+
+// GetConcurrency returns how many nodes may run at the same time, 1 means the queue is
+// executed sequentially.
+func (w *WorkflowExecutor) GetConcurrency() int {
+	concurrency := w.workflow.Settings.MaxConcurrency
+	if concurrency < 1 {
+		return 1
+	}
+
+	return min(concurrency, MaxWorkflowConcurrency)
+}
+
+type finishedNodeRun struct {
+	Run            NodeRun
+	ExecutionOrder int64
+}
+
+// RunExecutionQueueConcurrently runs queued tasks on at most concurrency goroutines. A
+// task is started as soon as a slot is free, so the time spent inside the nodes overlaps.
+//
+// Runs are finished on the calling goroutine in the order they were started, a run keeps
+// its slot until it is finished. Outputs are propagated, waiting tasks are joined and
+// slots are freed in the same order whichever node is faster, so the tasks started and
+// the execution orders they get are the same on every run of the workflow. A node that
+// is faster than the nodes started before it waits for them before its output is
+// propagated.
+//
+// Container nodes run their body through the execution queue, a container starts once
+// no other node is running and nothing else starts until it is done.
+//
+// Once a node fails, pauses the workflow or reaches its execution limit, no more tasks
+// are started and the runs that are still going are finished before returning.
+func (w *WorkflowExecutor) RunExecutionQueueConcurrently(ctx context.Context, concurrency int) error {
+	finished := make(chan finishedNodeRun, concurrency)
+	finishedRuns := map[int64]NodeRun{}
+
+	// Execution orders of the started runs that are not finished yet, in start order.
+	started := []int64{}
+
+	var stopErr error
+	isStopping := false
+
+	for {
+		for !isStopping && len(started) < concurrency {
+			execution, ok := w.nextConcurrentTask(len(started))
+			if !ok {
+				break
+			}
+
+			if ctx.Err() != nil {
+				stopErr, isStopping = context.Cause(ctx), true
+				break
+			}
+
+			if w.IsHandOffRequested() {
+				stopErr, isStopping = ErrExecutionHandedOff, true
+				break
+			}
+
+			w.mutex.Lock()
+			w.executionQueue = w.executionQueue[1:]
+			w.mutex.Unlock()
+
+			executionOrder := w.NextExecutionOrder()
+
+			if w.IsExecutionLimitReached(execution.NodeID) {
+				isStopping = true
+				break
+			}
+
+			run, err := w.StartNode(ctx, execution)
+			if err != nil {
+				stopErr, isStopping = w.HandleNodeFailure(ctx, execution, err), true
+				break
+			}
+
+			if run.Node.Type == domain.NodeTypeContainer {
+				w.RunNode(ctx, &run)
+
+				if err := w.finishConcurrentRun(ctx, run, executionOrder); err != nil {
+					stopErr, isStopping = err, true
+				}
+
+				continue
+			}
+
+			w.setRunningTask(executionOrder, &execution)
+			started = append(started, executionOrder)
+
+			go func(run NodeRun, executionOrder int64) {
+				w.RunNode(ctx, &run)
+
+				finished <- finishedNodeRun{Run: run, ExecutionOrder: executionOrder}
+			}(run, executionOrder)
+		}
+
+		if len(started) == 0 {
+			if isStopping || w.pauseResult != nil {
+				return stopErr
+			}
+
+			w.FlushWaitingTasks()
+
+			if w.QueueLength() == 0 {
+				return nil
+			}
+
+			continue
+		}
+
+		executionOrder := started[0]
+
+		for {
+			if _, ok := finishedRuns[executionOrder]; ok {
+				break
+			}
+
+			done := <-finished
+			finishedRuns[done.ExecutionOrder] = done.Run
+
+			// The failure is reported once the runs started before it are finished,
+			// nothing new is started in the meantime.
+			if done.Run.Err != nil {
+				isStopping = true
+			}
+		}
+
+		run := finishedRuns[executionOrder]
+		delete(finishedRuns, executionOrder)
+		started = started[1:]
+
+		w.setRunningTask(executionOrder, nil)
+
+		if err := w.finishConcurrentRun(ctx, run, executionOrder); err != nil && stopErr == nil {
+			stopErr = err
+		}
+
+		if stopErr != nil || w.pauseResult != nil {
+			isStopping = true
+		}
+	}
+}
+
+// nextConcurrentTask returns the task at the head of the queue, unless it is a container
+// that has to wait for the running nodes.
+func (w *WorkflowExecutor) nextConcurrentTask(running int) (NodeExecutionTask, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.executionQueue) == 0 {
+		return NodeExecutionTask{}, false
+	}
+
+	execution := w.executionQueue[0]
+
+	if node, ok := w.workflow.GetNodeByID(execution.NodeID); ok && node.Type == domain.NodeTypeContainer && running > 0 {
+		return NodeExecutionTask{}, false
+	}
+
+	return execution, true
+}
+
+func (w *WorkflowExecutor) finishConcurrentRun(ctx context.Context, run NodeRun, executionOrder int64) error {
+	_, err := w.FinishNode(ctx, run, ExecuteNodeParams{
+		Task:           run.Task,
+		ExecutionOrder: executionOrder,
+		Propagate:      true,
+	})
+	if err != nil {
+		return w.HandleNodeFailure(ctx, run.Task, err)
+	}
+
+	if w.pauseResult == nil {
+		w.SaveCheckpoint(ctx)
+	}
+
+	return nil
+}
+
+// setRunningTask records the task of a run that is in progress, or forgets it when task
+// is nil. Running tasks are saved with the queue in checkpoints so that they run again
+// when the execution is resumed.
+func (w *WorkflowExecutor) setRunningTask(executionOrder int64, task *NodeExecutionTask) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if task == nil {
+		delete(w.runningTasks, executionOrder)
+		return
+	}
+
+	if w.runningTasks == nil {
+		w.runningTasks = map[int64]NodeExecutionTask{}
+	}
+
+	w.runningTasks[executionOrder] = *task
+}
+
+// runningTasksInOrder returns the tasks of the runs in progress in the order they were
+// started, the caller holds the mutex.
+func (w *WorkflowExecutor) runningTasksInOrder() []NodeExecutionTask {
+	tasks := make([]NodeExecutionTask, 0, len(w.runningTasks))
+
+	for _, executionOrder := range slices.Sorted(maps.Keys(w.runningTasks)) {
+		tasks = append(tasks, w.runningTasks[executionOrder])
+	}
+
+	return tasks
+}
+
+// QueueLength returns the number of tasks waiting in the execution queue.
+func (w *WorkflowExecutor) QueueLength() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return len(w.executionQueue)
+}
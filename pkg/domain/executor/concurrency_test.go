@@ -0,0 +1,253 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+type concurrencyTestRuns struct {
+	mutex      sync.Mutex
+	runs       map[string]int
+	joinInputs map[int]int
+}
+
+func (r *concurrencyTestRuns) add(nodeID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.runs[nodeID]++
+}
+
+func (r *concurrencyTestRuns) get(nodeID string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.runs[nodeID]
+}
+
+func runConcurrentWorkflow(t *testing.T, workflow domain.Workflow, action func(ctx context.Context, input domain.IntegrationInput) error) executor.ExecutionResult {
+	t.Helper()
+
+	integration := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		if err := action(ctx, input); err != nil {
+			return domain.IntegrationOutput{}, err
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("test", integrationFunc(integration))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     completingClient{},
+	})
+
+	result, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_concurrent",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	return result
+}
+
+func concurrentTestWorkflow(concurrency int, nodeIDs []string, edges []domain.WorkflowEdge) domain.Workflow {
+	nodes := []domain.WorkflowNode{{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "test"}}
+
+	for _, nodeID := range nodeIDs {
+		nodes = append(nodes, domain.WorkflowNode{ID: nodeID, Type: domain.NodeTypeAction, IntegrationType: "test"})
+	}
+
+	return domain.Workflow{
+		ID:       "wf_concurrent",
+		Settings: domain.WorkflowSettings{MaxConcurrency: concurrency},
+		Nodes:    nodes,
+		Edges:    edges,
+	}
+}
+
+func TestWorkflowExecutor_ConcurrentFanOutFanIn(t *testing.T) {
+	runs := &concurrencyTestRuns{runs: map[string]int{}, joinInputs: map[int]int{}}
+	followUpDone := make(chan struct{})
+
+	// slow only finishes once follow_up has run, which requires follow_up to start while
+	// slow is still running.
+	workflow := concurrentTestWorkflow(4, []string{"slow", "fast", "follow_up", "join"}, []domain.WorkflowEdge{
+		{SourceNodeID: "trigger", TargetNodeID: "slow"},
+		{SourceNodeID: "trigger", TargetNodeID: "fast"},
+		{SourceNodeID: "fast", TargetNodeID: "follow_up"},
+		{SourceNodeID: "slow", TargetNodeID: "join", TargetIndex: 0},
+		{SourceNodeID: "follow_up", TargetNodeID: "join", TargetIndex: 1},
+	})
+
+	result := runConcurrentWorkflow(t, workflow, func(ctx context.Context, input domain.IntegrationInput) error {
+		runs.add(input.NodeID)
+
+		switch input.NodeID {
+		case "slow":
+			select {
+			case <-followUpDone:
+			case <-time.After(5 * time.Second):
+				return errors.New("follow_up did not run while slow was running")
+			}
+		case "follow_up":
+			close(followUpDone)
+		case "join":
+			runs.mutex.Lock()
+			for inputIndex, nodeItems := range input.ItemsByInputIndex {
+				runs.joinInputs[inputIndex] += len(nodeItems.Items)
+			}
+			runs.mutex.Unlock()
+		}
+
+		return nil
+	})
+
+	if hasFailedNode(result) {
+		t.Fatalf("execution failed, want it to succeed")
+	}
+
+	for _, nodeID := range []string{"slow", "fast", "follow_up", "join"} {
+		if got := runs.get(nodeID); got != 1 {
+			t.Errorf("%s ran %d times, want once", nodeID, got)
+		}
+	}
+
+	if runs.joinInputs[0] != 1 || runs.joinInputs[1] != 1 {
+		t.Errorf("join inputs = %v, want one item on each input", runs.joinInputs)
+	}
+}
+
+func TestWorkflowExecutor_ConcurrentBranchFailure(t *testing.T) {
+	runs := &concurrencyTestRuns{runs: map[string]int{}}
+	failed := make(chan struct{})
+
+	workflow := concurrentTestWorkflow(4, []string{"failing", "sibling", "after_sibling"}, []domain.WorkflowEdge{
+		{SourceNodeID: "trigger", TargetNodeID: "failing"},
+		{SourceNodeID: "trigger", TargetNodeID: "sibling"},
+		{SourceNodeID: "sibling", TargetNodeID: "after_sibling"},
+	})
+
+	result := runConcurrentWorkflow(t, workflow, func(ctx context.Context, input domain.IntegrationInput) error {
+		runs.add(input.NodeID)
+
+		switch input.NodeID {
+		case "failing":
+			defer close(failed)
+			return errors.New("branch failed")
+		case "sibling":
+			<-failed
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		return nil
+	})
+
+	if !hasFailedNode(result) {
+		t.Errorf("execution succeeded, want it to fail")
+	}
+
+	if got := runs.get("after_sibling"); got != 0 {
+		t.Errorf("after_sibling ran %d times, want no new nodes after the failure", got)
+	}
+
+	eventsByNodeID := map[string][]domain.EventType{}
+	for _, entry := range result.NodeExecutionResults {
+		eventsByNodeID[entry.NodeID] = append(eventsByNodeID[entry.NodeID], entry.EventType)
+	}
+
+	if events := eventsByNodeID["sibling"]; len(events) == 0 || events[len(events)-1] != domain.NodeExecuted {
+		t.Errorf("sibling events = %v, want the running sibling to be finished", events)
+	}
+
+	if events := eventsByNodeID["failing"]; len(events) == 0 || events[len(events)-1] != domain.NodeFailed {
+		t.Errorf("failing events = %v, want it to fail", events)
+	}
+}
+
+func TestWorkflowExecutor_ConcurrentExecutionLimit(t *testing.T) {
+	runs := &concurrencyTestRuns{runs: map[string]int{}}
+
+	workflow := concurrentTestWorkflow(4, []string{"a", "b", "c", "target"}, []domain.WorkflowEdge{
+		{SourceNodeID: "trigger", TargetNodeID: "a"},
+		{SourceNodeID: "trigger", TargetNodeID: "b"},
+		{SourceNodeID: "trigger", TargetNodeID: "c"},
+		{SourceNodeID: "a", TargetNodeID: "target"},
+		{SourceNodeID: "b", TargetNodeID: "target"},
+		{SourceNodeID: "c", TargetNodeID: "target"},
+	})
+	workflow.Settings.NodeExecutionLimit = 2
+
+	result := runConcurrentWorkflow(t, workflow, func(ctx context.Context, input domain.IntegrationInput) error {
+		runs.add(input.NodeID)
+
+		return nil
+	})
+
+	if hasFailedNode(result) {
+		t.Errorf("execution failed, want it to succeed")
+	}
+
+	if got := runs.get("target"); got != 2 {
+		t.Errorf("target ran %d times, want the limit of 2", got)
+	}
+}
+
+func TestWorkflowExecutor_ConcurrentExecutionOrderIsDeterministic(t *testing.T) {
+	workflow := concurrentTestWorkflow(4, []string{"b", "c", "d", "e", "f"}, []domain.WorkflowEdge{
+		{SourceNodeID: "trigger", TargetNodeID: "b"},
+		{SourceNodeID: "trigger", TargetNodeID: "c"},
+		{SourceNodeID: "b", TargetNodeID: "d"},
+		{SourceNodeID: "c", TargetNodeID: "e"},
+		{SourceNodeID: "e", TargetNodeID: "f"},
+	})
+
+	executionOrders := func(slowNodeID string) map[string]int {
+		result := runConcurrentWorkflow(t, workflow, func(ctx context.Context, input domain.IntegrationInput) error {
+			if input.NodeID == slowNodeID {
+				time.Sleep(30 * time.Millisecond)
+			}
+
+			return nil
+		})
+
+		orders := map[string]int{}
+		for _, entry := range result.NodeExecutionResults {
+			if entry.EventType == domain.NodeExecuted {
+				orders[entry.NodeID] = entry.ExecutionOrder
+			}
+		}
+
+		return orders
+	}
+
+	want := executionOrders("b")
+
+	if len(want) != 6 {
+		t.Fatalf("execution orders = %v, want one for each node", want)
+	}
+
+	for _, slowNodeID := range []string{"c", "e", "d"} {
+		got := executionOrders(slowNodeID)
+
+		for nodeID, order := range want {
+			if got[nodeID] != order {
+				t.Errorf("with %s slow, %s has execution order %d, want %d like with b slow", slowNodeID, nodeID, got[nodeID], order)
+			}
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/integrations/loop"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrContainerNotFound      = errors.New("container not found")
+	ErrPauseInsideContainer   = errors.New("workflow cannot be paused inside a container")
+	ErrContainerControlAbsent = errors.New("container control not found")
+	ErrMaxIterationsReached   = errors.New("loop reached max iterations")
+)
+
+// containerSchemasByIntegrationType holds the integrations that declare containers,
+// the executor needs them to resolve the role of each control referenced by an edge.
+var containerSchemasByIntegrationType = map[domain.IntegrationType]domain.Integration{
+	domain.IntegrationType_Loop: loop.Schema,
+}
+
+const (
+	DefaultForEachMaxIterations = 100
+)
+
+// ContainerScope holds the items that reached the controls of a running container
+// during a single pass over its body.
+type ContainerScope struct {
+	ContainerNode domain.WorkflowNode
+	Container     domain.IntegrationContainer
+
+	FeedbackItems []domain.Item
+	TerminalItems []domain.Item
+	IsTerminated  bool
+}
+
+func (s *ContainerScope) Collect(control domain.ContainerControl, items []domain.Item) {
+	switch control.Role {
+	case domain.ContainerControlRoleFeedback:
+		s.FeedbackItems = append(s.FeedbackItems, items...)
+	case domain.ContainerControlRoleTerminal:
+		s.TerminalItems = append(s.TerminalItems, items...)
+		s.IsTerminated = true
+	default:
+		log.Warn().
+			Str("container_node_id", s.ContainerNode.ID).
+			Str("control_id", control.ID).
+			Msg("Ignoring items sent to a container control that does not accept input")
+	}
+}
+
+func (w *WorkflowExecutor) GetContainer(node domain.WorkflowNode) (domain.IntegrationContainer, error) {
+	integration, ok := containerSchemasByIntegrationType[node.IntegrationType]
+	if !ok {
+		return domain.IntegrationContainer{}, fmt.Errorf("%w: %s", ErrContainerNotFound, node.IntegrationType)
+	}
+
+	container, ok := integration.GetContainerByType(node.ContainerNodeOpts.ContainerType)
+	if !ok {
+		return domain.IntegrationContainer{}, fmt.Errorf("%w: %s/%s", ErrContainerNotFound, node.IntegrationType, node.ContainerNodeOpts.ContainerType)
+	}
+
+	return container, nil
+}
+
+func (w *WorkflowExecutor) ExecuteContainerNode(ctx context.Context, node domain.WorkflowNode, execution NodeExecutionTask) (NodeExecutionResult, error) {
+	container, err := w.GetContainer(node)
+	if err != nil {
+		return NodeExecutionResult{}, err
+	}
+
+	var items []domain.Item
+
+	switch container.ContainerType {
+	case loop.IntegrationContainerType_ForEach:
+		items, err = w.ExecuteForEachContainer(ctx, node, container, execution)
+	default:
+		return NodeExecutionResult{}, fmt.Errorf("container type %s is not supported", container.ContainerType)
+	}
+
+	if err != nil {
+		return NodeExecutionResult{}, err
+	}
+
+	return NodeExecutionResult{
+		Output: domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, node.ID, items),
+		},
+		IntegrationType:       node.IntegrationType,
+		IntegrationActionType: domain.IntegrationActionType(container.ContainerType),
+	}, nil
+}
+
+type ForEachSettings struct {
+	MaxIterations int
+	Delay         time.Duration
+}
+
+func NewForEachSettings(settings map[string]any) (ForEachSettings, error) {
+	maxIterations, err := intSetting(settings, "max_iterations", DefaultForEachMaxIterations)
+	if err != nil {
+		return ForEachSettings{}, err
+	}
+
+	if maxIterations <= 0 {
+		return ForEachSettings{}, fmt.Errorf("max_iterations must be positive, got %d", maxIterations)
+	}
+
+	delayMs, err := intSetting(settings, "delay_ms", 0)
+	if err != nil {
+		return ForEachSettings{}, err
+	}
+
+	if delayMs < 0 {
+		return ForEachSettings{}, fmt.Errorf("delay_ms must not be negative, got %d", delayMs)
+	}
+
+	return ForEachSettings{
+		MaxIterations: maxIterations,
+		Delay:         time.Duration(delayMs) * time.Millisecond,
+	}, nil
+}
+
+// ExecuteForEachContainer feeds every input item through the container body one at a
+// time. Items that reach the feedback control finish the iteration and are collected,
+// items that reach the terminal control are collected and stop the loop. The node fails
+// when items are left after max_iterations, rather than dropping them.
+func (w *WorkflowExecutor) ExecuteForEachContainer(ctx context.Context, node domain.WorkflowNode, container domain.IntegrationContainer, execution NodeExecutionTask) ([]domain.Item, error) {
+	settings, err := NewForEachSettings(node.IntegrationSettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s settings: %w", container.ContainerType, err)
+	}
+
+	entrypoint, ok := container.GetControlByRole(domain.ContainerControlRoleEntrypoint)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no entrypoint", ErrContainerControlAbsent, container.ContainerType)
+	}
+
+	inputItems := orderedItems(execution.ItemsByInputIndex)
+	results := make([]domain.Item, 0, len(inputItems))
+
+	for iteration, item := range inputItems {
+		if iteration >= settings.MaxIterations {
+			return nil, fmt.Errorf("%w: max_iterations is %d but %d items are left", ErrMaxIterationsReached, settings.MaxIterations, len(inputItems)-iteration)
+		}
+
+		if iteration > 0 && settings.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(settings.Delay):
+			}
+		}
+
+		scope, err := w.ExecuteContainerBody(ctx, ExecuteContainerBodyParams{
+			ContainerNode: node,
+			Container:     container,
+			Entrypoint:    entrypoint,
+			Items:         []domain.Item{item},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("loop iteration %d failed: %w", iteration, err)
+		}
+
+		results = append(results, scope.FeedbackItems...)
+		results = append(results, scope.TerminalItems...)
+
+		if scope.IsTerminated {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+type ExecuteContainerBodyParams struct {
+	ContainerNode domain.WorkflowNode
+	Container     domain.IntegrationContainer
+	Entrypoint    domain.ContainerControl
+	Items         []domain.Item
+}
+
+// ExecuteContainerBody runs the nodes connected to the given entrypoint until the body
+// settles. The outer queue and waiting tasks are set aside while the body runs so that
+// joins inside the body only see items from the current pass.
+func (w *WorkflowExecutor) ExecuteContainerBody(ctx context.Context, p ExecuteContainerBodyParams) (*ContainerScope, error) {
+	scope := &ContainerScope{
+		ContainerNode: p.ContainerNode,
+		Container:     p.Container,
+	}
+
+	w.mutex.Lock()
+	outerQueue := w.executionQueue
+	outerWaitingTasks := w.waitingExecutionTasks
+	w.executionQueue = []NodeExecutionTask{}
+	w.waitingExecutionTasks = []WaitingExecutionTask{}
+	w.containerScopes = append(w.containerScopes, scope)
+	w.mutex.Unlock()
+
+	defer func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		w.executionQueue = outerQueue
+		w.waitingExecutionTasks = outerWaitingTasks
+		w.containerScopes = w.containerScopes[:len(w.containerScopes)-1]
+	}()
+
+	edges := w.workflow.GetControlOutgoingEdges(p.ContainerNode.ID, p.Entrypoint.ID)
+
+	for _, edge := range slices.Backward(edges) {
+		w.AddExecutionTask(NodeExecutionTask{
+			NodeID:            edge.TargetNodeID,
+			ItemsByInputIndex: domain.NewNodeItemsMap(edge.TargetIndex, p.ContainerNode.ID, p.Items),
+		})
+	}
+
+	if err := w.RunExecutionQueue(ctx); err != nil {
+		return nil, err
+	}
+
+	if w.pauseResult != nil {
+		return nil, ErrPauseInsideContainer
+	}
+
+	return scope, nil
+}
+
+// CollectControlItems hands items sent over an edge ending at a container control to
+// the innermost running scope of that container.
+func (w *WorkflowExecutor) CollectControlItems(edge domain.WorkflowEdge, items []domain.Item) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, scope := range slices.Backward(w.containerScopes) {
+		if scope.ContainerNode.ID != edge.TargetNodeID {
+			continue
+		}
+
+		control, ok := scope.Container.GetControlByID(edge.TargetControlID)
+		if !ok {
+			log.Warn().
+				Str("container_node_id", edge.TargetNodeID).
+				Str("control_id", edge.TargetControlID).
+				Msg("Ignoring items sent to an unknown container control")
+
+			return
+		}
+
+		scope.Collect(control, items)
+
+		return
+	}
+
+	log.Warn().
+		Str("container_node_id", edge.TargetNodeID).
+		Str("from_node_id", edge.SourceNodeID).
+		Msg("Ignoring items sent to a container control outside of its scope")
+}
+
+func orderedItems(itemsByIndex domain.NodeItemsMap) []domain.Item {
+	indices := make([]int, 0, len(itemsByIndex))
+	for index := range itemsByIndex {
+		indices = append(indices, index)
+	}
+
+	slices.Sort(indices)
+
+	items := []domain.Item{}
+	for _, index := range indices {
+		items = append(items, itemsByIndex[index].Items...)
+	}
+
+	return items
+}
+
+func intSetting(settings map[string]any, key string, defaultValue int) (int, error) {
+	value, exists := settings[key]
+	if !exists || value == nil {
+		return defaultValue, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		if v == "" {
+			return defaultValue, nil
+		}
+
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a number: %w", key, err)
+		}
+
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("%s must be a number, got %T", key, value)
+	}
+}
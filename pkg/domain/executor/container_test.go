@@ -0,0 +1,273 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/integrations/loop"
+)
+
+type integrationFunc func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error)
+
+func (f integrationFunc) CreateIntegration(ctx context.Context, p domain.CreateIntegrationParams) (domain.IntegrationExecutor, error) {
+	return f, nil
+}
+
+func (f integrationFunc) Execute(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	return f(ctx, input)
+}
+
+// completingClient stands in for the platform, the executions of the tests only
+// complete.
+type completingClient struct {
+	flowbaker.ClientInterface
+}
+
+func (completingClient) CompleteWorkflowExecution(ctx context.Context, req *flowbaker.CompleteExecutionRequest) error {
+	return nil
+}
+
+// hasFailedNode reports whether a node failed during the execution of the result.
+func hasFailedNode(result executor.ExecutionResult) bool {
+	return slices.ContainsFunc(result.NodeExecutionResults, func(entry domain.NodeExecutionEntry) bool {
+		return entry.EventType == domain.NodeFailed
+	})
+}
+
+// loopTestIntegration implements the nodes used by the loop tests, each node ID selects
+// what the node does with its items.
+type loopTestIntegration struct {
+	mutex    sync.Mutex
+	runs     map[string]int
+	received []float64
+}
+
+func newLoopTestIntegration() *loopTestIntegration {
+	return &loopTestIntegration{runs: map[string]int{}}
+}
+
+func (i *loopTestIntegration) execute(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.runs[input.NodeID]++
+
+	items := input.GetAllItems()
+
+	switch input.NodeID {
+	case "double":
+		doubled := make([]domain.Item, 0, len(items))
+		for _, item := range items {
+			doubled = append(doubled, map[string]any{"n": itemNumber(item) * 2})
+		}
+
+		return domain.IntegrationOutput{ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, doubled)}, nil
+	case "expand":
+		expanded := make([]domain.Item, 0, len(items)*2)
+		for _, item := range items {
+			expanded = append(expanded, item, map[string]any{"n": itemNumber(item) * 10})
+		}
+
+		return domain.IntegrationOutput{ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, expanded)}, nil
+	case "check":
+		// Items below 2 continue the loop, the others exit it.
+		continued, exited := []domain.Item{}, []domain.Item{}
+		for _, item := range items {
+			if itemNumber(item) < 2 {
+				continued = append(continued, item)
+			} else {
+				exited = append(exited, item)
+			}
+		}
+
+		return domain.IntegrationOutput{ItemsByOutputIndex: domain.NodeItemsMap{
+			0: {FromNodeID: input.NodeID, Items: continued},
+			1: {FromNodeID: input.NodeID, Items: exited},
+		}}, nil
+	case "boom":
+		for _, item := range items {
+			if itemNumber(item) == 2 {
+				return domain.IntegrationOutput{}, errors.New("cannot process item 2")
+			}
+		}
+	case "after":
+		for _, item := range items {
+			i.received = append(i.received, itemNumber(item))
+		}
+	}
+
+	return domain.IntegrationOutput{ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items)}, nil
+}
+
+func itemNumber(item domain.Item) float64 {
+	n, _ := item.(map[string]any)["n"].(float64)
+
+	return n
+}
+
+func loopNode(id string, settings map[string]any) domain.WorkflowNode {
+	return domain.WorkflowNode{
+		ID:                  id,
+		Type:                domain.NodeTypeContainer,
+		IntegrationType:     domain.IntegrationType_Loop,
+		IntegrationSettings: settings,
+		ContainerNodeOpts:   domain.ContainerNodeOpts{ContainerType: loop.IntegrationContainerType_ForEach},
+	}
+}
+
+func TestWorkflowExecutor_ForEachContainer(t *testing.T) {
+	actionNode := func(id string) domain.WorkflowNode {
+		return domain.WorkflowNode{ID: id, Type: domain.NodeTypeAction, IntegrationType: "test"}
+	}
+
+	tests := []struct {
+		name       string
+		nodes      []domain.WorkflowNode
+		edges      []domain.WorkflowEdge
+		payload    string
+		wantItems  []float64
+		wantRuns   map[string]int
+		wantFailed bool
+		wantError  string
+	}{
+		{
+			name:  "iterates over items",
+			nodes: []domain.WorkflowNode{loopNode("loop", nil), actionNode("double")},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "double"},
+				{SourceNodeID: "double", TargetNodeID: "loop", TargetControlID: "continue"},
+			},
+			payload:   `[{"n": 1}, {"n": 2}, {"n": 3}]`,
+			wantItems: []float64{2, 4, 6},
+			wantRuns:  map[string]int{"double": 3, "after": 1},
+		},
+		{
+			name:  "fails when items are left after max iterations",
+			nodes: []domain.WorkflowNode{loopNode("loop", map[string]any{"max_iterations": 2}), actionNode("double")},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "double"},
+				{SourceNodeID: "double", TargetNodeID: "loop", TargetControlID: "continue"},
+			},
+			payload:    `[{"n": 1}, {"n": 2}, {"n": 3}]`,
+			wantItems:  nil,
+			wantRuns:   map[string]int{"double": 2},
+			wantFailed: true,
+			wantError:  "max_iterations is 2 but 1 items are left",
+		},
+		{
+			name:  "exit before max iterations",
+			nodes: []domain.WorkflowNode{loopNode("loop", map[string]any{"max_iterations": 2}), actionNode("check")},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "check"},
+				{SourceNodeID: "check", SourceIndex: 0, TargetNodeID: "loop", TargetControlID: "continue"},
+				{SourceNodeID: "check", SourceIndex: 1, TargetNodeID: "loop", TargetControlID: "exit"},
+			},
+			payload:   `[{"n": 1}, {"n": 2}, {"n": 3}]`,
+			wantItems: []float64{1, 2},
+			wantRuns:  map[string]int{"check": 2, "after": 1},
+		},
+		{
+			name:  "exit stops the loop",
+			nodes: []domain.WorkflowNode{loopNode("loop", nil), actionNode("check")},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "check"},
+				{SourceNodeID: "check", SourceIndex: 0, TargetNodeID: "loop", TargetControlID: "continue"},
+				{SourceNodeID: "check", SourceIndex: 1, TargetNodeID: "loop", TargetControlID: "exit"},
+			},
+			payload:   `[{"n": 1}, {"n": 2}, {"n": 3}]`,
+			wantItems: []float64{1, 2},
+			wantRuns:  map[string]int{"check": 2, "after": 1},
+		},
+		{
+			name: "nested container",
+			nodes: []domain.WorkflowNode{
+				loopNode("loop", nil),
+				loopNode("inner", nil),
+				actionNode("expand"),
+				actionNode("double"),
+			},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "expand"},
+				{SourceNodeID: "expand", TargetNodeID: "inner"},
+				{SourceNodeID: "inner", SourceControlID: "start", TargetNodeID: "double"},
+				{SourceNodeID: "double", TargetNodeID: "inner", TargetControlID: "continue"},
+				{SourceNodeID: "inner", TargetNodeID: "loop", TargetControlID: "continue"},
+			},
+			payload:   `[{"n": 1}, {"n": 2}]`,
+			wantItems: []float64{2, 20, 4, 40},
+			wantRuns:  map[string]int{"expand": 2, "double": 4, "after": 1},
+		},
+		{
+			name:  "failure inside the body",
+			nodes: []domain.WorkflowNode{loopNode("loop", nil), actionNode("boom")},
+			edges: []domain.WorkflowEdge{
+				{SourceNodeID: "loop", SourceControlID: "start", TargetNodeID: "boom"},
+				{SourceNodeID: "boom", TargetNodeID: "loop", TargetControlID: "continue"},
+			},
+			payload:    `[{"n": 1}, {"n": 2}, {"n": 3}]`,
+			wantItems:  nil,
+			wantRuns:   map[string]int{"boom": 2},
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			integration := newLoopTestIntegration()
+
+			selector := domain.NewIntegrationSelector()
+			selector.RegisterCreator("test", integrationFunc(integration.execute))
+
+			service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+				IntegrationSelector: selector,
+				FlowbakerClient:     completingClient{},
+			})
+
+			nodes := append([]domain.WorkflowNode{
+				{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "test"},
+				actionNode("after"),
+			}, tt.nodes...)
+
+			edges := append([]domain.WorkflowEdge{
+				{SourceNodeID: "trigger", TargetNodeID: "loop"},
+				{SourceNodeID: "loop", TargetNodeID: "after"},
+			}, tt.edges...)
+
+			result, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: "exec_loop",
+				Workflow:    domain.Workflow{ID: "wf_loop", Nodes: nodes, Edges: edges},
+				EventName:   "trigger",
+				PayloadJSON: tt.payload,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if failed := hasFailedNode(result); failed != tt.wantFailed {
+				t.Errorf("failed = %t, want %t", failed, tt.wantFailed)
+			}
+
+			if tt.wantError != "" && !slices.ContainsFunc(result.NodeExecutionResults, func(entry domain.NodeExecutionEntry) bool {
+				return entry.EventType == domain.NodeFailed && entry.NodeID == "loop" && strings.Contains(entry.Error, tt.wantError)
+			}) {
+				t.Errorf("history has no failure of loop containing %q", tt.wantError)
+			}
+
+			if !reflect.DeepEqual(integration.received, tt.wantItems) {
+				t.Errorf("after received %v, want %v", integration.received, tt.wantItems)
+			}
+
+			if !reflect.DeepEqual(integration.runs, tt.wantRuns) {
+				t.Errorf("runs = %v, want %v", integration.runs, tt.wantRuns)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/mappers"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrExecutorDraining is returned for executions started while the executor shuts down.
+var ErrExecutorDraining = errors.New("executor is draining")
+
+// ErrExecutionHandedOff is returned by RunExecutionQueue when the execution stopped
+// between two nodes so that the platform resumes it on another executor.
+var ErrExecutionHandedOff = errors.New("execution handed off")
+
+// RequestHandOff makes the execution stop before its next top level node and send its
+// state to the platform instead of running to completion.
+func (w *WorkflowExecutor) RequestHandOff() {
+	w.handOffRequested.Store(true)
+}
+
+// IsHandOffRequested reports whether the execution queue has to stop. Container bodies
+// run to their end since their scope is not part of the snapshot.
+func (w *WorkflowExecutor) IsHandOffRequested() bool {
+	return w.handOffRequested.Load() && len(w.containerScopes) == 0
+}
+
+// HandOff sends the state of an execution that stopped between two nodes to the platform
+// as a pause without a paused node, which is resumed right away by rerunning the queued
+// tasks of the snapshot.
+func (w *WorkflowExecutor) HandOff(ctx context.Context, triggerNodeID string) (ExecutionResult, error) {
+	if w.executorStateSnapshot != nil && w.executorStateSnapshot.TriggerNodeID != "" {
+		triggerNodeID = w.executorStateSnapshot.TriggerNodeID
+	}
+
+	executionResults := w.historyRecorder.GetHistoryEntries()
+
+	snapshot := w.buildExecutorStateSnapshot(ctx, triggerNodeID)
+	if err := w.PortableSnapshot(ctx, snapshot); err != nil {
+		return ExecutionResult{}, fmt.Errorf("failed to read spilled items of resume state: %w", err)
+	}
+
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return ExecutionResult{}, fmt.Errorf("failed to marshal resume state: %w", err)
+	}
+
+	userID := ""
+	if w.userID != nil {
+		userID = *w.userID
+	}
+
+	now := time.Now()
+
+	err = w.client.PauseWorkflowExecution(ctx, &flowbaker.PauseExecutionRequest{
+		ExecutionID:       w.executionID,
+		WorkspaceID:       w.workflow.WorkspaceID,
+		WorkflowID:        w.workflow.ID,
+		UserID:            userID,
+		WakeAt:            now,
+		StartedAt:         w.WorkflowExecutionStartedAt,
+		PausedAt:          now,
+		NodeExecutions:    mappers.DomainNodeExecutionsToFlowbaker(w.usageCollector.GetNodeExecutions()),
+		HistoryEntries:    w.PortableEntries(ctx, executionResults),
+		IsTestingWorkflow: w.IsTestingWorkflow,
+		DryRun:            w.dryRun,
+		ResumeStateJSON:   snapshotJSON,
+	})
+	if err != nil {
+		return ExecutionResult{}, fmt.Errorf("failed to send hand off: %w", err)
+	}
+
+	if err := w.observer.Notify(ctx, WorkflowExecutionPausedEvent{
+		WakeAt:    now,
+		Timestamp: now,
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to notify workflow execution paused")
+	}
+
+	w.DeleteCheckpoint(ctx)
+
+	w.SaveHistory(ctx, SaveHistoryParams{
+		TriggerNodeID:  triggerNodeID,
+		Status:         ExecutionStatusPaused,
+		NodeExecutions: w.usageCollector.GetNodeExecutions(),
+		HistoryEntries: executionResults,
+	})
+
+	log.Info().Str("execution_id", w.executionID).Msg("Execution handed off")
+
+	executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
+	if !ok {
+		return ExecutionResult{}, errors.New("workflow execution context not found")
+	}
+
+	return ExecutionResult{
+		Payload:              executionContext.ResponsePayload,
+		Headers:              executionContext.ResponseHeaders,
+		StatusCode:           executionContext.ResponseStatusCode,
+		NodeExecutionResults: executionResults,
+	}, nil
+}
+
+// Drain stops admitting executions and waits up to gracePeriod for the running and
+// queued ones to finish. The executions left after that stop before their next node and
+// are handed off to the platform, Drain returns once all of them are gone or ctx is done.
+func (s *workflowExecutorService) Drain(ctx context.Context, gracePeriod time.Duration) error {
+	s.admission.StartDraining()
+
+	graceCtx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+
+	if s.waitForExecutions(graceCtx) {
+		return nil
+	}
+
+	// Queued executions start with the hand-off already requested, so they only run
+	// their checkpoint and hand the trigger task over.
+	s.admission.AdmitQueued()
+
+	executions := s.executionRegistry.List()
+
+	log.Info().Int("executions", len(executions)).Msg("Handing off running executions")
+
+	for _, execution := range executions {
+		execution.HandOffFunc()
+	}
+
+	if s.waitForExecutions(ctx) {
+		return nil
+	}
+
+	return fmt.Errorf("%d executions did not stop in time", s.executionRegistry.Count())
+}
+
+// waitForExecutions polls until no execution is running or queued, it returns false
+// when ctx is done first.
+func (s *workflowExecutorService) waitForExecutions(ctx context.Context) bool {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if status := s.admission.Status(); status.Running == 0 && status.Queued == 0 {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+func TestWorkflowExecutor_HandOff(t *testing.T) {
+	runs := map[string]int{}
+
+	var workflowExecutor executor.WorkflowExecutor
+
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		runs[input.NodeID]++
+
+		// The executor is asked to hand off while the first node runs, like a drain
+		// that started in the middle of the execution.
+		if input.NodeID == "first" {
+			workflowExecutor.RequestHandOff()
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("passthrough", integrationFunc(passthrough))
+
+	client := local.NewClient()
+	store := &memoryCheckpointStore{checkpoints: map[string]executor.ExecutionCheckpoint{}}
+
+	workflow := domain.Workflow{
+		ID: "wf_hand_off",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "passthrough"},
+			{ID: "first", Type: domain.NodeTypeAction, IntegrationType: "passthrough"},
+			{ID: "second", Type: domain.NodeTypeAction, IntegrationType: "passthrough"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "first"},
+			{SourceNodeID: "first", TargetNodeID: "second"},
+		},
+	}
+
+	workflowExecutor, err := executor.NewWorkflowExecutor(executor.WorkflowExecutorDeps{
+		ExecutionID:     "exec_hand_off",
+		Workflow:        workflow,
+		Selector:        selector,
+		ExecutorClient:  client,
+		CheckpointStore: store,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkflowExecutor() error = %v", err)
+	}
+
+	if _, err := workflowExecutor.Execute(context.Background(), "trigger", []domain.Item{map[string]any{"id": 1}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if runs["second"] != 0 {
+		t.Fatal("node second ran after the hand-off was requested")
+	}
+
+	if len(client.GetCompletedExecutions()) != 0 {
+		t.Fatal("handed off execution was completed")
+	}
+
+	if checkpoints, _ := store.List(context.Background()); len(checkpoints) != 0 {
+		t.Fatalf("got %d checkpoints after the hand-off, want none", len(checkpoints))
+	}
+
+	paused := client.GetPausedExecutions()
+	if len(paused) != 1 || paused[0].PauseNodeID != "" {
+		t.Fatalf("paused executions = %+v, want one pause without a paused node", paused)
+	}
+
+	var snapshot domain.ExecutorStateSnapshot
+	if err := json.Unmarshal(paused[0].ResumeStateJSON, &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal resume state: %v", err)
+	}
+
+	if queued := snapshot.QueuedTasks; len(queued) != 1 || queued[0].NodeID != "second" {
+		t.Fatalf("handed off queue = %v, want node second", queued)
+	}
+
+	// Another executor resumes the execution from the snapshot it was handed.
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+	})
+
+	_, err = service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID:           "exec_hand_off",
+		Workflow:              workflow,
+		EventName:             "trigger",
+		PayloadJSON:           `[{"id": 1}]`,
+		ExecutorStateSnapshot: &snapshot,
+	})
+	if err != nil {
+		t.Fatalf("Execute() from the handed off snapshot error = %v", err)
+	}
+
+	want := map[string]int{"first": 1, "second": 1}
+	for nodeID, count := range want {
+		if runs[nodeID] != count {
+			t.Errorf("node %s ran %d times, want %d", nodeID, runs[nodeID], count)
+		}
+	}
+
+	if completed := client.GetCompletedExecutions(); len(completed) != 1 || completed[0].ExecutionID != "exec_hand_off" {
+		t.Errorf("completed executions = %v, want exec_hand_off", completed)
+	}
+}
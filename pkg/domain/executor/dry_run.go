@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DryRunItem is output by write actions in dry runs instead of their result, one for
+// each input item the action would have run for.
+type DryRunItem struct {
+	DryRun          bool                         `json:"dry_run"`
+	IntegrationType domain.IntegrationType       `json:"integration_type"`
+	ActionType      domain.IntegrationActionType `json:"action_type"`
+	Parameters      map[string]any               `json:"parameters"`
+}
+
+// ActionSideEffect returns the side effect of the action of a node from the schema of
+// its integration, actions without a schema are classified by their type.
+func (w *WorkflowExecutor) ActionSideEffect(node domain.WorkflowNode) domain.ActionSideEffect {
+	schema, ok := w.schemasByType[node.IntegrationType]
+	if ok {
+		for _, action := range schema.Actions {
+			if action.ActionType == node.ActionNodeOpts.ActionType {
+				return action.GetSideEffect()
+			}
+		}
+	}
+
+	return domain.ClassifyActionType(node.ActionNodeOpts.ActionType)
+}
+
+// StubAction outputs the parameters the action of a node would have been executed with,
+// bound to each of its input items, without executing it.
+func (w *WorkflowExecutor) StubAction(ctx context.Context, node domain.WorkflowNode, task NodeExecutionTask) (domain.IntegrationOutput, error) {
+	log.Info().
+		Str("node_id", node.ID).
+		Str("integration_type", string(node.IntegrationType)).
+		Str("action_type", string(node.ActionNodeOpts.ActionType)).
+		Msg("Dry run, stubbing write action")
+
+	items := []domain.Item{}
+	for _, inputIndex := range slices.Sorted(maps.Keys(task.ItemsByInputIndex)) {
+		items = append(items, task.ItemsByInputIndex[inputIndex].Items...)
+	}
+
+	if len(items) == 0 {
+		items = append(items, map[string]any{})
+	}
+
+	outputItems := make([]domain.Item, 0, len(items))
+
+	for _, item := range items {
+		parameters := map[string]any{}
+
+		if w.parameterBinder == nil || node.IntegrationSettings == nil {
+			maps.Copy(parameters, node.IntegrationSettings)
+		} else if err := w.parameterBinder.BindToStruct(ctx, item, &parameters, node.IntegrationSettings); err != nil {
+			return domain.IntegrationOutput{}, fmt.Errorf("failed to bind parameters: %w", err)
+		}
+
+		outputItems = append(outputItems, DryRunItem{
+			DryRun:          true,
+			IntegrationType: node.IntegrationType,
+			ActionType:      node.ActionNodeOpts.ActionType,
+			Parameters:      parameters,
+		})
+	}
+
+	return domain.IntegrationOutput{
+		ItemsByOutputIndex: domain.NewNodeItemsMap(0, node.ID, outputItems),
+	}, nil
+}
@@ -0,0 +1,194 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+func TestWorkflowExecutorService_DryRun(t *testing.T) {
+	calls := map[string]int{}
+
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		calls[input.NodeID]++
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("tracker", integrationFunc(passthrough))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		Schemas: []domain.Integration{
+			{
+				ID: "tracker",
+				Actions: []domain.IntegrationAction{
+					{ActionType: "archive_issue", SideEffect: domain.ActionSideEffectRead},
+				},
+			},
+		},
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_dry_run",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "tracker"},
+			{ID: "get", Type: domain.NodeTypeAction, IntegrationType: "tracker", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "get_issue"}},
+			{ID: "archive", Type: domain.NodeTypeAction, IntegrationType: "tracker", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "archive_issue"}},
+			{
+				ID:                  "create",
+				Type:                domain.NodeTypeAction,
+				IntegrationType:     "tracker",
+				ActionNodeOpts:      domain.ActionNodeOpts{ActionType: "create_issue"},
+				IntegrationSettings: map[string]any{"title": "Review"},
+			},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "get"},
+			{SourceNodeID: "get", TargetNodeID: "archive"},
+			{SourceNodeID: "archive", TargetNodeID: "create"},
+		},
+	}
+
+	result, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_dry_run",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}, {"id": 2}]`,
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if calls["get"] != 1 || calls["archive"] != 1 || calls["create"] != 0 {
+		t.Errorf("integration calls = %v, want get and archive to run and create to be stubbed", calls)
+	}
+
+	for _, entry := range result.NodeExecutionResults {
+		if entry.NodeID != "create" || entry.EventType != domain.NodeExecuted {
+			continue
+		}
+
+		items := entry.ItemsByOutputIndex[0].Items
+		if len(items) != 2 {
+			t.Fatalf("create output = %v, want a stub for each input item", items)
+		}
+
+		stub, ok := items[0].(executor.DryRunItem)
+		if !ok || !stub.DryRun || stub.ActionType != "create_issue" || stub.Parameters["title"] != "Review" {
+			t.Errorf("create output item = %+v, want the stubbed create_issue parameters", items[0])
+		}
+
+		return
+	}
+
+	t.Errorf("history entries = %+v, want an executed entry for create", result.NodeExecutionResults)
+}
+
+func TestWorkflowExecutorService_DryRunStaysDryAfterPause(t *testing.T) {
+	calls := map[string]int{}
+
+	sleep := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		calls[input.NodeID]++
+
+		if input.ActionType == "sleep" {
+			execCtx, _ := domain.GetWorkflowExecutionContext(ctx)
+			execCtx.EmitNodeSignal(input.NodeID, domain.PauseSignal{WakeAt: time.Now().Add(time.Minute)})
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("tracker", integrationFunc(sleep))
+
+	client := local.NewClient()
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+		Schemas: []domain.Integration{
+			{
+				ID: "tracker",
+				Actions: []domain.IntegrationAction{
+					{ActionType: "sleep", SideEffect: domain.ActionSideEffectRead},
+				},
+			},
+		},
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_dry_run_pause",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "tracker"},
+			{ID: "sleep", Type: domain.NodeTypeAction, IntegrationType: "tracker", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "sleep"}},
+			{ID: "create", Type: domain.NodeTypeAction, IntegrationType: "tracker", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "create_issue"}},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "sleep"},
+			{SourceNodeID: "sleep", TargetNodeID: "create"},
+		},
+	}
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_dry_run_pause",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+		DryRun:      true,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	paused := client.GetPausedExecutions()
+	if len(paused) != 1 || !paused[0].DryRun {
+		t.Fatalf("paused executions = %+v, want one dry run pause", paused)
+	}
+
+	var snapshot domain.ExecutorStateSnapshot
+	if err := json.Unmarshal(paused[0].ResumeStateJSON, &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal resume state: %v", err)
+	}
+
+	// The platform resumes the execution once it wakes up, without the dry run flag.
+	result, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID:           "exec_dry_run_pause",
+		Workflow:              workflow,
+		EventName:             "trigger",
+		PayloadJSON:           `[{"id": 1}]`,
+		ExecutorStateSnapshot: &snapshot,
+	})
+	if err != nil {
+		t.Fatalf("Execute() from the paused snapshot error = %v", err)
+	}
+
+	if calls["sleep"] != 1 || calls["create"] != 0 {
+		t.Errorf("integration calls = %v, want create to be stubbed after the resume", calls)
+	}
+
+	for _, entry := range result.NodeExecutionResults {
+		if entry.NodeID != "create" || entry.EventType != domain.NodeExecuted {
+			continue
+		}
+
+		if stub, ok := entry.ItemsByOutputIndex[0].Items[0].(executor.DryRunItem); !ok || !stub.DryRun {
+			t.Errorf("create output = %v, want the stubbed parameters", entry.ItemsByOutputIndex[0].Items)
+		}
+
+		return
+	}
+
+	t.Errorf("history entries = %+v, want an executed entry for create", result.NodeExecutionResults)
+}
@@ -0,0 +1,89 @@
+package executor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type memoryEventSink struct {
+	mutex  sync.Mutex
+	events []domain.SinkEvent
+}
+
+func (s *memoryEventSink) Send(ctx context.Context, event domain.SinkEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func (s *memoryEventSink) Close() error {
+	return nil
+}
+
+func TestWorkflowExecutorService_SendsEventsToSinks(t *testing.T) {
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("passthrough", integrationFunc(passthrough))
+
+	sink := &memoryEventSink{}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		EventSinks:          []domain.EventSink{sink},
+	})
+
+	workflow := domain.Workflow{
+		ID:          "wf_sinks",
+		WorkspaceID: "ws_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "passthrough"},
+			{ID: "action", Type: domain.NodeTypeAction, IntegrationType: "passthrough"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "action"},
+		},
+	}
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_sinks",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	typesByNodeID := map[string][]string{}
+
+	for _, event := range sink.events {
+		if event.Kind != domain.SinkEventKindExecution || event.WorkspaceID != "ws_1" || event.ExecutionID != "exec_sinks" {
+			t.Errorf("event = %+v, want an execution event of exec_sinks in ws_1", event)
+		}
+
+		switch data := event.Data.(type) {
+		case *domain.NodeExecutionStartedEvent:
+			typesByNodeID[data.NodeID] = append(typesByNodeID[data.NodeID], event.Type)
+		case *domain.NodeExecutedEvent:
+			typesByNodeID[data.NodeID] = append(typesByNodeID[data.NodeID], event.Type)
+		}
+	}
+
+	actionTypes := typesByNodeID["action"]
+	if len(actionTypes) != 2 || actionTypes[0] != string(domain.NodeExecutionStarted) || actionTypes[1] != string(domain.NodeExecuted) {
+		t.Errorf("action events = %v, want node_execution_started and node_executed", actionTypes)
+	}
+}
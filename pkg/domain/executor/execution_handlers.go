@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
 	"github.com/flowbaker/flowbaker/pkg/domain"
@@ -39,6 +40,7 @@ func (h *HistoryRecorder) HandleEvent(ctx context.Context, event domain.Executio
 			EventType:          domain.NodeExecuted,
 			Timestamp:          e.EndedAt.UnixNano(),
 			ExecutionOrder:     int(e.ExecutionOrder),
+			IsCached:           e.IsCached,
 		})
 
 	case NodeExecutionFailedEvent:
@@ -50,6 +52,17 @@ func (h *HistoryRecorder) HandleEvent(ctx context.Context, event domain.Executio
 			Error:              e.Error.Error(),
 			Timestamp:          e.Timestamp.UnixNano(),
 		})
+
+	case NodeExecutionRetriedEvent:
+		h.historyEntries = append(h.historyEntries, domain.NodeExecutionEntry{
+			NodeID:             e.NodeID,
+			ItemsByInputIndex:  e.ItemsByInputIndex,
+			ItemsByOutputIndex: domain.NodeItemsMap{},
+			EventType:          domain.NodeRetried,
+			Error:              e.Error.Error(),
+			Timestamp:          e.Timestamp.UnixNano(),
+			Attempt:            e.Attempt,
+		})
 	}
 
 	return nil
@@ -131,6 +144,7 @@ func buildEntryFromEvent(event domain.ExecutionEvent) (domain.NodeExecutionEntry
 			EventType:          domain.NodeExecuted,
 			Timestamp:          e.EndedAt.UnixNano(),
 			ExecutionOrder:     int(e.ExecutionOrder),
+			IsCached:           e.IsCached,
 		}, true
 	case NodeExecutionFailedEvent:
 		return domain.NodeExecutionEntry{
@@ -140,6 +154,15 @@ func buildEntryFromEvent(event domain.ExecutionEvent) (domain.NodeExecutionEntry
 			Error:             e.Error.Error(),
 			Timestamp:         e.Timestamp.UnixNano(),
 		}, true
+	case NodeExecutionRetriedEvent:
+		return domain.NodeExecutionEntry{
+			NodeID:            e.NodeID,
+			ItemsByInputIndex: e.ItemsByInputIndex,
+			EventType:         domain.NodeRetried,
+			Error:             e.Error.Error(),
+			Timestamp:         e.Timestamp.UnixNano(),
+			Attempt:           e.Attempt,
+		}, true
 	}
 	return domain.NodeExecutionEntry{}, false
 }
@@ -174,72 +197,84 @@ func (b *EventBroadcaster) HandleEvent(ctx context.Context, event domain.Executi
 		return nil
 	}
 
+	platformEvent, ok := toPlatformEvent(event, b.workflowID, b.executionID)
+	if !ok {
+		return nil
+	}
+
+	return b.orderedEventPublisher.PublishEvent(ctx, platformEvent)
+}
+
+// toPlatformEvent maps an execution event to the event the platform receives. Events
+// that are only used inside of the executor are not mapped.
+func toPlatformEvent(event domain.ExecutionEvent, workflowID, executionID string) (domain.Event, bool) {
 	switch e := event.(type) {
 	case NodeExecutionStartedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.NodeExecutionStartedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.NodeExecutionStartedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			NodeID:              e.NodeID,
 			Timestamp:           e.Timestamp.UnixNano(),
 			IsReExecution:       e.IsReExecution,
 			IsTesting:           e.IsTesting,
 			IsFromErrorTrigger:  e.IsFromErrorTrigger,
-		})
+		}, true
 
 	case NodeExecutionCompletedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.NodeExecutedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.NodeExecutedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			NodeID:              e.NodeID,
 			Timestamp:           e.EndedAt.UnixNano(),
 			ExecutionOrder:      int(e.ExecutionOrder),
 			IsReExecution:       e.IsReExecution,
 			IsTesting:           e.IsTesting,
 			IsFromErrorTrigger:  e.IsFromErrorTrigger,
-		})
+		}, true
 
 	case NodeExecutionFailedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.NodeFailedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.NodeFailedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			NodeID:              e.NodeID,
 			Timestamp:           e.Timestamp.UnixNano(),
 			Error:               e.Error.Error(),
 			IsReExecution:       e.IsReExecution,
 			IsFromErrorTrigger:  e.IsFromErrorTrigger,
 			IsTesting:           e.IsTesting,
-		})
+			IsTimeout:           e.IsTimeout,
+		}, true
 
 	case WorkflowExecutionStartedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.WorkflowExecutionStartedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.WorkflowExecutionStartedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			Timestamp:           e.Timestamp.UnixNano(),
 			IsTesting:           e.IsTesting,
 			IsFromErrorTrigger:  e.IsFromErrorTrigger,
-		})
+		}, true
 
 	case WorkflowExecutionCompletedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.WorkflowExecutionCompletedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.WorkflowExecutionCompletedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			Timestamp:           e.Timestamp.UnixNano(),
 			IsTesting:           e.IsTesting,
 			IsFromErrorTrigger:  e.IsFromErrorTrigger,
-		})
+		}, true
 
 	case WorkflowExecutionPausedEvent:
-		return b.orderedEventPublisher.PublishEvent(ctx, &domain.WorkflowExecutionPausedEvent{
-			WorkflowID:          b.workflowID,
-			WorkflowExecutionID: b.executionID,
+		return &domain.WorkflowExecutionPausedEvent{
+			WorkflowID:          workflowID,
+			WorkflowExecutionID: executionID,
 			PauseNodeID:         e.PauseNodeID,
 			WakeAt:              e.WakeAt.UnixNano(),
 			Timestamp:           e.Timestamp.UnixNano(),
 			IsTesting:           e.IsTesting,
-		})
+		}, true
 	}
 
-	return nil
+	return nil, false
 }
 
 // UsageCollector collects node execution usage data
@@ -266,7 +301,12 @@ func (u *UsageCollector) HandleEvent(ctx context.Context, event domain.Execution
 		inputItemsSizeInBytes := domain.InputItemsSizeInBytes{}
 
 		for inputIndex, nodeItems := range e.ItemsByInputIndex {
-			inputItemsCount[inputIndex] = int64(len(nodeItems.Items))
+			inputItemsCount[inputIndex] = int64(nodeItems.Count())
+			if nodeItems.Ref != nil {
+				inputItemsSizeInBytes[inputIndex] = nodeItems.Ref.SizeInBytes
+				continue
+			}
+
 			serialized, err := json.Marshal(nodeItems.Items)
 			if err != nil {
 				log.Error().Err(err).Msgf("Failed to marshal items for input %d", inputIndex)
@@ -279,7 +319,12 @@ func (u *UsageCollector) HandleEvent(ctx context.Context, event domain.Execution
 		outputItemsSizeInBytes := domain.OutputItemsSizeInBytes{}
 
 		for outputIndex, nodeItems := range e.ItemsByOutputIndex {
-			outputItemsCount[outputIndex] = int64(len(nodeItems.Items))
+			outputItemsCount[outputIndex] = int64(nodeItems.Count())
+			if nodeItems.Ref != nil {
+				outputItemsSizeInBytes[outputIndex] = nodeItems.Ref.SizeInBytes
+				continue
+			}
+
 			serialized, err := json.Marshal(nodeItems.Items)
 			if err != nil {
 				log.Error().Err(err).Msgf("Failed to marshal items for output %d", outputIndex)
@@ -329,3 +374,68 @@ func NewStreamEventBroadcaster(publisher domain.StreamEventPublisher) *StreamEve
 func (b *StreamEventBroadcaster) HandleStreamEvent(ctx context.Context, event domain.StreamEvent) error {
 	return b.publisher.PublishStreamEvent(ctx, event)
 }
+
+// EventSinkBroadcaster sends execution and stream events to the event sinks of the
+// executor. Failed sends are logged, a sink must not stop the execution or the handlers
+// after it.
+type EventSinkBroadcaster struct {
+	sinks       []domain.EventSink
+	workspaceID string
+	workflowID  string
+	executionID string
+}
+
+// NewEventSinkBroadcaster creates a new event sink broadcaster
+func NewEventSinkBroadcaster(
+	sinks []domain.EventSink,
+	workspaceID string,
+	workflowID string,
+	executionID string,
+) *EventSinkBroadcaster {
+	return &EventSinkBroadcaster{
+		sinks:       sinks,
+		workspaceID: workspaceID,
+		workflowID:  workflowID,
+		executionID: executionID,
+	}
+}
+
+// HandleEvent sends the platform representation of execution events to the sinks
+func (b *EventSinkBroadcaster) HandleEvent(ctx context.Context, event domain.ExecutionEvent) error {
+	platformEvent, ok := toPlatformEvent(event, b.workflowID, b.executionID)
+	if !ok {
+		return nil
+	}
+
+	b.send(ctx, domain.SinkEventKindExecution, string(platformEvent.GetType()), platformEvent)
+
+	return nil
+}
+
+// HandleStreamEvent sends stream events to the sinks
+func (b *EventSinkBroadcaster) HandleStreamEvent(ctx context.Context, event domain.StreamEvent) error {
+	b.send(ctx, domain.SinkEventKindStream, string(event.GetEventType()), event)
+
+	return nil
+}
+
+func (b *EventSinkBroadcaster) send(ctx context.Context, kind domain.SinkEventKind, eventType string, data any) {
+	sinkEvent := domain.SinkEvent{
+		Kind:        kind,
+		Type:        eventType,
+		WorkspaceID: b.workspaceID,
+		WorkflowID:  b.workflowID,
+		ExecutionID: b.executionID,
+		Timestamp:   time.Now(),
+		Data:        data,
+	}
+
+	for _, sink := range b.sinks {
+		if err := sink.Send(ctx, sinkEvent); err != nil {
+			log.Warn().Err(err).
+				Str("execution_id", b.executionID).
+				Str("event_type", eventType).
+				Msg("Failed to send event to sink")
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/rs/zerolog/log"
 )
 
 type ReExecutableEvent interface {
@@ -62,6 +64,7 @@ type NodeExecutionCompletedEvent struct {
 	IsReExecution         bool
 	IsFromErrorTrigger    bool
 	IsTesting             bool
+	IsCached              bool
 }
 
 func (NodeExecutionCompletedEvent) GetEventType() domain.ExecutionEventType {
@@ -90,6 +93,7 @@ type NodeExecutionFailedEvent struct {
 	NodeID             string
 	ItemsByInputIndex  domain.NodeItemsMap
 	Error              error
+	IsTimeout          bool
 	Timestamp          time.Time
 	IsReExecution      bool
 	IsFromErrorTrigger bool
@@ -118,6 +122,42 @@ func (e NodeExecutionFailedEvent) SetIsTesting(isTesting bool) domain.ExecutionE
 	return e
 }
 
+type NodeExecutionRetriedEvent struct {
+	NodeID             string
+	ItemsByInputIndex  domain.NodeItemsMap
+	Attempt            int
+	MaxAttempts        int
+	Error              error
+	ErrorClass         domain.RetryErrorClass
+	RetryDelay         time.Duration
+	Timestamp          time.Time
+	IsReExecution      bool
+	IsFromErrorTrigger bool
+	IsTesting          bool
+}
+
+func (NodeExecutionRetriedEvent) GetEventType() domain.ExecutionEventType {
+	return domain.ExecutionEventTypeNodeExecutionRetried
+}
+
+func (e NodeExecutionRetriedEvent) SetIsReExecution(isReExecution bool) domain.ExecutionEvent {
+	e.IsReExecution = isReExecution
+
+	return e
+}
+
+func (e NodeExecutionRetriedEvent) SetIsFromErrorTrigger(isFromErrorTrigger bool) domain.ExecutionEvent {
+	e.IsFromErrorTrigger = isFromErrorTrigger
+
+	return e
+}
+
+func (e NodeExecutionRetriedEvent) SetIsTesting(isTesting bool) domain.ExecutionEvent {
+	e.IsTesting = isTesting
+
+	return e
+}
+
 type WorkflowExecutionStartedEvent struct {
 	Timestamp          time.Time
 	IsTesting          bool
@@ -199,6 +239,12 @@ func (o *executionObserver) SubscribeStream(handler domain.StreamEventHandler) {
 }
 
 func (o *executionObserver) Notify(ctx context.Context, event domain.ExecutionEvent) error {
+	if IsRunAbandoned(ctx) {
+		log.Debug().Str("event_type", string(event.GetEventType())).Msg("Dropping event of an abandoned node run")
+
+		return nil
+	}
+
 	executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
 	if ok {
 		if executionContext.IsReExecution {
@@ -232,6 +278,10 @@ func (o *executionObserver) Notify(ctx context.Context, event domain.ExecutionEv
 }
 
 func (o *executionObserver) NotifyStream(ctx context.Context, event domain.StreamEvent) error {
+	if IsRunAbandoned(ctx) {
+		return nil
+	}
+
 	for _, handler := range o.streamHandlers {
 		if err := handler.HandleStreamEvent(ctx, event); err != nil {
 			return err
@@ -0,0 +1,135 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ExecutionStatus string
+
+const (
+	ExecutionStatusSucceeded ExecutionStatus = "succeeded"
+	ExecutionStatusFailed    ExecutionStatus = "failed"
+	ExecutionStatusPaused    ExecutionStatus = "paused"
+	ExecutionStatusStopped   ExecutionStatus = "stopped"
+)
+
+// ExecutionRecord is an execution kept in the local history of the executor, with
+// the item payloads of every node so runs can be inspected without the platform.
+type ExecutionRecord struct {
+	ExecutionID    string                      `json:"execution_id"`
+	WorkspaceID    string                      `json:"workspace_id"`
+	WorkflowID     string                      `json:"workflow_id"`
+	TriggerNodeID  string                      `json:"trigger_node_id"`
+	Status         ExecutionStatus             `json:"status"`
+	IsTesting      bool                        `json:"is_testing"`
+	StartedAt      time.Time                   `json:"started_at"`
+	EndedAt        time.Time                   `json:"ended_at"`
+	NodeExecutions []domain.NodeExecution      `json:"node_executions"`
+	HistoryEntries []domain.NodeExecutionEntry `json:"history_entries"`
+}
+
+// ExecutionHistoryFilter selects records of one workspace, zero fields match any value.
+type ExecutionHistoryFilter struct {
+	WorkspaceID   string
+	WorkflowID    string
+	Status        ExecutionStatus
+	StartedAfter  time.Time
+	StartedBefore time.Time
+	Limit         int
+}
+
+func (f ExecutionHistoryFilter) Matches(record ExecutionRecord) bool {
+	if f.WorkspaceID != "" && record.WorkspaceID != f.WorkspaceID {
+		return false
+	}
+
+	if f.WorkflowID != "" && record.WorkflowID != f.WorkflowID {
+		return false
+	}
+
+	if f.Status != "" && record.Status != f.Status {
+		return false
+	}
+
+	if !f.StartedAfter.IsZero() && record.StartedAt.Before(f.StartedAfter) {
+		return false
+	}
+
+	if !f.StartedBefore.IsZero() && !record.StartedAt.Before(f.StartedBefore) {
+		return false
+	}
+
+	return true
+}
+
+var ErrExecutionRecordNotFound = errors.New("execution record not found")
+
+type ExecutionHistoryStore interface {
+	Save(ctx context.Context, record ExecutionRecord) error
+	Get(ctx context.Context, executionID string) (ExecutionRecord, error)
+	// List returns the matching records newest first, without their node executions
+	// and history entries.
+	List(ctx context.Context, filter ExecutionHistoryFilter) ([]ExecutionRecord, error)
+}
+
+type SaveHistoryParams struct {
+	TriggerNodeID  string
+	Status         ExecutionStatus
+	NodeExecutions []domain.NodeExecution
+	HistoryEntries []domain.NodeExecutionEntry
+}
+
+// SaveHistory stores the execution in the local history. A resumed execution continues
+// the record saved when it paused, so the record covers the whole run.
+func (w *WorkflowExecutor) SaveHistory(ctx context.Context, p SaveHistoryParams) {
+	if w.historyStore == nil {
+		return
+	}
+
+	record := ExecutionRecord{
+		ExecutionID:    w.executionID,
+		WorkspaceID:    w.workflow.WorkspaceID,
+		WorkflowID:     w.workflow.ID,
+		TriggerNodeID:  p.TriggerNodeID,
+		Status:         p.Status,
+		IsTesting:      w.IsTestingWorkflow,
+		StartedAt:      w.WorkflowExecutionStartedAt,
+		EndedAt:        time.Now(),
+		NodeExecutions: p.NodeExecutions,
+		HistoryEntries: p.HistoryEntries,
+	}
+
+	if w.executorStateSnapshot != nil {
+		previous, err := w.historyStore.Get(ctx, w.executionID)
+		if err != nil && !errors.Is(err, ErrExecutionRecordNotFound) {
+			log.Warn().Err(err).Str("execution_id", w.executionID).Msg("Failed to load execution history record")
+		}
+
+		if err == nil {
+			record.TriggerNodeID = previous.TriggerNodeID
+			record.StartedAt = previous.StartedAt
+			record.NodeExecutions = append(previous.NodeExecutions, record.NodeExecutions...)
+			record.HistoryEntries = append(withoutPauseEntry(previous.HistoryEntries), record.HistoryEntries...)
+		}
+	}
+
+	if err := w.historyStore.Save(ctx, record); err != nil {
+		log.Warn().Err(err).Str("execution_id", w.executionID).Msg("Failed to save execution history record")
+	}
+}
+
+// withoutPauseEntry drops the started entry of the paused node a paused record ends
+// with, the resumed run records the node as executed.
+func withoutPauseEntry(entries []domain.NodeExecutionEntry) []domain.NodeExecutionEntry {
+	if n := len(entries); n > 0 && entries[n-1].EventType == domain.NodeExecutionStarted {
+		return entries[:n-1]
+	}
+
+	return entries
+}
@@ -0,0 +1,116 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type memoryHistoryStore struct {
+	mutex   sync.Mutex
+	records map[string]executor.ExecutionRecord
+}
+
+func (s *memoryHistoryStore) Save(ctx context.Context, record executor.ExecutionRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.records[record.ExecutionID] = record
+
+	return nil
+}
+
+func (s *memoryHistoryStore) Get(ctx context.Context, executionID string) (executor.ExecutionRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record, ok := s.records[executionID]
+	if !ok {
+		return executor.ExecutionRecord{}, executor.ErrExecutionRecordNotFound
+	}
+
+	return record, nil
+}
+
+func (s *memoryHistoryStore) List(ctx context.Context, filter executor.ExecutionHistoryFilter) ([]executor.ExecutionRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestWorkflowExecutorService_SavesHistory(t *testing.T) {
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	failing := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		return domain.IntegrationOutput{}, errors.New("upstream unavailable")
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("passthrough", integrationFunc(passthrough))
+	selector.RegisterCreator("failing", integrationFunc(failing))
+
+	store := &memoryHistoryStore{records: map[string]executor.ExecutionRecord{}}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		HistoryStore:        store,
+	})
+
+	tests := []struct {
+		executionID     string
+		integrationType domain.IntegrationType
+		wantStatus      executor.ExecutionStatus
+		wantEventType   domain.EventType
+	}{
+		{"exec_succeeded", "passthrough", executor.ExecutionStatusSucceeded, domain.NodeExecuted},
+		{"exec_failed", "failing", executor.ExecutionStatusFailed, domain.NodeFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.executionID, func(t *testing.T) {
+			workflow := domain.Workflow{
+				ID:          "wf_history",
+				WorkspaceID: "ws_1",
+				Nodes: []domain.WorkflowNode{
+					{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "passthrough"},
+					{ID: "action", Type: domain.NodeTypeAction, IntegrationType: tt.integrationType},
+				},
+				Edges: []domain.WorkflowEdge{
+					{SourceNodeID: "trigger", TargetNodeID: "action"},
+				},
+			}
+
+			_, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: tt.executionID,
+				Workflow:    workflow,
+				EventName:   "trigger",
+				PayloadJSON: `[{"id": 1}]`,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			record, err := store.Get(context.Background(), tt.executionID)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if record.Status != tt.wantStatus || record.WorkspaceID != "ws_1" || record.TriggerNodeID != "trigger" {
+				t.Errorf("record = %+v, want status %s", record, tt.wantStatus)
+			}
+
+			entries := record.HistoryEntries
+			if len(entries) == 0 || entries[len(entries)-1].NodeID != "action" || entries[len(entries)-1].EventType != tt.wantEventType {
+				t.Errorf("history entries = %+v, want them to end with action %s", entries, tt.wantEventType)
+			}
+		})
+	}
+}
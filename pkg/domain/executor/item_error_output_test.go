@@ -0,0 +1,80 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+func TestWorkflowExecutor_RoutesFailedItemsToErrorOutput(t *testing.T) {
+	var mutex sync.Mutex
+	receivedByNodeID := map[string]int{}
+
+	actionManager := domain.NewIntegrationActionManager().
+		AddPerItem("send", func(ctx context.Context, params domain.IntegrationInput, item domain.Item) (domain.Item, error) {
+			if item.(map[string]any)["id"].(float64) == 2 {
+				return nil, errors.New("status 400")
+			}
+
+			return item, nil
+		})
+
+	messages := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		if input.ActionType == "send" {
+			return actionManager.Run(ctx, input.ActionType, input)
+		}
+
+		mutex.Lock()
+		receivedByNodeID[input.NodeID] += len(input.GetAllItems())
+		mutex.Unlock()
+
+		return domain.IntegrationOutput{}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("messages", integrationFunc(messages))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     completingClient{},
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_item_errors",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "messages"},
+			{
+				ID:              "send",
+				Type:            domain.NodeTypeAction,
+				IntegrationType: "messages",
+				ActionNodeOpts:  domain.ActionNodeOpts{ActionType: "send"},
+				Settings:        domain.NodeSettings{ContinueOnItemError: true},
+			},
+			{ID: "sent", Type: domain.NodeTypeAction, IntegrationType: "messages"},
+			{ID: "report", Type: domain.NodeTypeAction, IntegrationType: "messages"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "send"},
+			{SourceNodeID: "send", TargetNodeID: "sent"},
+			{SourceNodeID: "send", SourceIndex: domain.ItemErrorOutputIndex, TargetNodeID: "report"},
+		},
+	}
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_item_errors",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := map[string]int{"sent": 2, "report": 1}; !reflect.DeepEqual(receivedByNodeID, want) {
+		t.Errorf("received items = %v, want %v", receivedByNodeID, want)
+	}
+}
@@ -0,0 +1,224 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/metrics"
+)
+
+const (
+	executionStatusSucceeded = "succeeded"
+	executionStatusFailed    = "failed"
+)
+
+// ExecutionMetrics are the executor metrics shared by all executions, they are fed by
+// a MetricsRecorder subscribed to each execution.
+type ExecutionMetrics struct {
+	WorkflowExecutionsStarted   *metrics.CounterVec
+	WorkflowExecutionsCompleted *metrics.CounterVec
+	WorkflowExecutionsPaused    *metrics.CounterVec
+	WorkflowExecutionDuration   *metrics.HistogramVec
+
+	NodeExecutionsStarted   *metrics.CounterVec
+	NodeExecutionsCompleted *metrics.CounterVec
+	NodeExecutionsFailed    *metrics.CounterVec
+	NodeExecutionsRetried   *metrics.CounterVec
+	NodeExecutionDuration   *metrics.HistogramVec
+
+	NodeInputItems  *metrics.CounterVec
+	NodeOutputItems *metrics.CounterVec
+	NodeInputBytes  *metrics.CounterVec
+	NodeOutputBytes *metrics.CounterVec
+
+	registry    *metrics.Registry
+	nodeIDLabel bool
+}
+
+type ExecutionMetricsOption func(m *ExecutionMetrics)
+
+// WithNodeIDLabel adds the node_id label to node metrics. Every node of every workflow
+// then has its own series, so it is off by default.
+func WithNodeIDLabel() ExecutionMetricsOption {
+	return func(m *ExecutionMetrics) {
+		m.nodeIDLabel = true
+	}
+}
+
+func NewExecutionMetrics(registry *metrics.Registry, opts ...ExecutionMetricsOption) *ExecutionMetrics {
+	m := &ExecutionMetrics{registry: registry}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	nodeLabelNames := []string{"workflow_id", "integration_type", "action_type"}
+	if m.nodeIDLabel {
+		nodeLabelNames = []string{"workflow_id", "node_id", "integration_type", "action_type"}
+	}
+
+	m.WorkflowExecutionsStarted = registry.NewCounterVec("flowbaker_workflow_executions_started_total", "Workflow executions started.", "workflow_id")
+	m.WorkflowExecutionsCompleted = registry.NewCounterVec("flowbaker_workflow_executions_completed_total", "Workflow executions completed, failed when any node failed.", "workflow_id", "status")
+	m.WorkflowExecutionsPaused = registry.NewCounterVec("flowbaker_workflow_executions_paused_total", "Workflow executions paused for a later resume.", "workflow_id")
+	m.WorkflowExecutionDuration = registry.NewHistogramVec("flowbaker_workflow_execution_duration_seconds", "Duration of workflow executions from start to completion.", metrics.DefaultDurationBuckets, "workflow_id", "status")
+
+	m.NodeExecutionsStarted = registry.NewCounterVec("flowbaker_node_executions_started_total", "Node executions started.", nodeLabelNames...)
+	m.NodeExecutionsCompleted = registry.NewCounterVec("flowbaker_node_executions_completed_total", "Node executions completed.", nodeLabelNames...)
+	m.NodeExecutionsFailed = registry.NewCounterVec("flowbaker_node_executions_failed_total", "Node executions failed.", append(nodeLabelNames, "timeout")...)
+	m.NodeExecutionsRetried = registry.NewCounterVec("flowbaker_node_executions_retried_total", "Failed node attempts that were retried.", append(nodeLabelNames, "error_class")...)
+	m.NodeExecutionDuration = registry.NewHistogramVec("flowbaker_node_execution_duration_seconds", "Duration of node executions.", metrics.DefaultDurationBuckets, append(nodeLabelNames, "status")...)
+
+	m.NodeInputItems = registry.NewCounterVec("flowbaker_node_input_items_total", "Items received by completed nodes.", nodeLabelNames...)
+	m.NodeOutputItems = registry.NewCounterVec("flowbaker_node_output_items_total", "Items produced by completed nodes.", nodeLabelNames...)
+	m.NodeInputBytes = registry.NewCounterVec("flowbaker_node_input_bytes_total", "Serialized size of the items received by completed nodes.", nodeLabelNames...)
+	m.NodeOutputBytes = registry.NewCounterVec("flowbaker_node_output_bytes_total", "Serialized size of the items produced by completed nodes.", nodeLabelNames...)
+
+	return m
+}
+
+// RegisterActiveExecutions exposes the number of executions in the registry as a gauge.
+func (m *ExecutionMetrics) RegisterActiveExecutions(executionRegistry *ExecutionRegistry) {
+	m.registry.NewGaugeFunc("flowbaker_active_executions", "Workflow executions currently running on the executor.", func() float64 {
+		return float64(executionRegistry.Count())
+	})
+}
+
+// RegisterQueuedExecutions exposes the number of executions waiting for a free slot as a
+// gauge.
+func (m *ExecutionMetrics) RegisterQueuedExecutions(status func() AdmissionStatus) {
+	m.registry.NewGaugeFunc("flowbaker_queued_executions", "Workflow executions waiting for a free slot on the executor.", func() float64 {
+		return float64(status().Queued)
+	})
+}
+
+// MetricsRecorder records the events of one execution into the shared ExecutionMetrics.
+// Item and byte counts are taken from the UsageCollector when the execution completes
+// or pauses, so the items are serialized only once per node.
+type MetricsRecorder struct {
+	metrics        *ExecutionMetrics
+	workflow       domain.Workflow
+	usageCollector *UsageCollector
+
+	workflowStartedAt  time.Time
+	nodeStartedAt      map[string]time.Time
+	hasFailedNodes     bool
+	recordedUsageCount int
+	mutex              sync.Mutex
+}
+
+func NewMetricsRecorder(executionMetrics *ExecutionMetrics, workflow domain.Workflow, usageCollector *UsageCollector) *MetricsRecorder {
+	return &MetricsRecorder{
+		metrics:        executionMetrics,
+		workflow:       workflow,
+		usageCollector: usageCollector,
+		nodeStartedAt:  map[string]time.Time{},
+	}
+}
+
+func (r *MetricsRecorder) HandleEvent(ctx context.Context, event domain.ExecutionEvent) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch e := event.(type) {
+	case WorkflowExecutionStartedEvent:
+		r.workflowStartedAt = e.Timestamp
+		r.metrics.WorkflowExecutionsStarted.Inc(r.workflow.ID)
+
+	case WorkflowExecutionCompletedEvent:
+		status := executionStatusSucceeded
+		if r.hasFailedNodes {
+			status = executionStatusFailed
+		}
+
+		r.metrics.WorkflowExecutionsCompleted.Inc(r.workflow.ID, status)
+
+		// Resumed executions did not see the start, their duration is unknown.
+		if !r.workflowStartedAt.IsZero() {
+			r.metrics.WorkflowExecutionDuration.Observe(e.Timestamp.Sub(r.workflowStartedAt).Seconds(), r.workflow.ID, status)
+		}
+
+		r.recordUsage()
+
+	case WorkflowExecutionPausedEvent:
+		r.metrics.WorkflowExecutionsPaused.Inc(r.workflow.ID)
+		r.recordUsage()
+
+	case NodeExecutionStartedEvent:
+		r.nodeStartedAt[e.NodeID] = e.Timestamp
+		r.metrics.NodeExecutionsStarted.Inc(r.nodeLabelValues(e.NodeID, "", "")...)
+
+	case NodeExecutionCompletedEvent:
+		labelValues := r.nodeLabelValues(e.NodeID, e.IntegrationType, e.IntegrationActionType)
+
+		r.metrics.NodeExecutionsCompleted.Inc(labelValues...)
+		r.metrics.NodeExecutionDuration.Observe(e.EndedAt.Sub(e.StartedAt).Seconds(), append(labelValues, executionStatusSucceeded)...)
+
+		delete(r.nodeStartedAt, e.NodeID)
+
+	case NodeExecutionFailedEvent:
+		r.hasFailedNodes = true
+
+		labelValues := r.nodeLabelValues(e.NodeID, "", "")
+
+		timeout := "false"
+		if e.IsTimeout {
+			timeout = "true"
+		}
+
+		r.metrics.NodeExecutionsFailed.Inc(append(labelValues, timeout)...)
+
+		if startedAt, ok := r.nodeStartedAt[e.NodeID]; ok {
+			r.metrics.NodeExecutionDuration.Observe(e.Timestamp.Sub(startedAt).Seconds(), append(labelValues, executionStatusFailed)...)
+			delete(r.nodeStartedAt, e.NodeID)
+		}
+
+	case NodeExecutionRetriedEvent:
+		r.metrics.NodeExecutionsRetried.Inc(append(r.nodeLabelValues(e.NodeID, "", ""), string(e.ErrorClass))...)
+	}
+
+	return nil
+}
+
+// recordUsage adds the item counts and sizes of the nodes completed since the last call.
+func (r *MetricsRecorder) recordUsage() {
+	nodeExecutions := r.usageCollector.GetNodeExecutions()
+
+	for _, nodeExecution := range nodeExecutions[r.recordedUsageCount:] {
+		labelValues := r.nodeLabelValues(nodeExecution.NodeID, nodeExecution.IntegrationType, nodeExecution.IntegrationActionType)
+
+		for _, count := range nodeExecution.InputItemsCount {
+			r.metrics.NodeInputItems.Add(float64(count), labelValues...)
+		}
+
+		for _, count := range nodeExecution.OutputItemsCount {
+			r.metrics.NodeOutputItems.Add(float64(count), labelValues...)
+		}
+
+		for _, size := range nodeExecution.InputItemsSizeInBytes {
+			r.metrics.NodeInputBytes.Add(float64(size), labelValues...)
+		}
+
+		for _, size := range nodeExecution.OutputItemsSizeInBytes {
+			r.metrics.NodeOutputBytes.Add(float64(size), labelValues...)
+		}
+	}
+
+	r.recordedUsageCount = len(nodeExecutions)
+}
+
+// nodeLabelValues labels node metrics with the integration and action of the workflow
+// node, events of nodes outside the workflow such as agent tools keep their own.
+func (r *MetricsRecorder) nodeLabelValues(nodeID string, integrationType domain.IntegrationType, actionType domain.IntegrationActionType) []string {
+	if node, ok := r.workflow.GetNodeByID(nodeID); ok {
+		integrationType = node.IntegrationType
+		actionType = node.ActionNodeOpts.ActionType
+	}
+
+	if r.metrics.nodeIDLabel {
+		return []string{r.workflow.ID, nodeID, string(integrationType), string(actionType)}
+	}
+
+	return []string{r.workflow.ID, string(integrationType), string(actionType)}
+}
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/metrics"
+)
+
+func TestMetricsRecorder_HandleEvent(t *testing.T) {
+	executionMetrics := NewExecutionMetrics(metrics.NewRegistry())
+
+	workflow := domain.Workflow{
+		ID: "wf_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "fetch", Type: domain.NodeTypeAction, IntegrationType: "http", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "request"}},
+			{ID: "notify", Type: domain.NodeTypeAction, IntegrationType: "slack", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "send_message"}},
+		},
+	}
+
+	usageCollector := NewUsageCollector()
+	observer := NewExecutionObserver()
+	observer.Subscribe(usageCollector)
+	observer.Subscribe(NewMetricsRecorder(executionMetrics, workflow, usageCollector))
+
+	ctx := context.Background()
+	now := time.Now()
+
+	events := []domain.ExecutionEvent{
+		WorkflowExecutionStartedEvent{Timestamp: now},
+		NodeExecutionStartedEvent{NodeID: "fetch", Timestamp: now},
+		NodeExecutionCompletedEvent{
+			NodeID:             "fetch",
+			ItemsByInputIndex:  domain.NewNodeItemsMap(0, "trigger", []domain.Item{1}),
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, "fetch", []domain.Item{1, 2}),
+			StartedAt:          now,
+			EndedAt:            now.Add(200 * time.Millisecond),
+		},
+		NodeExecutionStartedEvent{NodeID: "notify", Timestamp: now},
+		NodeExecutionFailedEvent{NodeID: "notify", Error: errors.New("channel not found"), Timestamp: now.Add(time.Second)},
+		WorkflowExecutionCompletedEvent{Timestamp: now.Add(2 * time.Second)},
+	}
+
+	for _, event := range events {
+		if err := observer.Notify(ctx, event); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+
+	fetchLabels := []string{"wf_1", "http", "request"}
+	notifyLabels := []string{"wf_1", "slack", "send_message"}
+
+	checks := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"fetch started", executionMetrics.NodeExecutionsStarted.Value(fetchLabels...), 1},
+		{"fetch completed", executionMetrics.NodeExecutionsCompleted.Value(fetchLabels...), 1},
+		{"fetch input items", executionMetrics.NodeInputItems.Value(fetchLabels...), 1},
+		{"fetch output items", executionMetrics.NodeOutputItems.Value(fetchLabels...), 2},
+		{"fetch output bytes", executionMetrics.NodeOutputBytes.Value(fetchLabels...), float64(len("[1,2]"))},
+		{"notify failed", executionMetrics.NodeExecutionsFailed.Value(append(notifyLabels, "false")...), 1},
+		{"notify failed duration", float64(executionMetrics.NodeExecutionDuration.Count(append(notifyLabels, "failed")...)), 1},
+		{"workflow failed", executionMetrics.WorkflowExecutionsCompleted.Value("wf_1", "failed"), 1},
+		{"workflow duration", float64(executionMetrics.WorkflowExecutionDuration.Count("wf_1", "failed")), 1},
+	}
+
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestMetricsRecorder_NodeIDLabel(t *testing.T) {
+	executionMetrics := NewExecutionMetrics(metrics.NewRegistry(), WithNodeIDLabel())
+
+	workflow := domain.Workflow{
+		ID: "wf_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "fetch", Type: domain.NodeTypeAction, IntegrationType: "http", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "request"}},
+		},
+	}
+
+	recorder := NewMetricsRecorder(executionMetrics, workflow, NewUsageCollector())
+
+	if err := recorder.HandleEvent(context.Background(), NodeExecutionStartedEvent{NodeID: "fetch", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("HandleEvent() error = %v", err)
+	}
+
+	if got := executionMetrics.NodeExecutionsStarted.Value("wf_1", "fetch", "http", "request"); got != 1 {
+		t.Errorf("fetch started = %v, want 1", got)
+	}
+}
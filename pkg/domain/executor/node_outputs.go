@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+// ReadNodeOutput returns the output of the last successful run of a node in this
+// execution. Nodes are found by ID first and then by name, spilled items are read back
+// from the payload store. The outputs are kept in snapshots, so nodes that ran before a
+// pause or a hand-off can still be read after the execution is resumed.
+func (w *WorkflowExecutor) ReadNodeOutput(ctx context.Context, nameOrID string) (domain.NodeOutput, bool, error) {
+	node, exists := w.findNode(nameOrID)
+	if !exists {
+		return domain.NodeOutput{}, false, nil
+	}
+
+	w.mutex.Lock()
+	itemsByOutputIndex, exists := w.nodeOutputs[node.ID]
+	w.mutex.Unlock()
+
+	if !exists {
+		return domain.NodeOutput{}, false, nil
+	}
+
+	itemsByOutputIndex, err := w.ResolveItems(ctx, itemsByOutputIndex)
+	if err != nil {
+		return domain.NodeOutput{}, false, err
+	}
+
+	return domain.NodeOutput{
+		NodeID:             node.ID,
+		NodeName:           node.Name,
+		ItemsByOutputIndex: itemsByOutputIndex,
+	}, true, nil
+}
+
+func (w *WorkflowExecutor) setNodeOutput(nodeID string, itemsByOutputIndex domain.NodeItemsMap) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.nodeOutputs[nodeID] = itemsByOutputIndex
+}
+
+func (w *WorkflowExecutor) findNode(nameOrID string) (domain.WorkflowNode, bool) {
+	if node, exists := w.workflow.GetNodeByID(nameOrID); exists {
+		return node, true
+	}
+
+	for _, node := range w.workflow.Nodes {
+		if node.Name == nameOrID {
+			return node, true
+		}
+	}
+
+	return domain.WorkflowNode{}, false
+}
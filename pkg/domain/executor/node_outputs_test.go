@@ -0,0 +1,179 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+func TestWorkflowExecutor_ReadNodeOutput(t *testing.T) {
+	var sourceOutput domain.NodeOutput
+	var found bool
+
+	integration := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		items := input.GetAllItems()
+
+		switch input.NodeID {
+		case "source":
+			items = []domain.Item{map[string]any{"id": 1}, map[string]any{"id": 2}}
+		case "reader":
+			executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
+			if !ok || executionContext.NodeOutputs == nil {
+				return domain.IntegrationOutput{}, errors.New("execution context has no node outputs")
+			}
+
+			var err error
+			sourceOutput, found, err = executionContext.NodeOutputs.ReadNodeOutput(ctx, "Source")
+			if err != nil {
+				return domain.IntegrationOutput{}, err
+			}
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("test", integrationFunc(integration))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_node_outputs",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "test"},
+			{ID: "source", Name: "Source", Type: domain.NodeTypeAction, IntegrationType: "test"},
+			{ID: "between", Type: domain.NodeTypeAction, IntegrationType: "test"},
+			{ID: "reader", Type: domain.NodeTypeAction, IntegrationType: "test"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "source"},
+			{SourceNodeID: "source", TargetNodeID: "between"},
+			{SourceNodeID: "between", TargetNodeID: "reader"},
+		},
+	}
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_node_outputs",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 0}]`,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !found {
+		t.Fatal("ReadNodeOutput() found = false, want the output of the source node")
+	}
+
+	if sourceOutput.NodeID != "source" || len(sourceOutput.ItemsByOutputIndex[0].Items) != 2 {
+		t.Errorf("output = %+v, want the two items of the source node", sourceOutput)
+	}
+}
+
+func TestWorkflowExecutor_ReadNodeOutputAfterResume(t *testing.T) {
+	var sourceOutput domain.NodeOutput
+	var found bool
+
+	integration := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		items := input.GetAllItems()
+
+		switch input.NodeID {
+		case "source":
+			items = []domain.Item{map[string]any{"id": 1}, map[string]any{"id": 2}}
+		case "sleep":
+			executionContext, _ := domain.GetWorkflowExecutionContext(ctx)
+			executionContext.EmitNodeSignal(input.NodeID, domain.PauseSignal{WakeAt: time.Now().Add(time.Minute)})
+		case "reader":
+			executionContext, _ := domain.GetWorkflowExecutionContext(ctx)
+
+			var err error
+			sourceOutput, found, err = executionContext.NodeOutputs.ReadNodeOutput(ctx, "Source")
+			if err != nil {
+				return domain.IntegrationOutput{}, err
+			}
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("test", integrationFunc(integration))
+
+	client := local.NewClient()
+
+	workflow := domain.Workflow{
+		ID: "wf_node_outputs_resume",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "test"},
+			{ID: "source", Name: "Source", Type: domain.NodeTypeAction, IntegrationType: "test"},
+			{ID: "sleep", Type: domain.NodeTypeAction, IntegrationType: "test"},
+			{ID: "reader", Type: domain.NodeTypeAction, IntegrationType: "test"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "source"},
+			{SourceNodeID: "source", TargetNodeID: "sleep"},
+			{SourceNodeID: "sleep", TargetNodeID: "reader"},
+		},
+	}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+	})
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_node_outputs_resume",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 0}]`,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	paused := client.GetPausedExecutions()
+	if len(paused) != 1 {
+		t.Fatalf("paused executions = %d, want the sleep to pause the execution", len(paused))
+	}
+
+	var snapshot domain.ExecutorStateSnapshot
+	if err := json.Unmarshal(paused[0].ResumeStateJSON, &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal resume state: %v", err)
+	}
+
+	// The execution is resumed by a new executor that only has the snapshot.
+	resumeService := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+	})
+
+	if _, err := resumeService.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID:           "exec_node_outputs_resume",
+		Workflow:              workflow,
+		EventName:             "trigger",
+		PayloadJSON:           `[{"id": 0}]`,
+		ExecutorStateSnapshot: &snapshot,
+	}); err != nil {
+		t.Fatalf("Execute() from the paused snapshot error = %v", err)
+	}
+
+	if !found {
+		t.Fatal("ReadNodeOutput() found = false after the resume, want the output of the source node")
+	}
+
+	if sourceOutput.NodeID != "source" || len(sourceOutput.ItemsByOutputIndex[0].Items) != 2 {
+		t.Errorf("output = %+v, want the two items of the source node", sourceOutput)
+	}
+}
@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultNodeOutputCacheTTL is how long cached outputs are reused when the node does not
+// set a TTL.
+const DefaultNodeOutputCacheTTL = 24 * time.Hour
+
+// NodeOutputCache stores the outputs of nodes that opted into caching. Outputs are
+// looked up by a key that changes with the parameters and input items of the node.
+type NodeOutputCache interface {
+	Get(ctx context.Context, key string) (domain.NodeItemsMap, bool, error)
+	Set(ctx context.Context, key string, output domain.NodeItemsMap, ttl time.Duration) error
+}
+
+type nodeOutputCacheKey struct {
+	IntegrationType domain.IntegrationType       `json:"integration_type"`
+	ActionType      domain.IntegrationActionType `json:"action_type"`
+	Parameters      []any                        `json:"parameters"`
+	Items           domain.NodeItemsMap          `json:"items"`
+}
+
+// NodeOutputCacheKey hashes the parameters of the node, bound to each of its input
+// items, together with the input items. The key is scoped to the workspace since the
+// parameters include its credentials.
+func (w *WorkflowExecutor) NodeOutputCacheKey(ctx context.Context, node domain.WorkflowNode, task NodeExecutionTask) (string, error) {
+	key := nodeOutputCacheKey{
+		IntegrationType: node.IntegrationType,
+		ActionType:      node.ActionNodeOpts.ActionType,
+		Items:           task.ItemsByInputIndex,
+	}
+
+	if w.parameterBinder == nil || node.IntegrationSettings == nil {
+		key.Parameters = []any{node.IntegrationSettings}
+	} else {
+		items := []domain.Item{}
+		for _, inputIndex := range slices.Sorted(maps.Keys(task.ItemsByInputIndex)) {
+			items = append(items, task.ItemsByInputIndex[inputIndex].Items...)
+		}
+
+		if len(items) == 0 {
+			items = append(items, map[string]any{})
+		}
+
+		for _, item := range items {
+			var parameters map[string]any
+			if err := w.parameterBinder.BindToStruct(ctx, item, &parameters, node.IntegrationSettings); err != nil {
+				return "", fmt.Errorf("failed to bind parameters: %w", err)
+			}
+
+			key.Parameters = append(key.Parameters, parameters)
+		}
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cache key: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return w.workflow.WorkspaceID + ":" + hex.EncodeToString(hash[:]), nil
+}
+
+// ExecuteCached returns the cached output of a node that opted into caching when there
+// is one for its parameters and input items, and otherwise runs attemptFunc and caches
+// its output. A cache that fails is logged and the node runs as if it had no cache.
+func (w *WorkflowExecutor) ExecuteCached(ctx context.Context, node domain.WorkflowNode, task NodeExecutionTask, attemptFunc AttemptFunc) (domain.IntegrationOutput, bool, error) {
+	if w.outputCache == nil || !node.Settings.CacheOutput {
+		output, err := attemptFunc(ctx)
+		return output, false, err
+	}
+
+	key, err := w.NodeOutputCacheKey(ctx, node, task)
+	if err != nil {
+		log.Warn().Err(err).Str("node_id", node.ID).Msg("Failed to build node output cache key, running node without cache")
+
+		output, err := attemptFunc(ctx)
+		return output, false, err
+	}
+
+	itemsByOutputIndex, ok, err := w.outputCache.Get(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Str("node_id", node.ID).Msg("Failed to read node output cache")
+	}
+
+	if ok {
+		log.Debug().Str("node_id", node.ID).Msg("Node output served from cache")
+
+		return domain.IntegrationOutput{ItemsByOutputIndex: itemsByOutputIndex}, true, nil
+	}
+
+	output, err := attemptFunc(ctx)
+	if err != nil {
+		return output, false, err
+	}
+
+	ttl := node.Settings.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultNodeOutputCacheTTL
+	}
+
+	if err := w.outputCache.Set(ctx, key, output.ItemsByOutputIndex, ttl); err != nil {
+		log.Warn().Err(err).Str("node_id", node.ID).Msg("Failed to write node output cache")
+	}
+
+	return output, false, nil
+}
@@ -0,0 +1,110 @@
+package executor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type memoryOutputCache struct {
+	mutex   sync.Mutex
+	outputs map[string]domain.NodeItemsMap
+}
+
+func (c *memoryOutputCache) Get(ctx context.Context, key string) (domain.NodeItemsMap, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	output, ok := c.outputs[key]
+
+	return output, ok, nil
+}
+
+func (c *memoryOutputCache) Set(ctx context.Context, key string, output domain.NodeItemsMap, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.outputs[key] = output
+
+	return nil
+}
+
+func TestWorkflowExecutorService_CachesNodeOutput(t *testing.T) {
+	calls := 0
+
+	lookup := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		calls++
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("lookup", integrationFunc(lookup))
+
+	store := &memoryHistoryStore{records: map[string]executor.ExecutionRecord{}}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		HistoryStore:        store,
+		OutputCache:         &memoryOutputCache{outputs: map[string]domain.NodeItemsMap{}},
+	})
+
+	workflow := domain.Workflow{
+		ID:          "wf_cache",
+		WorkspaceID: "ws_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "lookup"},
+			{ID: "lookup", Type: domain.NodeTypeAction, IntegrationType: "lookup", Settings: domain.NodeSettings{CacheOutput: true}},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "lookup"},
+		},
+	}
+
+	tests := []struct {
+		executionID string
+		payload     string
+		wantCalls   int
+		wantCached  bool
+	}{
+		{"exec_first", `[{"id": 1}]`, 1, false},
+		{"exec_same_input", `[{"id": 1}]`, 1, true},
+		{"exec_other_input", `[{"id": 2}]`, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.executionID, func(t *testing.T) {
+			_, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: tt.executionID,
+				Workflow:    workflow,
+				EventName:   "trigger",
+				PayloadJSON: tt.payload,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("integration calls = %d, want %d", calls, tt.wantCalls)
+			}
+
+			record, err := store.Get(context.Background(), tt.executionID)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			entries := record.HistoryEntries
+			if len(entries) == 0 || entries[len(entries)-1].NodeID != "lookup" || entries[len(entries)-1].IsCached != tt.wantCached {
+				t.Errorf("history entries = %+v, want lookup entry with is cached %t", entries, tt.wantCached)
+			}
+		})
+	}
+}
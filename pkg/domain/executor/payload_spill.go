@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/mappers"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultSpillThresholdBytes is the size over which node outputs are spilled when a
+// payload store is set without a threshold.
+const DefaultSpillThresholdBytes = 16 << 20
+
+var ErrPayloadStoreNotConfigured = errors.New("items were spilled but no payload store is configured")
+
+// PayloadStore keeps node outputs that are too large to hold in memory. The items are
+// written as a JSON array and read back when a node or the history needs them.
+type PayloadStore interface {
+	Put(ctx context.Context, p PutPayloadParams) (domain.ItemsRef, error)
+	Get(ctx context.Context, ref domain.ItemsRef) ([]domain.Item, error)
+}
+
+// LocalPayloadStore is implemented by payload stores whose items can only be read by
+// this executor. Their items are read back before snapshots and history entries are
+// sent to the platform, which may resume the execution on another executor.
+type LocalPayloadStore interface {
+	PayloadStore
+	IsLocal() bool
+}
+
+type PutPayloadParams struct {
+	WorkspaceID string
+	ExecutionID string
+	Data        []byte // JSON array of the items
+	Count       int
+}
+
+// ResolveNodeItemsMap returns the map with the items of spilled indices read back from
+// the store. The map itself is returned when nothing was spilled.
+func ResolveNodeItemsMap(ctx context.Context, store PayloadStore, itemsByIndex domain.NodeItemsMap) (domain.NodeItemsMap, error) {
+	if !itemsByIndex.HasRefs() {
+		return itemsByIndex, nil
+	}
+
+	if store == nil {
+		return nil, ErrPayloadStoreNotConfigured
+	}
+
+	resolved := make(domain.NodeItemsMap, len(itemsByIndex))
+
+	for index, nodeItems := range itemsByIndex {
+		if nodeItems.Ref == nil {
+			resolved[index] = nodeItems
+			continue
+		}
+
+		items, err := store.Get(ctx, *nodeItems.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spilled items %s: %w", nodeItems.Ref.ID, err)
+		}
+
+		resolved[index] = domain.NodeItems{
+			FromNodeID: nodeItems.FromNodeID,
+			Items:      items,
+		}
+	}
+
+	return resolved, nil
+}
+
+// ResolveEntries resolves the spilled items of history entries, so they can be served
+// to clients that do not know about the payload store.
+func ResolveEntries(ctx context.Context, store PayloadStore, entries []domain.NodeExecutionEntry) ([]domain.NodeExecutionEntry, error) {
+	resolved := make([]domain.NodeExecutionEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		itemsByInputIndex, err := ResolveNodeItemsMap(ctx, store, entry.ItemsByInputIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		itemsByOutputIndex, err := ResolveNodeItemsMap(ctx, store, entry.ItemsByOutputIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.ItemsByInputIndex = itemsByInputIndex
+		entry.ItemsByOutputIndex = itemsByOutputIndex
+
+		resolved = append(resolved, entry)
+	}
+
+	return resolved, nil
+}
+
+// SpillOutput replaces the items of output indices larger than the spill threshold with
+// references to the payload store. A failed write is logged and keeps the items in memory.
+func (w *WorkflowExecutor) SpillOutput(ctx context.Context, nodeID string, itemsByOutputIndex domain.NodeItemsMap) domain.NodeItemsMap {
+	if w.payloadStore == nil || len(itemsByOutputIndex) == 0 {
+		return itemsByOutputIndex
+	}
+
+	threshold := w.spillThresholdBytes
+	if threshold <= 0 {
+		threshold = DefaultSpillThresholdBytes
+	}
+
+	spilled := make(domain.NodeItemsMap, len(itemsByOutputIndex))
+
+	for outputIndex, nodeItems := range itemsByOutputIndex {
+		spilled[outputIndex] = nodeItems
+
+		if nodeItems.Ref != nil || len(nodeItems.Items) == 0 {
+			continue
+		}
+
+		data, err := json.Marshal(nodeItems.Items)
+		if err != nil {
+			log.Warn().Err(err).Str("node_id", nodeID).Msg("Failed to marshal node output, keeping it in memory")
+			continue
+		}
+
+		if int64(len(data)) <= threshold {
+			continue
+		}
+
+		ref, err := w.payloadStore.Put(ctx, PutPayloadParams{
+			WorkspaceID: w.workflow.WorkspaceID,
+			ExecutionID: w.executionID,
+			Data:        data,
+			Count:       len(nodeItems.Items),
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("node_id", nodeID).Msg("Failed to spill node output, keeping it in memory")
+			continue
+		}
+
+		log.Debug().
+			Str("node_id", nodeID).
+			Int("output_index", outputIndex).
+			Int64("size_in_bytes", ref.SizeInBytes).
+			Msg("Spilled node output to payload store")
+
+		spilled[outputIndex] = domain.NodeItems{
+			FromNodeID: nodeItems.FromNodeID,
+			Ref:        &ref,
+		}
+	}
+
+	return spilled
+}
+
+// hasLocalPayloads reports whether spilled items can not be read outside of this executor.
+func (w *WorkflowExecutor) hasLocalPayloads() bool {
+	store, ok := w.payloadStore.(LocalPayloadStore)
+
+	return ok && store.IsLocal()
+}
+
+// PortableSnapshot reads the spilled items of the snapshot back when they are only
+// readable by this executor, so that any executor can resume from it.
+func (w *WorkflowExecutor) PortableSnapshot(ctx context.Context, snapshot *domain.ExecutorStateSnapshot) error {
+	if !w.hasLocalPayloads() {
+		return nil
+	}
+
+	pauseNodeOutput, err := w.ResolveItems(ctx, snapshot.PauseNodeOutput)
+	if err != nil {
+		return err
+	}
+
+	snapshot.PauseNodeOutput = pauseNodeOutput
+
+	for i, task := range snapshot.WaitingTasks {
+		receivedPayloads, err := w.ResolveItems(ctx, task.ReceivedPayloads)
+		if err != nil {
+			return err
+		}
+
+		snapshot.WaitingTasks[i].ReceivedPayloads = receivedPayloads
+	}
+
+	for i, task := range snapshot.QueuedTasks {
+		itemsByInputIndex, err := w.ResolveItems(ctx, task.ItemsByInputIndex)
+		if err != nil {
+			return err
+		}
+
+		snapshot.QueuedTasks[i].ItemsByInputIndex = itemsByInputIndex
+	}
+
+	for nodeID, itemsByOutputIndex := range snapshot.NodeOutputs {
+		resolved, err := w.ResolveItems(ctx, itemsByOutputIndex)
+		if err != nil {
+			return err
+		}
+
+		snapshot.NodeOutputs[nodeID] = resolved
+	}
+
+	return nil
+}
+
+// PortableEntries returns the history entries sent to the platform, with the spilled
+// items read back when they are only readable by this executor. The entries keep their
+// references when the items can not be read.
+func (w *WorkflowExecutor) PortableEntries(ctx context.Context, entries []domain.NodeExecutionEntry) []flowbaker.NodeExecutionEntry {
+	if w.hasLocalPayloads() {
+		resolved, err := ResolveEntries(ctx, w.payloadStore, entries)
+		if err != nil {
+			log.Warn().Err(err).Str("execution_id", w.executionID).Msg("Failed to read spilled items of history entries, sending references")
+		} else {
+			entries = resolved
+		}
+	}
+
+	return mappers.DomainNodeExecutionEntriesToFlowbaker(entries)
+}
+
+// ResolveItems reads the spilled items of a task back from the payload store.
+func (w *WorkflowExecutor) ResolveItems(ctx context.Context, itemsByIndex domain.NodeItemsMap) (domain.NodeItemsMap, error) {
+	return ResolveNodeItemsMap(ctx, w.payloadStore, itemsByIndex)
+}
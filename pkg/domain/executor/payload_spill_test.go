@@ -0,0 +1,284 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type memoryPayloadStore struct {
+	mutex    sync.Mutex
+	payloads map[string][]byte
+}
+
+func (s *memoryPayloadStore) Put(ctx context.Context, p executor.PutPayloadParams) (domain.ItemsRef, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	id := fmt.Sprintf("payload_%d", len(s.payloads))
+	s.payloads[id] = p.Data
+
+	return domain.ItemsRef{ID: id, WorkspaceID: p.WorkspaceID, Count: p.Count, SizeInBytes: int64(len(p.Data))}, nil
+}
+
+func (s *memoryPayloadStore) Get(ctx context.Context, ref domain.ItemsRef) ([]domain.Item, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var items []domain.Item
+	if err := json.Unmarshal(s.payloads[ref.ID], &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func TestWorkflowExecutorService_SpillsLargeOutputs(t *testing.T) {
+	expand := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		items := []domain.Item{}
+		for i := range 100 {
+			items = append(items, map[string]any{"index": i})
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items),
+		}, nil
+	}
+
+	var received []domain.Item
+
+	collect := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		received = input.GetAllItems()
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, []domain.Item{map[string]any{"count": len(received)}}),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("expand", integrationFunc(expand))
+	selector.RegisterCreator("collect", integrationFunc(collect))
+
+	payloadStore := &memoryPayloadStore{payloads: map[string][]byte{}}
+	historyStore := &memoryHistoryStore{records: map[string]executor.ExecutionRecord{}}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+		HistoryStore:        historyStore,
+		PayloadStore:        payloadStore,
+		SpillThresholdBytes: 512,
+	})
+
+	workflow := domain.Workflow{
+		ID:          "wf_spill",
+		WorkspaceID: "ws_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "expand"},
+			{ID: "expand", Type: domain.NodeTypeAction, IntegrationType: "expand"},
+			{ID: "collect", Type: domain.NodeTypeAction, IntegrationType: "collect"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "expand"},
+			{SourceNodeID: "expand", TargetNodeID: "collect"},
+		},
+	}
+
+	_, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_spill",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(received) != 100 {
+		t.Errorf("collect received %d items, want 100", len(received))
+	}
+
+	if len(payloadStore.payloads) != 1 {
+		t.Errorf("spilled payloads = %d, want only the output of expand", len(payloadStore.payloads))
+	}
+
+	record, err := historyStore.Get(context.Background(), "exec_spill")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	found := false
+
+	for _, entry := range record.HistoryEntries {
+		if entry.NodeID != "expand" || entry.EventType != domain.NodeExecuted {
+			continue
+		}
+
+		found = true
+
+		ref := entry.ItemsByOutputIndex[0].Ref
+		if ref == nil || ref.Count != 100 || entry.ItemsByOutputIndex[0].Items != nil {
+			t.Errorf("expand output = %+v, want a reference to 100 items", entry.ItemsByOutputIndex[0])
+		}
+
+		resolved, err := executor.ResolveEntries(context.Background(), payloadStore, []domain.NodeExecutionEntry{entry})
+		if err != nil {
+			t.Fatalf("ResolveEntries() error = %v", err)
+		}
+
+		if len(resolved[0].ItemsByOutputIndex[0].Items) != 100 {
+			t.Errorf("resolved expand output has %d items, want 100", len(resolved[0].ItemsByOutputIndex[0].Items))
+		}
+	}
+
+	if !found {
+		t.Errorf("history entries = %+v, want an executed entry for expand", record.HistoryEntries)
+	}
+
+	for _, nodeExecution := range record.NodeExecutions {
+		if nodeExecution.NodeID == "collect" && nodeExecution.InputItemsCount[0] != 100 {
+			t.Errorf("collect input items count = %d, want 100", nodeExecution.InputItemsCount[0])
+		}
+	}
+}
+
+// localPayloadStore keeps spilled items that only the executor that wrote them can
+// read, like payloads spilled to disk.
+type localPayloadStore struct {
+	memoryPayloadStore
+}
+
+func (s *localPayloadStore) IsLocal() bool {
+	return true
+}
+
+func TestWorkflowExecutor_ResolvesLocalPayloadsBeforeHandOff(t *testing.T) {
+	var workflowExecutor executor.WorkflowExecutor
+
+	var received []domain.Item
+
+	expand := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		items := []domain.Item{}
+		for i := range 100 {
+			items = append(items, map[string]any{"index": i})
+		}
+
+		if input.NodeID == "expand" {
+			workflowExecutor.RequestHandOff()
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, items),
+		}, nil
+	}
+
+	collect := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		received = input.GetAllItems()
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, received),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("expand", integrationFunc(expand))
+	selector.RegisterCreator("collect", integrationFunc(collect))
+
+	client := local.NewClient()
+
+	workflow := domain.Workflow{
+		ID:          "wf_local_spill",
+		WorkspaceID: "ws_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "expand"},
+			{ID: "expand", Type: domain.NodeTypeAction, IntegrationType: "expand"},
+			{ID: "collect", Type: domain.NodeTypeAction, IntegrationType: "collect"},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "expand"},
+			{SourceNodeID: "expand", TargetNodeID: "collect"},
+		},
+	}
+
+	workflowExecutor, err := executor.NewWorkflowExecutor(executor.WorkflowExecutorDeps{
+		ExecutionID:         "exec_local_spill",
+		Workflow:            workflow,
+		Selector:            selector,
+		ExecutorClient:      client,
+		PayloadStore:        &localPayloadStore{memoryPayloadStore{payloads: map[string][]byte{}}},
+		SpillThresholdBytes: 512,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkflowExecutor() error = %v", err)
+	}
+
+	if _, err := workflowExecutor.Execute(context.Background(), "trigger", []domain.Item{map[string]any{"id": 1}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	paused := client.GetPausedExecutions()
+	if len(paused) != 1 {
+		t.Fatalf("paused executions = %d, want the hand-off", len(paused))
+	}
+
+	var snapshot domain.ExecutorStateSnapshot
+	if err := json.Unmarshal(paused[0].ResumeStateJSON, &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal resume state: %v", err)
+	}
+
+	if queued := snapshot.QueuedTasks; len(queued) != 1 || queued[0].ItemsByInputIndex[0].Ref != nil || len(queued[0].ItemsByInputIndex[0].Items) != 100 {
+		t.Fatalf("handed off queue = %+v, want the 100 items of expand", queued)
+	}
+
+	for _, entry := range paused[0].HistoryEntries {
+		for _, nodeItems := range entry.ItemsByOutputIndex {
+			if nodeItems.Ref != nil {
+				t.Errorf("handed off history entry of %s has a reference to local items", entry.NodeID)
+			}
+		}
+	}
+
+	// Another executor with its own disk resumes the execution.
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     client,
+		PayloadStore:        &localPayloadStore{memoryPayloadStore{payloads: map[string][]byte{}}},
+		SpillThresholdBytes: 512,
+	})
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID:           "exec_local_spill",
+		Workflow:              workflow,
+		EventName:             "trigger",
+		PayloadJSON:           `[{"id": 1}]`,
+		ExecutorStateSnapshot: &snapshot,
+	}); err != nil {
+		t.Fatalf("Execute() from the handed off snapshot error = %v", err)
+	}
+
+	if len(received) != 100 {
+		t.Errorf("collect received %d items, want 100", len(received))
+	}
+
+	completed := client.GetCompletedExecutions()
+	if len(completed) != 1 {
+		t.Fatalf("completed executions = %d, want 1", len(completed))
+	}
+
+	for _, entry := range completed[0].HistoryEntries {
+		if entry.NodeID != "collect" || entry.EventType != flowbaker.EventTypeNodeExecuted {
+			continue
+		}
+
+		if output := entry.ItemsByOutputIndex[0]; output.Ref != nil || len(output.Items) != 100 {
+			t.Errorf("completed collect output = %+v, want the 100 items", output)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AttemptFunc func(ctx context.Context) (domain.IntegrationOutput, error)
+
+// ExecuteWithRetry runs attemptFunc until it succeeds or the retry policy of the node
+// gives up. Every failed attempt that is followed by another one is reported to the
+// observer so that the history shows each attempt.
+//
+// Per item actions retry the items that failed on their own through ItemRetryFunc, the
+// node is not attempted again when they give up so that items that succeeded do not
+// run twice.
+func (w *WorkflowExecutor) ExecuteWithRetry(ctx context.Context, node domain.WorkflowNode, execution NodeExecutionTask, attemptFunc AttemptFunc) (domain.IntegrationOutput, error) {
+	for attempt := 1; ; attempt++ {
+		output, err := attemptFunc(ctx)
+		if err == nil {
+			return output, nil
+		}
+
+		// The node ran out of time or was stopped while the attempt ran.
+		if ctx.Err() != nil {
+			return domain.IntegrationOutput{}, context.Cause(ctx)
+		}
+
+		var itemErr domain.ItemRetryError
+		if errors.As(err, &itemErr) {
+			return domain.IntegrationOutput{}, err
+		}
+
+		retry, waitErr := w.WaitForRetry(ctx, node, execution.ItemsByInputIndex, attempt, err)
+		if waitErr != nil {
+			return domain.IntegrationOutput{}, waitErr
+		}
+
+		if !retry {
+			if attempt > 1 {
+				return domain.IntegrationOutput{}, fmt.Errorf("failed after %d attempts: %w", attempt, err)
+			}
+
+			return domain.IntegrationOutput{}, err
+		}
+	}
+}
+
+// ItemRetryFunc returns the function per item actions of the node call when an item
+// fails, nil when the node does not retry.
+func (w *WorkflowExecutor) ItemRetryFunc(node domain.WorkflowNode) domain.ItemRetryFunc {
+	if !node.Settings.RetryPolicy.IsEnabled() {
+		return nil
+	}
+
+	return func(ctx context.Context, item domain.Item, attempt int, err error) bool {
+		retry, _ := w.WaitForRetry(ctx, node, domain.NewNodeItemsMap(0, node.ID, []domain.Item{item}), attempt, err)
+
+		return retry
+	}
+}
+
+// WaitForRetry reports whether the retry policy of the node allows another attempt
+// after the given failed attempt, and waits for its delay when it does. The error is
+// set when the context ends during the delay.
+func (w *WorkflowExecutor) WaitForRetry(ctx context.Context, node domain.WorkflowNode, itemsByInputIndex domain.NodeItemsMap, attempt int, err error) (bool, error) {
+	policy := node.Settings.RetryPolicy
+
+	if !policy.ShouldRetry(attempt, err) {
+		return false, nil
+	}
+
+	maxAttempts := policy.GetMaxAttempts()
+	delay := policy.GetDelay(attempt)
+	errorClass := domain.ClassifyError(err)
+
+	log.Warn().
+		Err(err).
+		Str("node_id", node.ID).
+		Int("attempt", attempt).
+		Int("max_attempts", maxAttempts).
+		Str("error_class", string(errorClass)).
+		Dur("retry_delay", delay).
+		Msg("Node attempt failed, retrying")
+
+	if err := w.observer.Notify(ctx, NodeExecutionRetriedEvent{
+		NodeID:            node.ID,
+		ItemsByInputIndex: itemsByInputIndex,
+		Attempt:           attempt,
+		MaxAttempts:       maxAttempts,
+		Error:             err,
+		ErrorClass:        errorClass,
+		RetryDelay:        delay,
+		Timestamp:         time.Now(),
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to notify node execution retried")
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false, context.Cause(ctx)
+	case <-timer.C:
+		return true, nil
+	}
+}
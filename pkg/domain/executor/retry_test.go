@@ -0,0 +1,198 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+func retryAttempts(entries []domain.NodeExecutionEntry) []int {
+	attempts := []int{}
+
+	for _, entry := range entries {
+		if entry.EventType == domain.NodeRetried {
+			attempts = append(attempts, entry.Attempt)
+		}
+	}
+
+	return attempts
+}
+
+func TestWorkflowExecutor_RetriesNode(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error
+		wantRuns     int
+		wantAttempts []int
+		wantFailed   bool
+	}{
+		{
+			name:         "transient errors",
+			errs:         []error{errors.New("status 503"), errors.New("connection reset by peer")},
+			wantRuns:     3,
+			wantAttempts: []int{1, 2},
+		},
+		{
+			name:         "client error is not retried by default",
+			errs:         []error{errors.New("status 404")},
+			wantRuns:     1,
+			wantAttempts: []int{},
+			wantFailed:   true,
+		},
+		{
+			name:         "attempts exhausted",
+			errs:         []error{errors.New("status 500"), errors.New("status 500"), errors.New("status 500")},
+			wantRuns:     3,
+			wantAttempts: []int{1, 2},
+			wantFailed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+
+			flaky := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+				runs++
+
+				if runs <= len(tt.errs) {
+					return domain.IntegrationOutput{}, tt.errs[runs-1]
+				}
+
+				return domain.IntegrationOutput{
+					ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+				}, nil
+			}
+
+			selector := domain.NewIntegrationSelector()
+			selector.RegisterCreator("flaky", integrationFunc(flaky))
+
+			service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+				IntegrationSelector: selector,
+				FlowbakerClient:     completingClient{},
+			})
+
+			workflow := domain.Workflow{
+				ID: "wf_retry",
+				Nodes: []domain.WorkflowNode{
+					{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "flaky"},
+					{
+						ID:              "action",
+						Type:            domain.NodeTypeAction,
+						IntegrationType: "flaky",
+						Settings: domain.NodeSettings{
+							RetryPolicy: domain.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond},
+						},
+					},
+				},
+				Edges: []domain.WorkflowEdge{
+					{SourceNodeID: "trigger", TargetNodeID: "action"},
+				},
+			}
+
+			result, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: "exec_retry",
+				Workflow:    workflow,
+				EventName:   "trigger",
+				PayloadJSON: `[{"id": 1}]`,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+
+			if attempts := retryAttempts(result.NodeExecutionResults); !reflect.DeepEqual(attempts, tt.wantAttempts) {
+				t.Errorf("retried attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+
+			if failed := hasFailedNode(result); failed != tt.wantFailed {
+				t.Errorf("failed = %t, want %t", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestWorkflowExecutor_RetriesFailedItemsOnly(t *testing.T) {
+	var mutex sync.Mutex
+	runsByID := map[float64]int{}
+
+	actionManager := domain.NewIntegrationActionManager().
+		AddPerItem("send", func(ctx context.Context, params domain.IntegrationInput, item domain.Item) (domain.Item, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			id := item.(map[string]any)["id"].(float64)
+			runsByID[id]++
+
+			if id == 2 && runsByID[id] < 3 {
+				return nil, fmt.Errorf("item %v: status 502", id)
+			}
+
+			return item, nil
+		})
+
+	send := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		return actionManager.Run(ctx, input.ActionType, input)
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("messages", integrationFunc(send))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     completingClient{},
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_item_retry",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "messages"},
+			{
+				ID:              "send",
+				Type:            domain.NodeTypeAction,
+				IntegrationType: "messages",
+				ActionNodeOpts:  domain.ActionNodeOpts{ActionType: "send"},
+				Settings: domain.NodeSettings{
+					RetryPolicy: domain.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond},
+				},
+			},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "send"},
+		},
+	}
+
+	result, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_item_retry",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := map[float64]int{1: 1, 2: 3, 3: 1}; !reflect.DeepEqual(runsByID, want) {
+		t.Errorf("runs by item = %v, want %v", runsByID, want)
+	}
+
+	if attempts := retryAttempts(result.NodeExecutionResults); !reflect.DeepEqual(attempts, []int{1, 2}) {
+		t.Errorf("retried attempts = %v, want [1 2]", attempts)
+	}
+
+	for _, entry := range result.NodeExecutionResults {
+		if entry.EventType == domain.NodeExecuted && entry.NodeID == "send" && len(entry.ItemsByOutputIndex[0].Items) != 3 {
+			t.Errorf("send output = %+v, want all three items", entry.ItemsByOutputIndex)
+		}
+	}
+}
@@ -32,7 +32,7 @@ func NewWaitingExecutionTask(nodeID string, payloads domain.NodeItemsMap) Waitin
 	}
 }
 
-func (t WaitingExecutionTask) AddItems(fromNodeID string, inputIndex int, items []domain.Item) {
+func (t WaitingExecutionTask) AddItems(inputIndex int, nodeItems domain.NodeItems) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
@@ -40,7 +40,7 @@ func (t WaitingExecutionTask) AddItems(fromNodeID string, inputIndex int, items
 		t.ReceivedPayloads = domain.NodeItemsMap{}
 	}
 
-	t.ReceivedPayloads.Set(inputIndex, fromNodeID, items)
+	t.ReceivedPayloads[inputIndex] = nodeItems
 }
 
 func (t *WaitingExecutionTask) ToExecutionTask() NodeExecutionTask {
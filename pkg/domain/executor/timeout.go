@@ -0,0 +1,118 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+// NodeTimeoutError is the cause of a node context that ran out of its Timeout setting.
+type NodeTimeoutError struct {
+	NodeID  string
+	Timeout time.Duration
+}
+
+func (e NodeTimeoutError) Error() string {
+	return fmt.Sprintf("node %s timed out after %s", e.NodeID, e.Timeout)
+}
+
+func (e NodeTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// WorkflowTimeoutError is the cause of an execution context that ran out of the
+// workflow's MaxDuration setting.
+type WorkflowTimeoutError struct {
+	MaxDuration time.Duration
+}
+
+func (e WorkflowTimeoutError) Error() string {
+	return fmt.Sprintf("workflow execution exceeded max duration of %s", e.MaxDuration)
+}
+
+func (e WorkflowTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+func IsTimeoutError(err error) bool {
+	var nodeTimeoutErr NodeTimeoutError
+	var workflowTimeoutErr WorkflowTimeoutError
+
+	return errors.As(err, &nodeTimeoutErr) || errors.As(err, &workflowTimeoutErr)
+}
+
+func IsWorkflowTimeoutError(err error) bool {
+	var workflowTimeoutErr WorkflowTimeoutError
+
+	return errors.As(err, &workflowTimeoutErr)
+}
+
+// NewWorkflowContext bounds the execution context by the workflow's MaxDuration setting.
+func (w *WorkflowExecutor) NewWorkflowContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	maxDuration := w.workflow.Settings.MaxDuration
+	if maxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, maxDuration, WorkflowTimeoutError{MaxDuration: maxDuration})
+}
+
+// NewNodeContext bounds the node context by the node's Timeout setting, retries of the
+// node share the same deadline.
+func (w *WorkflowExecutor) NewNodeContext(ctx context.Context, node domain.WorkflowNode) (context.Context, context.CancelFunc) {
+	timeout := node.Settings.Timeout
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, NodeTimeoutError{NodeID: node.ID, Timeout: timeout})
+}
+
+type nodeExecutionOutcome struct {
+	result NodeExecutionResult
+	err    error
+}
+
+type abandonedRunKey struct{}
+
+// IsRunAbandoned reports whether ctx belongs to a run that RunUntilDone stopped waiting
+// for. The node of such a run has already been reported as failed, so nothing it still
+// does may be reported.
+func IsRunAbandoned(ctx context.Context) bool {
+	abandoned, ok := ctx.Value(abandonedRunKey{}).(*atomic.Bool)
+
+	return ok && abandoned.Load()
+}
+
+// RunUntilDone runs fn in its own goroutine and returns as soon as ctx is done, so an
+// integration that ignores cancellation cannot hold the execution past its deadline.
+// The result of an abandoned run is discarded and the context given to fn is marked, see
+// IsRunAbandoned.
+func RunUntilDone(ctx context.Context, fn func(ctx context.Context) (NodeExecutionResult, error)) (NodeExecutionResult, error) {
+	done := make(chan nodeExecutionOutcome, 1)
+
+	abandoned := &atomic.Bool{}
+	runCtx := context.WithValue(ctx, abandonedRunKey{}, abandoned)
+
+	go func() {
+		result, err := fn(runCtx)
+		done <- nodeExecutionOutcome{result: result, err: err}
+	}()
+
+	select {
+	case outcome := <-done:
+		if outcome.err != nil && ctx.Err() != nil {
+			return NodeExecutionResult{}, context.Cause(ctx)
+		}
+
+		return outcome.result, outcome.err
+	case <-ctx.Done():
+		abandoned.Store(true)
+
+		return NodeExecutionResult{}, context.Cause(ctx)
+	}
+}
@@ -0,0 +1,227 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+)
+
+type nopEventPublisher struct{}
+
+func (nopEventPublisher) PublishEvent(ctx context.Context, event domain.Event) error {
+	return nil
+}
+
+// persistingClient records the entries the executor persists while the execution runs.
+type persistingClient struct {
+	completingClient
+
+	mutex   sync.Mutex
+	entries []flowbaker.NodeExecutionEntry
+}
+
+func (c *persistingClient) PersistNodeExecution(ctx context.Context, workspaceID string, req *flowbaker.PersistNodeExecutionRequest) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = append(c.entries, req.Entry)
+
+	return nil
+}
+
+func (c *persistingClient) countEntries(eventType flowbaker.EventType) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	count := 0
+
+	for _, entry := range c.entries {
+		if entry.EventType == eventType {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestWorkflowExecutor_Timeouts(t *testing.T) {
+	// released is closed when the test ends, integrations that ignore cancellation block
+	// on it so that their runs are abandoned.
+	released := make(chan struct{})
+	defer close(released)
+
+	tests := []struct {
+		name         string
+		workflow     domain.WorkflowSettings
+		node         domain.NodeSettings
+		run          func(ctx context.Context) error
+		wantError    string
+		wantAttempts []int
+	}{
+		{
+			name:     "workflow max duration",
+			workflow: domain.WorkflowSettings{MaxDuration: 20 * time.Millisecond},
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantError:    "workflow execution exceeded max duration of 20ms",
+			wantAttempts: []int{},
+		},
+		{
+			name: "node timeout",
+			node: domain.NodeSettings{Timeout: 20 * time.Millisecond},
+			run: func(ctx context.Context) error {
+				<-released
+				return nil
+			},
+			wantError:    "node action timed out after 20ms",
+			wantAttempts: []int{},
+		},
+		{
+			name: "timeout during retry backoff",
+			node: domain.NodeSettings{
+				Timeout:     20 * time.Millisecond,
+				RetryPolicy: domain.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Minute},
+			},
+			run: func(ctx context.Context) error {
+				return errors.New("status 503")
+			},
+			wantError:    "node action timed out after 20ms",
+			wantAttempts: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+				if err := tt.run(ctx); err != nil {
+					return domain.IntegrationOutput{}, err
+				}
+
+				return domain.IntegrationOutput{
+					ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+				}, nil
+			}
+
+			selector := domain.NewIntegrationSelector()
+			selector.RegisterCreator("slow", integrationFunc(action))
+
+			service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+				IntegrationSelector: selector,
+				FlowbakerClient:     completingClient{},
+			})
+
+			workflow := domain.Workflow{
+				ID:       "wf_timeout",
+				Settings: tt.workflow,
+				Nodes: []domain.WorkflowNode{
+					{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "slow"},
+					{ID: "action", Type: domain.NodeTypeAction, IntegrationType: "slow", Settings: tt.node},
+				},
+				Edges: []domain.WorkflowEdge{
+					{SourceNodeID: "trigger", TargetNodeID: "action"},
+				},
+			}
+
+			startedAt := time.Now()
+
+			result, err := service.Execute(context.Background(), executor.ExecuteParams{
+				ExecutionID: "exec_timeout",
+				Workflow:    workflow,
+				EventName:   "trigger",
+				PayloadJSON: `[{"id": 1}]`,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if elapsed := time.Since(startedAt); elapsed > 5*time.Second {
+				t.Errorf("execution took %s, want it to stop at the timeout", elapsed)
+			}
+
+			entries := result.NodeExecutionResults
+			last := entries[len(entries)-1]
+
+			if last.NodeID != "action" || last.EventType != domain.NodeFailed || !strings.Contains(last.Error, tt.wantError) {
+				t.Errorf("last entry = %+v, want action to fail with %q", last, tt.wantError)
+			}
+
+			if attempts := retryAttempts(entries); len(attempts) != len(tt.wantAttempts) {
+				t.Errorf("retried attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWorkflowExecutor_AbandonedRunIsNotReported(t *testing.T) {
+	release := make(chan struct{})
+	returned := make(chan struct{})
+
+	stubborn := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		defer close(returned)
+
+		// Ignores cancellation and fails with a retryable error once the run has been
+		// abandoned.
+		<-release
+
+		return domain.IntegrationOutput{}, errors.New("status 503")
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("stubborn", integrationFunc(stubborn))
+
+	client := &persistingClient{}
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector:   selector,
+		FlowbakerClient:       client,
+		OrderedEventPublisher: nopEventPublisher{},
+	})
+
+	workflow := domain.Workflow{
+		ID: "wf_abandoned",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "stubborn"},
+			{
+				ID:              "action",
+				Type:            domain.NodeTypeAction,
+				IntegrationType: "stubborn",
+				Settings: domain.NodeSettings{
+					Timeout:     20 * time.Millisecond,
+					RetryPolicy: domain.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond},
+				},
+			},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "action"},
+		},
+	}
+
+	if _, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID:  "exec_abandoned",
+		Workflow:     workflow,
+		EventName:    "trigger",
+		PayloadJSON:  `[{"id": 1}]`,
+		EnableEvents: true,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	close(release)
+	<-returned
+
+	// The abandoned run would report a retry right after the integration returns.
+	time.Sleep(20 * time.Millisecond)
+
+	if retried := client.countEntries(flowbaker.EventTypeNodeRetried); retried != 0 {
+		t.Errorf("got %d retries persisted after the run was abandoned, want none", retried)
+	}
+}
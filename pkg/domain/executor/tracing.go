@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"github.com/flowbaker/flowbaker/pkg/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/flowbaker/flowbaker/pkg/domain/executor")
+
+const (
+	attributeWorkspaceID     = attribute.Key("flowbaker.workspace.id")
+	attributeWorkflowID      = attribute.Key("flowbaker.workflow.id")
+	attributeExecutionID     = attribute.Key("flowbaker.execution.id")
+	attributeTriggerNodeID   = attribute.Key("flowbaker.trigger.node_id")
+	attributeIsResume        = attribute.Key("flowbaker.execution.resume")
+	attributeIsTesting       = attribute.Key("flowbaker.execution.testing")
+	attributeNodeID          = attribute.Key("flowbaker.node.id")
+	attributeNodeType        = attribute.Key("flowbaker.node.type")
+	attributeIntegrationType = attribute.Key("flowbaker.integration.type")
+	attributeActionType      = attribute.Key("flowbaker.action.type")
+	attributeInputItems      = attribute.Key("flowbaker.node.input_items")
+	attributeOutputItems     = attribute.Key("flowbaker.node.output_items")
+)
+
+// endSpan marks the span as failed when err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func countItems(itemsByIndex domain.NodeItemsMap) int {
+	count := 0
+	for _, nodeItems := range itemsByIndex {
+		count += nodeItems.Count()
+	}
+
+	return count
+}
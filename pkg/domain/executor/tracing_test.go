@@ -0,0 +1,126 @@
+package executor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWorkflowExecutor_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	integrationSpanIDs := map[string]trace.SpanID{}
+
+	passthrough := func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		integrationSpanIDs[input.NodeID] = trace.SpanContextFromContext(ctx).SpanID()
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}
+
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator("passthrough", integrationFunc(passthrough))
+
+	service := executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+		IntegrationSelector: selector,
+		FlowbakerClient:     local.NewClient(),
+	})
+
+	workflow := domain.Workflow{
+		ID:          "wf_tracing",
+		WorkspaceID: "ws_1",
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: "passthrough"},
+			{ID: "fetch", Type: domain.NodeTypeAction, IntegrationType: "passthrough", ActionNodeOpts: domain.ActionNodeOpts{ActionType: "get"}},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "fetch"},
+		},
+	}
+
+	_, err := service.Execute(context.Background(), executor.ExecuteParams{
+		ExecutionID: "exec_1",
+		Workflow:    workflow,
+		EventName:   "trigger",
+		PayloadJSON: `[{"id": 1}, {"id": 2}]`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	spansByNodeID := map[string]sdktrace.ReadOnlySpan{}
+	var workflowSpan sdktrace.ReadOnlySpan
+
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "workflow.execute":
+			workflowSpan = span
+		case "node.execute":
+			spansByNodeID[spanAttribute(span, "flowbaker.node.id").AsString()] = span
+		}
+	}
+
+	if workflowSpan == nil {
+		t.Fatal("no workflow.execute span was recorded")
+	}
+
+	if got := spanAttribute(workflowSpan, "flowbaker.execution.id").AsString(); got != "exec_1" {
+		t.Errorf("workflow span execution id = %q, want exec_1", got)
+	}
+
+	if _, ok := integrationSpanIDs["fetch"]; !ok {
+		t.Fatal("integration of node fetch did not run")
+	}
+
+	fetchSpan, ok := spansByNodeID["fetch"]
+	if !ok || len(spansByNodeID) != 2 {
+		t.Fatalf("got node spans for %v, want trigger and fetch", spansByNodeID)
+	}
+
+	for nodeID, span := range spansByNodeID {
+		if span.Parent().SpanID() != workflowSpan.SpanContext().SpanID() {
+			t.Errorf("span of node %s is not a child of the workflow span", nodeID)
+		}
+
+		// Trigger nodes pass their payload through without creating the integration
+		if spanID, ok := integrationSpanIDs[nodeID]; ok && spanID != span.SpanContext().SpanID() {
+			t.Errorf("integration of node %s did not run in the node span", nodeID)
+		}
+	}
+
+	checks := map[string]string{
+		"flowbaker.integration.type": "passthrough",
+		"flowbaker.action.type":      "get",
+	}
+
+	for key, want := range checks {
+		if got := spanAttribute(fetchSpan, attribute.Key(key)).AsString(); got != want {
+			t.Errorf("fetch span %s = %q, want %q", key, got, want)
+		}
+	}
+
+	if got := spanAttribute(fetchSpan, "flowbaker.node.output_items").AsInt64(); got != 2 {
+		t.Errorf("fetch span output items = %d, want 2", got)
+	}
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
@@ -0,0 +1,563 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+
+type ProblemSeverity string
+
+const (
+	// ProblemSeverityError is a problem that makes the execution fail.
+	ProblemSeverityError ProblemSeverity = "error"
+	// ProblemSeverityWarning is a problem that may make the execution fail or misbehave.
+	ProblemSeverityWarning ProblemSeverity = "warning"
+)
+
+type WorkflowProblem struct {
+	NodeID   string
+	Severity ProblemSeverity
+	Message  string
+}
+
+func (p WorkflowProblem) String() string {
+	if p.NodeID == "" {
+		return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+	}
+
+	return fmt.Sprintf("%s: node %s: %s", p.Severity, p.NodeID, p.Message)
+}
+
+type WorkflowValidationError struct {
+	Problems []WorkflowProblem
+}
+
+func (e *WorkflowValidationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, problem.String())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrInvalidWorkflow, strings.Join(messages, "; "))
+}
+
+func (e *WorkflowValidationError) Unwrap() error {
+	return ErrInvalidWorkflow
+}
+
+// WorkflowValidator finds the problems the WorkflowExecutor would only discover while
+// running a workflow, using the registered integrations and their schemas.
+type WorkflowValidator struct {
+	integrationSelector domain.IntegrationSelector
+	schemasByType       map[domain.IntegrationType]domain.Integration
+}
+
+type WorkflowValidatorDependencies struct {
+	IntegrationSelector domain.IntegrationSelector
+	Schemas             []domain.Integration
+}
+
+func NewWorkflowValidator(deps WorkflowValidatorDependencies) *WorkflowValidator {
+	schemasByType := map[domain.IntegrationType]domain.Integration{}
+
+	for integrationType, schema := range containerSchemasByIntegrationType {
+		schemasByType[integrationType] = schema
+	}
+
+	for _, schema := range deps.Schemas {
+		schemasByType[schema.ID] = schema
+	}
+
+	return &WorkflowValidator{
+		integrationSelector: deps.IntegrationSelector,
+		schemasByType:       schemasByType,
+	}
+}
+
+// Check returns a WorkflowValidationError holding the problems with error severity,
+// warnings do not prevent the workflow from running. Missing required properties are
+// only warnings when testing a workflow, so that it can be tried out while some of its
+// nodes are still being configured.
+func (v *WorkflowValidator) Check(ctx context.Context, workflow domain.Workflow, isTestingWorkflow bool) error {
+	errorProblems := []WorkflowProblem{}
+
+	for _, problem := range v.validate(ctx, workflow, isTestingWorkflow) {
+		if problem.Severity == ProblemSeverityError {
+			errorProblems = append(errorProblems, problem)
+		}
+	}
+
+	if len(errorProblems) == 0 {
+		return nil
+	}
+
+	return &WorkflowValidationError{Problems: errorProblems}
+}
+
+// Validate returns all problems of the workflow, ordered by node.
+func (v *WorkflowValidator) Validate(ctx context.Context, workflow domain.Workflow) []WorkflowProblem {
+	return v.validate(ctx, workflow, false)
+}
+
+func (v *WorkflowValidator) validate(ctx context.Context, workflow domain.Workflow, isTestingWorkflow bool) []WorkflowProblem {
+	requiredPropertySeverity := ProblemSeverityError
+	if isTestingWorkflow {
+		requiredPropertySeverity = ProblemSeverityWarning
+	}
+
+	problems := []WorkflowProblem{}
+
+	addProblem := func(nodeID string, severity ProblemSeverity, format string, args ...any) {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   nodeID,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	nodesByID := map[string]domain.WorkflowNode{}
+
+	for _, node := range workflow.Nodes {
+		if _, ok := nodesByID[node.ID]; ok {
+			addProblem(node.ID, ProblemSeverityError, "node ID is used by more than one node")
+			continue
+		}
+
+		nodesByID[node.ID] = node
+	}
+
+	for _, node := range workflow.Nodes {
+		if node.ParentID != "" {
+			parent, ok := nodesByID[node.ParentID]
+			if !ok {
+				addProblem(node.ID, ProblemSeverityError, "parent node %s not found", node.ParentID)
+			} else if parent.Type != domain.NodeTypeContainer {
+				addProblem(node.ID, ProblemSeverityError, "parent node %s is not a container", node.ParentID)
+			}
+		}
+
+		problems = append(problems, v.validateNode(ctx, node, requiredPropertySeverity)...)
+	}
+
+	for _, edge := range workflow.Edges {
+		problems = append(problems, v.validateEdge(edge, nodesByID)...)
+	}
+
+	for _, node := range workflow.Nodes {
+		if v.hasItemErrorOutput(node) && !hasOutgoingEdge(workflow, node.ID, domain.ItemErrorOutputIndex) {
+			addProblem(node.ID, ProblemSeverityWarning, "node continues on item errors but its %s output is not connected, failed items are dropped", strings.ToLower(domain.ItemErrorOutputHandle.Text))
+		}
+	}
+
+	for _, cycle := range findCycles(workflow) {
+		if hasExecutionLimit(workflow, cycle) {
+			continue
+		}
+
+		addProblem(cycle[0], ProblemSeverityWarning, "nodes %s form a cycle without an execution limit, it stops after %d executions per node", strings.Join(cycle, ", "), DefaultNodeExecutionLimit)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].NodeID < problems[j].NodeID
+	})
+
+	return problems
+}
+
+func (v *WorkflowValidator) validateNode(ctx context.Context, node domain.WorkflowNode, requiredPropertySeverity ProblemSeverity) []WorkflowProblem {
+	problems := []WorkflowProblem{}
+
+	addProblem := func(severity ProblemSeverity, format string, args ...any) {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   node.ID,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	schema, hasSchema := v.schemasByType[node.IntegrationType]
+
+	switch node.Type {
+	case domain.NodeTypeTrigger:
+		if !hasSchema {
+			return problems
+		}
+
+		if _, ok := schema.GetTriggerByType(node.TriggerNodeOpts.EventType); !ok {
+			addProblem(ProblemSeverityError, "trigger %s not found in integration %s", node.TriggerNodeOpts.EventType, node.IntegrationType)
+		}
+
+	case domain.NodeTypeAction:
+		// Nodes used by other nodes, e.g. AI agent tools, are not run by the executor.
+		if node.UsageContext != "" && node.UsageContext != string(domain.UsageContextWorkflow) {
+			return problems
+		}
+
+		if _, err := v.integrationSelector.SelectCreator(ctx, domain.SelectIntegrationParams{
+			IntegrationType: node.IntegrationType,
+		}); err != nil {
+			addProblem(ProblemSeverityError, "%v", err)
+		}
+
+		if !hasSchema {
+			return problems
+		}
+
+		action, ok := schema.GetActionByType(node.ActionNodeOpts.ActionType)
+		if !ok {
+			addProblem(ProblemSeverityError, "action %s not found in integration %s", node.ActionNodeOpts.ActionType, node.IntegrationType)
+			return problems
+		}
+
+		for _, key := range missingRequiredProperties(action.Properties, node.IntegrationSettings) {
+			addProblem(requiredPropertySeverity, "required property %s has no value", key)
+		}
+
+	case domain.NodeTypeContainer:
+		if !hasSchema {
+			addProblem(ProblemSeverityError, "%v: %s", ErrContainerNotFound, node.IntegrationType)
+			return problems
+		}
+
+		container, ok := schema.GetContainerByType(node.ContainerNodeOpts.ContainerType)
+		if !ok {
+			addProblem(ProblemSeverityError, "%v: %s/%s", ErrContainerNotFound, node.IntegrationType, node.ContainerNodeOpts.ContainerType)
+			return problems
+		}
+
+		for _, key := range missingRequiredProperties(container.Properties, node.IntegrationSettings) {
+			addProblem(requiredPropertySeverity, "required property %s has no value", key)
+		}
+
+	default:
+		addProblem(ProblemSeverityError, "unknown node type %q", node.Type)
+	}
+
+	return problems
+}
+
+func (v *WorkflowValidator) validateEdge(edge domain.WorkflowEdge, nodesByID map[string]domain.WorkflowNode) []WorkflowProblem {
+	problems := []WorkflowProblem{}
+
+	source, hasSource := nodesByID[edge.SourceNodeID]
+	target, hasTarget := nodesByID[edge.TargetNodeID]
+
+	if !hasSource {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   edge.TargetNodeID,
+			Severity: ProblemSeverityError,
+			Message:  fmt.Sprintf("incoming edge from node %s that does not exist", edge.SourceNodeID),
+		})
+	}
+
+	if !hasTarget {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   edge.SourceNodeID,
+			Severity: ProblemSeverityError,
+			Message:  fmt.Sprintf("outgoing edge to node %s that does not exist", edge.TargetNodeID),
+		})
+	}
+
+	if !hasSource || !hasTarget {
+		return problems
+	}
+
+	if edge.SourceControlID != "" {
+		if message := v.validateControl(source, edge.SourceControlID); message != "" {
+			problems = append(problems, WorkflowProblem{NodeID: source.ID, Severity: ProblemSeverityError, Message: message})
+		}
+	}
+
+	if edge.TargetControlID != "" {
+		if message := v.validateControl(target, edge.TargetControlID); message != "" {
+			problems = append(problems, WorkflowProblem{NodeID: target.ID, Severity: ProblemSeverityError, Message: message})
+		}
+	}
+
+	if edge.IsControlEdge() {
+		return problems
+	}
+
+	if edge.SourceIndex == domain.ItemErrorOutputIndex && !v.hasItemErrorOutput(source) {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   source.ID,
+			Severity: ProblemSeverityError,
+			Message:  fmt.Sprintf("edge to node %s leaves the %s output but the node does not continue on item errors", target.ID, strings.ToLower(domain.ItemErrorOutputHandle.Text)),
+		})
+	}
+
+	outputIndices := v.getHandleIndices(source, func(handles domain.ContextHandles) []domain.NodeHandle { return handles.Output })
+	if edge.SourceIndex != domain.ItemErrorOutputIndex && len(outputIndices) > 0 && !slices.Contains(outputIndices, edge.SourceIndex) {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   source.ID,
+			Severity: ProblemSeverityError,
+			Message:  fmt.Sprintf("edge to node %s leaves output %d that does not exist", target.ID, edge.SourceIndex),
+		})
+	}
+
+	inputIndices := v.getHandleIndices(target, func(handles domain.ContextHandles) []domain.NodeHandle { return handles.Input })
+	if len(inputIndices) > 0 && !slices.Contains(inputIndices, edge.TargetIndex) {
+		problems = append(problems, WorkflowProblem{
+			NodeID:   target.ID,
+			Severity: ProblemSeverityError,
+			Message:  fmt.Sprintf("edge from node %s targets input %d that does not exist", source.ID, edge.TargetIndex),
+		})
+	}
+
+	return problems
+}
+
+func (v *WorkflowValidator) validateControl(node domain.WorkflowNode, controlID string) string {
+	if node.Type != domain.NodeTypeContainer {
+		return fmt.Sprintf("edge references control %s but the node is not a container", controlID)
+	}
+
+	schema, ok := v.schemasByType[node.IntegrationType]
+	if !ok {
+		return ""
+	}
+
+	container, ok := schema.GetContainerByType(node.ContainerNodeOpts.ContainerType)
+	if !ok {
+		return ""
+	}
+
+	if _, ok := container.GetControlByID(controlID); !ok {
+		return fmt.Sprintf("%v: %s", ErrContainerControlAbsent, controlID)
+	}
+
+	return ""
+}
+
+// hasItemErrorOutput reports whether the output handles of the node include
+// ItemErrorOutputHandle.
+func (v *WorkflowValidator) hasItemErrorOutput(node domain.WorkflowNode) bool {
+	if node.Type != domain.NodeTypeAction {
+		return false
+	}
+
+	action := domain.IntegrationAction{ActionType: node.ActionNodeOpts.ActionType}
+
+	if schema, ok := v.schemasByType[node.IntegrationType]; ok {
+		if schemaAction, ok := schema.GetActionByType(node.ActionNodeOpts.ActionType); ok {
+			action = schemaAction
+		}
+	}
+
+	return slices.ContainsFunc(action.GetOutputHandles(domain.UsageContextWorkflow, node.Settings), func(handle domain.NodeHandle) bool {
+		return handle.Index == domain.ItemErrorOutputIndex
+	})
+}
+
+// getHandleIndices returns the indices of the handles the schema declares for the node,
+// or nil when they are unknown. Nodes without declared output handles, e.g. switches,
+// create their outputs from their settings.
+func (v *WorkflowValidator) getHandleIndices(node domain.WorkflowNode, selectHandles func(handles domain.ContextHandles) []domain.NodeHandle) []int {
+	schema, ok := v.schemasByType[node.IntegrationType]
+	if !ok {
+		return nil
+	}
+
+	var handlesByContext map[domain.ActionUsageContext]domain.ContextHandles
+
+	switch node.Type {
+	case domain.NodeTypeTrigger:
+		trigger, ok := schema.GetTriggerByType(node.TriggerNodeOpts.EventType)
+		if !ok {
+			return nil
+		}
+
+		handlesByContext = trigger.HandlesByContext
+	case domain.NodeTypeAction:
+		action, ok := schema.GetActionByType(node.ActionNodeOpts.ActionType)
+		if !ok {
+			return nil
+		}
+
+		handlesByContext = action.HandlesByContext
+	case domain.NodeTypeContainer:
+		container, ok := schema.GetContainerByType(node.ContainerNodeOpts.ContainerType)
+		if !ok {
+			return nil
+		}
+
+		handlesByContext = container.HandlesByContext
+	default:
+		return nil
+	}
+
+	indices := []int{}
+
+	for _, handles := range handlesByContext {
+		for _, handle := range selectHandles(handles) {
+			indices = append(indices, handle.Index)
+		}
+	}
+
+	return indices
+}
+
+// missingRequiredProperties returns the keys of the required properties that are shown
+// for the given settings but have no value and no default.
+func missingRequiredProperties(properties []domain.NodeProperty, settings map[string]any) []string {
+	missing := []string{}
+
+	for _, property := range properties {
+		if !property.Required || property.Hidden || property.Default != nil {
+			continue
+		}
+
+		if !isPropertyShown(property, settings) {
+			continue
+		}
+
+		value, ok := settings[property.Key]
+		if !ok || value == nil || value == "" {
+			missing = append(missing, property.Key)
+		}
+	}
+
+	return missing
+}
+
+func isPropertyShown(property domain.NodeProperty, settings map[string]any) bool {
+	if property.DependsOn != nil && !valuesEqual(settings[property.DependsOn.PropertyKey], property.DependsOn.Value) {
+		return false
+	}
+
+	if property.ShowIf != nil && !containsValue(property.ShowIf.Values, settings[property.ShowIf.PropertyKey]) {
+		return false
+	}
+
+	if property.HideIf != nil && containsValue(property.HideIf.Values, settings[property.HideIf.PropertyKey]) {
+		return false
+	}
+
+	return true
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if valuesEqual(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// valuesEqual compares setting values loosely, settings decoded from JSON hold numbers
+// as float64 while schemas declare them as int.
+func valuesEqual(a, b any) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	return a != nil && b != nil && fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// findCycles returns the node IDs of every cycle formed by regular edges, each cycle
+// sorted. Loops over container controls are bounded by the container and left out.
+func findCycles(workflow domain.Workflow) [][]string {
+	successors := map[string][]string{}
+
+	for _, edge := range workflow.Edges {
+		if edge.IsControlEdge() {
+			continue
+		}
+
+		successors[edge.SourceNodeID] = append(successors[edge.SourceNodeID], edge.TargetNodeID)
+	}
+
+	// Tarjan's strongly connected components algorithm.
+	index := 0
+	indices := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	cycles := [][]string{}
+
+	var connect func(nodeID string)
+	connect = func(nodeID string) {
+		indices[nodeID] = index
+		lowLinks[nodeID] = index
+		index++
+
+		stack = append(stack, nodeID)
+		onStack[nodeID] = true
+
+		for _, successor := range successors[nodeID] {
+			if _, visited := indices[successor]; !visited {
+				connect(successor)
+				lowLinks[nodeID] = min(lowLinks[nodeID], lowLinks[successor])
+			} else if onStack[successor] {
+				lowLinks[nodeID] = min(lowLinks[nodeID], indices[successor])
+			}
+		}
+
+		if lowLinks[nodeID] != indices[nodeID] {
+			return
+		}
+
+		component := []string{}
+
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+
+			component = append(component, last)
+
+			if last == nodeID {
+				break
+			}
+		}
+
+		if len(component) > 1 || slices.Contains(successors[nodeID], nodeID) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range workflow.Nodes {
+		if _, visited := indices[node.ID]; !visited {
+			connect(node.ID)
+		}
+	}
+
+	return cycles
+}
+
+func hasOutgoingEdge(workflow domain.Workflow, nodeID string, outputIndex int) bool {
+	for _, edge := range workflow.Edges {
+		if edge.SourceNodeID == nodeID && edge.SourceIndex == outputIndex && !edge.IsControlEdge() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasExecutionLimit(workflow domain.Workflow, nodeIDs []string) bool {
+	if workflow.Settings.NodeExecutionLimit > 0 {
+		return true
+	}
+
+	for _, nodeID := range nodeIDs {
+		node, ok := workflow.GetNodeByID(nodeID)
+		if ok && node.Settings.OverwriteExecutionLimit && node.Settings.ExecutionLimit > 0 {
+			return true
+		}
+	}
+
+	return false
+}
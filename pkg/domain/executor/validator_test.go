@@ -0,0 +1,204 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+type nopCreator struct{}
+
+func (nopCreator) CreateIntegration(ctx context.Context, p domain.CreateIntegrationParams) (domain.IntegrationExecutor, error) {
+	return nil, nil
+}
+
+const testIntegrationType domain.IntegrationType = "test_integration"
+
+var testSchema = domain.Integration{
+	ID: testIntegrationType,
+	Actions: []domain.IntegrationAction{
+		{
+			ActionType: "send",
+			Properties: []domain.NodeProperty{
+				{Key: "channel", Required: true},
+				{Key: "mode", Required: true, Default: "plain"},
+				{Key: "template", Required: true, ShowIf: &domain.ShowIf{PropertyKey: "mode", Values: []any{"template"}}},
+			},
+			HandlesByContext: map[domain.ActionUsageContext]domain.ContextHandles{
+				domain.UsageContextWorkflow: {
+					Input:  []domain.NodeHandle{{Index: 0}},
+					Output: []domain.NodeHandle{{Index: 0}},
+				},
+			},
+		},
+	},
+	Triggers: []domain.IntegrationTrigger{
+		{EventType: "received"},
+	},
+}
+
+func newTestValidator() *WorkflowValidator {
+	selector := domain.NewIntegrationSelector()
+	selector.RegisterCreator(testIntegrationType, nopCreator{})
+
+	return NewWorkflowValidator(WorkflowValidatorDependencies{
+		IntegrationSelector: selector,
+		Schemas:             []domain.Integration{testSchema},
+	})
+}
+
+func TestWorkflowValidator_Validate(t *testing.T) {
+	workflow := domain.Workflow{
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger, IntegrationType: testIntegrationType, TriggerNodeOpts: domain.TriggerNodeOpts{EventType: "received"}},
+			{ID: "send", Type: domain.NodeTypeAction, IntegrationType: testIntegrationType, ActionNodeOpts: domain.ActionNodeOpts{ActionType: "send"}, IntegrationSettings: map[string]any{"mode": "template"}},
+			{ID: "unknown-action", Type: domain.NodeTypeAction, IntegrationType: testIntegrationType, ActionNodeOpts: domain.ActionNodeOpts{ActionType: "delete"}},
+			{ID: "unknown-integration", Type: domain.NodeTypeAction, IntegrationType: "missing"},
+			{ID: "tool", Type: domain.NodeTypeAction, IntegrationType: "missing", UsageContext: string(domain.UsageContextTool)},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "trigger", TargetNodeID: "send"},
+			{SourceNodeID: "trigger", TargetNodeID: "send", TargetIndex: 2},
+			{SourceNodeID: "send", TargetNodeID: "ghost"},
+			{SourceNodeID: "send", TargetNodeID: "unknown-action"},
+			{SourceNodeID: "send", SourceIndex: 3, TargetNodeID: "unknown-action"},
+			{SourceNodeID: "unknown-action", TargetNodeID: "send"},
+		},
+	}
+
+	problems := newTestValidator().Validate(context.Background(), workflow)
+
+	want := []struct {
+		nodeID   string
+		severity ProblemSeverity
+		message  string
+	}{
+		{"send", ProblemSeverityError, "required property channel has no value"},
+		{"send", ProblemSeverityError, "required property template has no value"},
+		{"send", ProblemSeverityError, "outgoing edge to node ghost"},
+		{"send", ProblemSeverityError, "leaves output 3"},
+		{"send", ProblemSeverityError, "targets input 2"},
+		{"send", ProblemSeverityWarning, "form a cycle without an execution limit"},
+		{"unknown-action", ProblemSeverityError, "action delete not found"},
+		{"unknown-integration", ProblemSeverityError, "integration not found"},
+	}
+
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+
+	for _, w := range want {
+		found := false
+
+		for _, problem := range problems {
+			if problem.NodeID == w.nodeID && problem.Severity == w.severity && strings.Contains(problem.Message, w.message) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("missing %s problem on node %s containing %q in %v", w.severity, w.nodeID, w.message, problems)
+		}
+	}
+
+	err := newTestValidator().Check(context.Background(), workflow, false)
+
+	var validationErr *WorkflowValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, ErrInvalidWorkflow) {
+		t.Fatalf("Check() error = %v, want a WorkflowValidationError", err)
+	}
+
+	if len(validationErr.Problems) != 7 {
+		t.Errorf("Check() reported %d problems, want the 7 errors", len(validationErr.Problems))
+	}
+
+	// Testing a workflow only warns about missing required properties.
+	err = newTestValidator().Check(context.Background(), workflow, true)
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Check() error = %v, want a WorkflowValidationError", err)
+	}
+
+	if len(validationErr.Problems) != 5 {
+		t.Errorf("Check() reported %d problems when testing, want the 5 errors", len(validationErr.Problems))
+	}
+}
+
+func TestWorkflowValidator_CycleWithExecutionLimit(t *testing.T) {
+	workflow := domain.Workflow{
+		Nodes: []domain.WorkflowNode{
+			{ID: "a", Type: domain.NodeTypeAction, IntegrationType: testIntegrationType, ActionNodeOpts: domain.ActionNodeOpts{ActionType: "send"}, IntegrationSettings: map[string]any{"channel": "general"}},
+			{ID: "b", Type: domain.NodeTypeAction, IntegrationType: testIntegrationType, ActionNodeOpts: domain.ActionNodeOpts{ActionType: "send"}, IntegrationSettings: map[string]any{"channel": "general"},
+				Settings: domain.NodeSettings{OverwriteExecutionLimit: true, ExecutionLimit: 5}},
+		},
+		Edges: []domain.WorkflowEdge{
+			{SourceNodeID: "a", TargetNodeID: "b"},
+			{SourceNodeID: "b", TargetNodeID: "a"},
+		},
+	}
+
+	if problems := newTestValidator().Validate(context.Background(), workflow); len(problems) != 0 {
+		t.Errorf("Validate() = %v, want no problems", problems)
+	}
+}
+
+func TestWorkflowValidator_ItemErrorOutput(t *testing.T) {
+	sendNode := func(id string, continueOnItemError bool) domain.WorkflowNode {
+		return domain.WorkflowNode{
+			ID:                  id,
+			Type:                domain.NodeTypeAction,
+			IntegrationType:     testIntegrationType,
+			ActionNodeOpts:      domain.ActionNodeOpts{ActionType: "send"},
+			IntegrationSettings: map[string]any{"channel": "general"},
+			Settings:            domain.NodeSettings{ContinueOnItemError: continueOnItemError},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		nodes        []domain.WorkflowNode
+		edges        []domain.WorkflowEdge
+		wantSeverity ProblemSeverity
+		wantMessage  string
+	}{
+		{
+			name:  "connected failed items output",
+			nodes: []domain.WorkflowNode{sendNode("send", true), sendNode("report", false)},
+			edges: []domain.WorkflowEdge{{SourceNodeID: "send", SourceIndex: domain.ItemErrorOutputIndex, TargetNodeID: "report"}},
+		},
+		{
+			name:         "failed items output of a node that does not continue on item errors",
+			nodes:        []domain.WorkflowNode{sendNode("send", false), sendNode("report", false)},
+			edges:        []domain.WorkflowEdge{{SourceNodeID: "send", SourceIndex: domain.ItemErrorOutputIndex, TargetNodeID: "report"}},
+			wantSeverity: ProblemSeverityError,
+			wantMessage:  "does not continue on item errors",
+		},
+		{
+			name:         "unconnected failed items output",
+			nodes:        []domain.WorkflowNode{sendNode("send", true)},
+			wantSeverity: ProblemSeverityWarning,
+			wantMessage:  "failed items are dropped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := newTestValidator().Validate(context.Background(), domain.Workflow{Nodes: tt.nodes, Edges: tt.edges})
+
+			if tt.wantMessage == "" {
+				if len(problems) != 0 {
+					t.Errorf("Validate() = %v, want no problems", problems)
+				}
+
+				return
+			}
+
+			if len(problems) != 1 || problems[0].NodeID != "send" || problems[0].Severity != tt.wantSeverity || !strings.Contains(problems[0].Message, tt.wantMessage) {
+				t.Errorf("Validate() = %v, want one %s on send containing %q", problems, tt.wantSeverity, tt.wantMessage)
+			}
+		})
+	}
+}
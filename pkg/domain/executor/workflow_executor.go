@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
@@ -16,12 +18,14 @@ import (
 	"github.com/flowbaker/flowbaker/pkg/domain"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type NodeExecutionResult struct {
 	Output                domain.IntegrationOutput
 	IntegrationType       domain.IntegrationType
 	IntegrationActionType domain.IntegrationActionType
+	IsCached              bool // The output was reused from an earlier run of the node
 }
 
 type WorkflowExecutorI interface {
@@ -34,10 +38,15 @@ type WorkflowExecutor struct {
 
 	waitingExecutionTasks []WaitingExecutionTask
 	executionQueue        []NodeExecutionTask
+	runningTasks          map[int64]NodeExecutionTask // Tasks of concurrent runs in progress, by execution order
 	executedNodes         map[string]struct{}
+	nodeOutputs           map[string]domain.NodeItemsMap // Last output of each executed node, see ReadNodeOutput
 
 	edgeIndex              domain.EdgeIndex
 	executionCountByNodeID map[string]int
+	executionOrder         int64
+
+	containerScopes []*ContainerScope
 
 	mutex sync.Mutex
 
@@ -61,6 +70,24 @@ type WorkflowExecutor struct {
 
 	pauseResult           *pauseResult
 	executorStateSnapshot *domain.ExecutorStateSnapshot
+
+	checkpointStore CheckpointStore
+	checkpoint      *ExecutionCheckpoint
+
+	historyStore ExecutionHistoryStore
+
+	callDepth int
+
+	outputCache     NodeOutputCache
+	parameterBinder domain.IntegrationParameterBinder
+
+	payloadStore        PayloadStore
+	spillThresholdBytes int64
+
+	dryRun        bool
+	schemasByType map[domain.IntegrationType]domain.Integration
+
+	handOffRequested atomic.Bool
 }
 
 type pauseResult struct {
@@ -81,8 +108,8 @@ func (w *WorkflowExecutor) buildExecutorStateSnapshot(ctx context.Context, trigg
 		})
 	}
 
-	queuedTasks := make([]domain.QueuedTaskSnapshot, 0, len(w.executionQueue))
-	for _, t := range w.executionQueue {
+	queuedTasks := make([]domain.QueuedTaskSnapshot, 0, len(w.executionQueue)+len(w.runningTasks))
+	for _, t := range append(slices.Clone(w.executionQueue), w.runningTasksInOrder()...) {
 		queuedTasks = append(queuedTasks, domain.QueuedTaskSnapshot{
 			NodeID:            t.NodeID,
 			ItemsByInputIndex: t.ItemsByInputIndex,
@@ -104,16 +131,23 @@ func (w *WorkflowExecutor) buildExecutorStateSnapshot(ctx context.Context, trigg
 		lastEventOrder = orderCtx.GetCurrentOrder()
 	}
 
-	return &domain.ExecutorStateSnapshot{
-		PauseNodeID:            w.pauseResult.NodeID,
+	snapshot := &domain.ExecutorStateSnapshot{
 		TriggerNodeID:          triggerNodeID,
-		PauseNodeOutput:        w.pauseResult.NodeOutput,
 		WaitingTasks:           waitingTasks,
 		QueuedTasks:            queuedTasks,
 		ExecutedNodes:          executedNodes,
 		ExecutionCountByNodeID: executionCount,
 		LastEventOrder:         lastEventOrder,
+		DryRun:                 w.dryRun,
+		NodeOutputs:            maps.Clone(w.nodeOutputs),
 	}
+
+	if w.pauseResult != nil {
+		snapshot.PauseNodeID = w.pauseResult.NodeID
+		snapshot.PauseNodeOutput = w.pauseResult.NodeOutput
+	}
+
+	return snapshot
 }
 
 type WorkflowExecutorDeps struct {
@@ -127,6 +161,17 @@ type WorkflowExecutorDeps struct {
 	ExecutorClient        flowbaker.ClientInterface
 	OrderedEventPublisher domain.EventPublisher
 	ExecutorStateSnapshot *domain.ExecutorStateSnapshot
+	CheckpointStore       CheckpointStore       // Optional, state is not checkpointed when nil
+	Metrics               *ExecutionMetrics     // Optional, metrics are not recorded when nil
+	HistoryStore          ExecutionHistoryStore // Optional, executions are not kept locally when nil
+	CallDepth             int
+	OutputCache           NodeOutputCache                               // Optional, node outputs are not cached when nil
+	ParameterBinder       domain.IntegrationParameterBinder             // Optional, cache keys hash the unbound parameters when nil
+	PayloadStore          PayloadStore                                  // Optional, node outputs are kept in memory when nil
+	SpillThresholdBytes   int64                                         // DefaultSpillThresholdBytes when zero
+	DryRun                bool                                          // Also restored from the snapshot, so that a resumed dry run stays dry
+	Schemas               map[domain.IntegrationType]domain.Integration // Optional, used to classify the actions of dry runs
+	EventSinks            []domain.EventSink                            // Optional, events are only sent to the platform when empty
 }
 
 func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
@@ -159,6 +204,22 @@ func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
 
 	observer.Subscribe(historyRecorder)
 	observer.Subscribe(usageCollector)
+
+	// Metrics are recorded before the events are published, a failed publish stops the
+	// remaining handlers.
+	if deps.Metrics != nil {
+		observer.Subscribe(NewMetricsRecorder(deps.Metrics, deps.Workflow, usageCollector))
+	}
+
+	// Sinks are notified before the platform for the same reason, they receive the
+	// events even when publishing to the platform fails.
+	if len(deps.EventSinks) > 0 {
+		sinkBroadcaster := NewEventSinkBroadcaster(deps.EventSinks, deps.Workflow.WorkspaceID, deps.Workflow.ID, deps.ExecutionID)
+
+		observer.Subscribe(sinkBroadcaster)
+		observer.SubscribeStream(sinkBroadcaster)
+	}
+
 	observer.Subscribe(eventBroadcaster)
 	observer.Subscribe(incrementalPersister)
 	observer.SubscribeStream(streamBroadcaster)
@@ -167,6 +228,7 @@ func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
 	queuedTasks := []NodeExecutionTask{}
 	executedNodes := map[string]struct{}{}
 	executionCountByNodeID := map[string]int{}
+	nodeOutputs := map[string]domain.NodeItemsMap{}
 
 	if deps.ExecutorStateSnapshot != nil {
 		for _, snap := range deps.ExecutorStateSnapshot.WaitingTasks {
@@ -184,6 +246,7 @@ func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
 		for nodeID, count := range deps.ExecutorStateSnapshot.ExecutionCountByNodeID {
 			executionCountByNodeID[nodeID] = count
 		}
+		maps.Copy(nodeOutputs, deps.ExecutorStateSnapshot.NodeOutputs)
 	}
 
 	return WorkflowExecutor{
@@ -193,6 +256,7 @@ func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
 		waitingExecutionTasks:      waitingTasks,
 		executionQueue:             queuedTasks,
 		executedNodes:              executedNodes,
+		nodeOutputs:                nodeOutputs,
 		edgeIndex:                  edgeIndex,
 		integrationSelector:        deps.Selector,
 		executionCountByNodeID:     executionCountByNodeID,
@@ -206,6 +270,15 @@ func NewWorkflowExecutor(deps WorkflowExecutorDeps) (WorkflowExecutor, error) {
 		usageCollector:             usageCollector,
 		streamEventPublisher:       streamEventPublisher,
 		executorStateSnapshot:      deps.ExecutorStateSnapshot,
+		checkpointStore:            deps.CheckpointStore,
+		historyStore:               deps.HistoryStore,
+		callDepth:                  deps.CallDepth,
+		outputCache:                deps.OutputCache,
+		parameterBinder:            deps.ParameterBinder,
+		payloadStore:               deps.PayloadStore,
+		spillThresholdBytes:        deps.SpillThresholdBytes,
+		dryRun:                     deps.DryRun || (deps.ExecutorStateSnapshot != nil && deps.ExecutorStateSnapshot.DryRun),
+		schemasByType:              deps.Schemas,
 	}, nil
 }
 
@@ -213,7 +286,25 @@ const (
 	DefaultNodeExecutionLimit = 1000
 )
 
+// Execute runs the workflow from the trigger node, or from the snapshot it was created
+// with, inside a span that is the parent of all node spans of the execution.
 func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []domain.Item) (ExecutionResult, error) {
+	ctx, span := tracer.Start(ctx, "workflow.execute", trace.WithAttributes(
+		attributeWorkspaceID.String(w.workflow.WorkspaceID),
+		attributeWorkflowID.String(w.workflow.ID),
+		attributeExecutionID.String(w.executionID),
+		attributeTriggerNodeID.String(nodeID),
+		attributeIsResume.Bool(w.executorStateSnapshot != nil),
+		attributeIsTesting.Bool(w.IsTestingWorkflow),
+	))
+
+	result, err := w.execute(ctx, nodeID, items)
+	endSpan(span, err)
+
+	return result, err
+}
+
+func (w *WorkflowExecutor) execute(ctx context.Context, nodeID string, items []domain.Item) (ExecutionResult, error) {
 	workspaceID := w.workflow.WorkspaceID
 	defer w.streamEventPublisher.Close()
 
@@ -229,6 +320,8 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		return ExecutionResult{}, fmt.Errorf("failed to marshal items: %w", err)
 	}
 
+	w.checkpoint = w.newCheckpoint(nodeID, inputPayload)
+
 	startOrder := 0
 	if w.executorStateSnapshot != nil {
 		startOrder = w.executorStateSnapshot.LastEventOrder
@@ -245,8 +338,16 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		IsFromErrorTrigger:  isErrorTrigger,
 		IsTesting:           w.IsTestingWorkflow,
 		TriggerNode:         triggerNode,
+		CallDepth:           w.callDepth,
+		WorkflowName:        w.workflow.Name,
+		StartedAt:           w.WorkflowExecutionStartedAt,
+		NodeOutputs:         w,
+		StrictTemplates:     w.workflow.Settings.StrictTemplates,
 	})
 
+	ctx, cancelWorkflow := w.NewWorkflowContext(ctx)
+	defer cancelWorkflow()
+
 	isResume := w.executorStateSnapshot != nil
 
 	if !isResume {
@@ -259,15 +360,13 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 
 	log.Info().Bool("resume", isResume).Msgf("Executing workflow triggered by node %s", nodeID)
 
-	executionCount := 0
-
-	if isResume {
+	if isResume && w.executorStateSnapshot.PauseNodeID != "" {
 		pauseNode, exists := w.workflow.GetNodeByID(w.executorStateSnapshot.PauseNodeID)
 		if !exists {
 			return ExecutionResult{}, fmt.Errorf("resume: paused node %s not found in workflow", w.executorStateSnapshot.PauseNodeID)
 		}
 
-		executionCount++
+		executionOrder := w.NextExecutionOrder()
 
 		resumedOutput := domain.IntegrationOutput{
 			ItemsByOutputIndex: w.executorStateSnapshot.PauseNodeOutput,
@@ -284,7 +383,7 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 			NodeID:                w.executorStateSnapshot.PauseNodeID,
 			ItemsByInputIndex:     w.executorStateSnapshot.PauseNodeOutput,
 			ItemsByOutputIndex:    resumedOutput.ItemsByOutputIndex,
-			ExecutionOrder:        int64(executionCount),
+			ExecutionOrder:        executionOrder,
 			IntegrationType:       pauseNode.IntegrationType,
 			IntegrationActionType: pauseNode.ActionNodeOpts.ActionType,
 			StartedAt:             now,
@@ -292,59 +391,50 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		}); err != nil {
 			log.Error().Err(err).Msg("Failed to notify paused node completion on resume")
 		}
-	} else {
+	} else if !isResume {
+		// A snapshot without a paused node comes from a local checkpoint, its queue was
+		// restored by NewWorkflowExecutor and only has to be run again.
 		w.AddExecutionTask(NodeExecutionTask{
 			NodeID:            nodeID,
 			ItemsByInputIndex: domain.NewNodeItemsMap(0, nodeID, items),
 		})
 	}
 
-	for len(w.executionQueue) > 0 {
-		if ctx.Err() != nil {
-			return ExecutionResult{}, ctx.Err()
+	w.SaveCheckpoint(ctx)
+
+	status := ExecutionStatusSucceeded
+
+	if err := w.RunExecutionQueue(ctx); err != nil {
+		var nodeErr NodeExecutionError
+
+		switch {
+		case errors.Is(context.Cause(ctx), ErrExecutionStopped):
+			log.Info().Str("workflow_id", w.workflow.ID).Msg("Workflow execution stopped")
+
+			// Like timed out executions, stopped ones are completed with what ran so far
+			// and their checkpoint is deleted so that they are not resumed.
+			ctx = context.WithoutCancel(ctx)
+			status = ExecutionStatusStopped
+		case IsWorkflowTimeoutError(err):
+			log.Warn().Err(err).Str("workflow_id", w.workflow.ID).Msg("Workflow execution timed out")
+
+			// The execution is completed with what ran so far, which needs a context
+			// that is not past the deadline.
+			ctx = context.WithoutCancel(ctx)
+			status = ExecutionStatusFailed
+		case errors.As(err, &nodeErr) && !w.IsInterrupted(ctx):
+			status = ExecutionStatusFailed
+		case errors.Is(err, ErrExecutionHandedOff):
+			return w.HandOff(ctx, nodeID)
+		default:
+			return ExecutionResult{}, err
 		}
-
-		execution := w.executionQueue[0]
-		w.executionQueue = w.executionQueue[1:]
-
-		executionCount++
-
-		if w.IsExecutionLimitReached(execution.NodeID) {
-			break
-		}
-
-		_, err := w.ExecuteNode(ctx, ExecuteNodeParams{
-			Task:           execution,
-			ExecutionOrder: int64(executionCount),
-			Propagate:      true,
-		})
-		if err != nil {
-			log.Error().Err(err).Msg("Error executing node")
-
-			errNotify := w.observer.Notify(ctx, NodeExecutionFailedEvent{
-				NodeID:            execution.NodeID,
-				ItemsByInputIndex: execution.ItemsByInputIndex,
-				Error:             err,
-				Timestamp:         time.Now(),
-			})
-			if errNotify != nil {
-				log.Error().Err(errNotify).Str("workflow_id", w.workflow.ID).Msg("executor: failed to notify node failed event")
-			}
-
-			break
-		}
-
-		if w.pauseResult != nil {
-			break
-		}
-
-		w.FlushWaitingTasks()
 	}
 
 	executionResults := w.historyRecorder.GetHistoryEntries()
 
 	nodeExecutions := mappers.DomainNodeExecutionsToFlowbaker(w.usageCollector.GetNodeExecutions())
-	historyEntries := mappers.DomainNodeExecutionEntriesToFlowbaker(executionResults)
+	historyEntries := w.PortableEntries(ctx, executionResults)
 
 	if w.pauseResult != nil {
 		originalTriggerID := nodeID
@@ -360,7 +450,7 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 			Timestamp:          time.Now().UnixNano(),
 		}
 		executionResults = append(executionResults, pauseEntry)
-		historyEntries = mappers.DomainNodeExecutionEntriesToFlowbaker(executionResults)
+		historyEntries = w.PortableEntries(ctx, executionResults)
 
 		if err := w.observer.Notify(ctx, WorkflowExecutionPausedEvent{
 			PauseNodeID: w.pauseResult.NodeID,
@@ -371,6 +461,10 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		}
 
 		snapshot := w.buildExecutorStateSnapshot(ctx, originalTriggerID)
+		if err := w.PortableSnapshot(ctx, snapshot); err != nil {
+			return ExecutionResult{}, fmt.Errorf("failed to read spilled items of resume state: %w", err)
+		}
+
 		snapshotJSON, err := json.Marshal(snapshot)
 		if err != nil {
 			return ExecutionResult{}, fmt.Errorf("failed to marshal resume state: %w", err)
@@ -392,6 +486,7 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 			NodeExecutions:    nodeExecutions,
 			HistoryEntries:    historyEntries,
 			IsTestingWorkflow: w.IsTestingWorkflow,
+			DryRun:            w.dryRun,
 			ResumeStateJSON:   snapshotJSON,
 		}
 
@@ -399,6 +494,15 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 			return ExecutionResult{}, fmt.Errorf("failed to send pause: %w", err)
 		}
 
+		w.DeleteCheckpoint(ctx)
+
+		w.SaveHistory(ctx, SaveHistoryParams{
+			TriggerNodeID:  originalTriggerID,
+			Status:         ExecutionStatusPaused,
+			NodeExecutions: w.usageCollector.GetNodeExecutions(),
+			HistoryEntries: executionResults,
+		})
+
 		log.Info().Str("pause_node_id", w.pauseResult.NodeID).Time("wake_at", w.pauseResult.WakeAt).Msg("Workflow paused")
 
 		executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
@@ -434,6 +538,15 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		log.Error().Err(err).Msg("Failed to send complete workflow execution request")
 	}
 
+	w.DeleteCheckpoint(ctx)
+
+	w.SaveHistory(ctx, SaveHistoryParams{
+		TriggerNodeID:  nodeID,
+		Status:         status,
+		NodeExecutions: w.usageCollector.GetNodeExecutions(),
+		HistoryEntries: executionResults,
+	})
+
 	if err := w.observer.Notify(ctx, WorkflowExecutionCompletedEvent{
 		Timestamp: time.Now(),
 	}); err != nil {
@@ -447,14 +560,144 @@ func (w *WorkflowExecutor) Execute(ctx context.Context, nodeID string, items []d
 		return ExecutionResult{}, errors.New("workflow execution context not found")
 	}
 
+	// Called workflows return what their last node produced when they do not send a
+	// response themselves, which is what the calling node outputs.
+	payload := executionContext.ResponsePayload
+	if payload == nil && w.callDepth > 0 {
+		payload = w.lastNodeOutputPayload(ctx, executionResults)
+	}
+
 	return ExecutionResult{
-		Payload:              executionContext.ResponsePayload,
+		Payload:              payload,
 		Headers:              executionContext.ResponseHeaders,
 		StatusCode:           executionContext.ResponseStatusCode,
 		NodeExecutionResults: executionResults,
 	}, nil
 }
 
+func (w *WorkflowExecutor) lastNodeOutputPayload(ctx context.Context, entries []domain.NodeExecutionEntry) []byte {
+	for _, entry := range slices.Backward(entries) {
+		if entry.EventType != domain.NodeExecuted {
+			continue
+		}
+
+		itemsByOutputIndex, err := w.ResolveItems(ctx, entry.ItemsByOutputIndex)
+		if err != nil {
+			log.Error().Err(err).Str("node_id", entry.NodeID).Msg("Failed to read last node output")
+			return nil
+		}
+
+		items := []domain.Item{}
+		for _, outputIndex := range slices.Sorted(maps.Keys(itemsByOutputIndex)) {
+			items = append(items, itemsByOutputIndex[outputIndex].Items...)
+		}
+
+		payload, err := json.Marshal(items)
+		if err != nil {
+			log.Error().Err(err).Str("node_id", entry.NodeID).Msg("Failed to marshal last node output")
+			return nil
+		}
+
+		return payload
+	}
+
+	return nil
+}
+
+// NodeExecutionError is returned by RunExecutionQueue when a node fails, the failure
+// has already been reported to the observer when it is returned.
+type NodeExecutionError struct {
+	NodeID string
+	Err    error
+}
+
+func (e NodeExecutionError) Error() string {
+	return fmt.Sprintf("node %s failed: %s", e.NodeID, e.Err)
+}
+
+func (e NodeExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// RunExecutionQueue executes queued tasks until the queue is drained, a node pauses the
+// workflow, a node reaches its execution limit, a node fails or the execution is handed
+// off.
+func (w *WorkflowExecutor) RunExecutionQueue(ctx context.Context) error {
+	if concurrency := w.GetConcurrency(); concurrency > 1 {
+		return w.RunExecutionQueueConcurrently(ctx, concurrency)
+	}
+
+	for len(w.executionQueue) > 0 {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		if w.IsHandOffRequested() {
+			return ErrExecutionHandedOff
+		}
+
+		execution := w.executionQueue[0]
+		w.executionQueue = w.executionQueue[1:]
+
+		executionOrder := w.NextExecutionOrder()
+
+		if w.IsExecutionLimitReached(execution.NodeID) {
+			break
+		}
+
+		_, err := w.ExecuteNode(ctx, ExecuteNodeParams{
+			Task:           execution,
+			ExecutionOrder: executionOrder,
+			Propagate:      true,
+		})
+		if err != nil {
+			return w.HandleNodeFailure(ctx, execution, err)
+		}
+
+		if w.pauseResult != nil {
+			break
+		}
+
+		w.FlushWaitingTasks()
+		w.SaveCheckpoint(ctx)
+	}
+
+	return nil
+}
+
+// HandleNodeFailure reports a failed node and returns the NodeExecutionError that stops
+// the execution queue.
+func (w *WorkflowExecutor) HandleNodeFailure(ctx context.Context, execution NodeExecutionTask, err error) error {
+	log.Error().Err(err).Msg("Error executing node")
+
+	// The execution context may already be past its deadline, the failure still
+	// has to be reported.
+	errNotify := w.observer.Notify(context.WithoutCancel(ctx), NodeExecutionFailedEvent{
+		NodeID:            execution.NodeID,
+		ItemsByInputIndex: execution.ItemsByInputIndex,
+		Error:             err,
+		IsTimeout:         IsTimeoutError(err),
+		Timestamp:         time.Now(),
+	})
+	if errNotify != nil {
+		log.Error().Err(errNotify).Str("workflow_id", w.workflow.ID).Msg("executor: failed to notify node failed event")
+	}
+
+	return NodeExecutionError{
+		NodeID: execution.NodeID,
+		Err:    err,
+	}
+}
+
+func (w *WorkflowExecutor) NextExecutionOrder() int64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.executionOrder++
+
+	return w.executionOrder
+}
+
 type ExecutionNodeType string
 
 const (
@@ -473,11 +716,37 @@ type ExecuteNodeResult struct {
 }
 
 func (w *WorkflowExecutor) ExecuteNode(ctx context.Context, p ExecuteNodeParams) (ExecuteNodeResult, error) {
-	task := p.Task
+	run, err := w.StartNode(ctx, p.Task)
+	if err != nil {
+		return ExecuteNodeResult{}, err
+	}
+
+	w.RunNode(ctx, &run)
 
+	return w.FinishNode(ctx, run, p)
+}
+
+// NodeRun carries a node execution through StartNode, RunNode and FinishNode. Only
+// RunNode may be called concurrently for different runs.
+type NodeRun struct {
+	Node      domain.WorkflowNode
+	Task      NodeExecutionTask
+	StartedAt time.Time
+	EndedAt   time.Time
+	Span      trace.Span
+
+	Result NodeExecutionResult
+	Err    error
+}
+
+func (w *WorkflowExecutor) StartNode(ctx context.Context, task NodeExecutionTask) (NodeRun, error) {
 	node, exists := w.workflow.GetNodeByID(task.NodeID)
 	if !exists {
-		return ExecuteNodeResult{}, fmt.Errorf("node %s not found in workflow", task.NodeID)
+		return NodeRun{}, fmt.Errorf("node %s not found in workflow", task.NodeID)
+	}
+
+	if !slices.Contains([]domain.NodeType{domain.NodeTypeAction, domain.NodeTypeTrigger, domain.NodeTypeContainer}, node.Type) {
+		return NodeRun{}, fmt.Errorf("node type is invalid: %s", node.Type)
 	}
 
 	w.mutex.Lock()
@@ -487,6 +756,16 @@ func (w *WorkflowExecutor) ExecuteNode(ctx context.Context, p ExecuteNodeParams)
 
 	nodeExecutionStartedAt := time.Now()
 
+	// The span ends in FinishNode, it is not put in ctx here because the run executes
+	// with the context RunNode is called with.
+	_, span := tracer.Start(ctx, "node.execute", trace.WithTimestamp(nodeExecutionStartedAt), trace.WithAttributes(
+		attributeNodeID.String(node.ID),
+		attributeNodeType.String(string(node.Type)),
+		attributeIntegrationType.String(string(node.IntegrationType)),
+		attributeActionType.String(string(node.ActionNodeOpts.ActionType)),
+		attributeInputItems.Int(countItems(task.ItemsByInputIndex)),
+	))
+
 	if err := w.observer.Notify(ctx, NodeExecutionStartedEvent{
 		NodeID:            task.NodeID,
 		ItemsByInputIndex: task.ItemsByInputIndex,
@@ -495,32 +774,104 @@ func (w *WorkflowExecutor) ExecuteNode(ctx context.Context, p ExecuteNodeParams)
 		log.Error().Err(err).Msg("Failed to notify node execution started")
 	}
 
+	return NodeRun{
+		Node:      node,
+		Task:      task,
+		StartedAt: nodeExecutionStartedAt,
+		Span:      span,
+	}, nil
+}
+
+// RunNode executes the node of a started run without touching the execution queue, so
+// that runs of action and trigger nodes can execute concurrently.
+func (w *WorkflowExecutor) RunNode(ctx context.Context, run *NodeRun) {
+	if run.Span != nil {
+		ctx = trace.ContextWithSpan(ctx, run.Span)
+	}
+
+	run.Result, run.Err = w.ExecuteNodeByType(ctx, run.Node, run.Task)
+	run.EndedAt = time.Now()
+}
+
+func (w *WorkflowExecutor) ExecuteNodeByType(ctx context.Context, node domain.WorkflowNode, task NodeExecutionTask) (NodeExecutionResult, error) {
 	var result NodeExecutionResult
 	var err error
 
+	nodeCtx, cancelNode := w.NewNodeContext(ctx, node)
+	defer cancelNode()
+
+	nodeCtx = domain.NewContextWithNodeOutputCache(nodeCtx)
+
+	// Spilled inputs are only loaded while the node runs, the task keeps the references
+	// for history and snapshots.
+	task.ItemsByInputIndex, err = w.ResolveItems(nodeCtx, task.ItemsByInputIndex)
+	if err != nil {
+		return NodeExecutionResult{}, err
+	}
+
 	switch node.Type {
 	case domain.NodeTypeAction:
-		result, err = w.ExecuteActionNode(ctx, node, task)
+		result, err = RunUntilDone(nodeCtx, func(ctx context.Context) (NodeExecutionResult, error) {
+			return w.ExecuteActionNode(ctx, node, task)
+		})
 	case domain.NodeTypeTrigger:
-		result, err = w.ExecuteTriggerNode(ctx, node, task)
+		result, err = w.ExecuteTriggerNode(nodeCtx, node, task)
+	case domain.NodeTypeContainer:
+		result, err = w.ExecuteContainerNode(nodeCtx, node, task)
+		if err != nil && nodeCtx.Err() != nil && !IsTimeoutError(err) {
+			err = fmt.Errorf("%w: %w", context.Cause(nodeCtx), err)
+		}
 	default:
-		return ExecuteNodeResult{}, fmt.Errorf("node type is invalid: %s", node.Type)
+		return NodeExecutionResult{}, fmt.Errorf("node type is invalid: %s", node.Type)
 	}
 
 	if err != nil {
-		result, err = w.HandleNodeExecutionError(HandleNodeExecutionErrorParams{
+		return w.HandleNodeExecutionError(HandleNodeExecutionErrorParams{
 			Err:  err,
 			Node: node,
 		})
-		if err != nil {
-			return ExecuteNodeResult{}, err
-		}
 	}
 
+	return result, nil
+}
+
+// FinishNode propagates the output of a run and ends its span.
+func (w *WorkflowExecutor) FinishNode(ctx context.Context, run NodeRun, p ExecuteNodeParams) (ExecuteNodeResult, error) {
+	result, err := w.finishNode(ctx, run, p)
+
+	if run.Span != nil {
+		run.Span.SetAttributes(attributeOutputItems.Int(countItems(result.ItemsByOutputIndex)))
+		endSpan(run.Span, err)
+	}
+
+	return result, err
+}
+
+func (w *WorkflowExecutor) finishNode(ctx context.Context, run NodeRun, p ExecuteNodeParams) (ExecuteNodeResult, error) {
+	node := run.Node
+	task := run.Task
+	result := run.Result
+
+	if run.Err != nil {
+		return ExecuteNodeResult{}, run.Err
+	}
+
+	result.Output.ItemsByOutputIndex = w.SpillOutput(ctx, node.ID, result.Output.ItemsByOutputIndex)
+	w.setNodeOutput(node.ID, result.Output.ItemsByOutputIndex)
+
 	if execCtx, ok := domain.GetWorkflowExecutionContext(ctx); ok {
-		for _, sig := range execCtx.DrainSignals() {
+		for _, sig := range execCtx.DrainNodeSignals(node.ID) {
 			switch s := sig.(type) {
 			case domain.PauseSignal:
+				if w.pauseResult != nil {
+					log.Warn().
+						Str("node_id", node.ID).
+						Str("pause_node_id", w.pauseResult.NodeID).
+						Msg("Execution is already paused by another node, completing node without pausing")
+
+					continue
+				}
+
 				w.pauseResult = &pauseResult{
 					NodeID:     node.ID,
 					WakeAt:     s.WakeAt,
@@ -531,8 +882,6 @@ func (w *WorkflowExecutor) ExecuteNode(ctx context.Context, p ExecuteNodeParams)
 		}
 	}
 
-	nodeExecutionEndedAt := time.Now()
-
 	if p.Propagate {
 		err := w.Propagate(ctx, node.ID, result.Output)
 		if err != nil {
@@ -542,15 +891,16 @@ func (w *WorkflowExecutor) ExecuteNode(ctx context.Context, p ExecuteNodeParams)
 
 	w.MarkNodeAsExecuted(node.ID)
 
-	err = w.observer.Notify(ctx, NodeExecutionCompletedEvent{
+	err := w.observer.Notify(ctx, NodeExecutionCompletedEvent{
 		NodeID:                node.ID,
 		ItemsByInputIndex:     task.ItemsByInputIndex,
 		ItemsByOutputIndex:    result.Output.ItemsByOutputIndex,
 		ExecutionOrder:        p.ExecutionOrder,
 		IntegrationType:       result.IntegrationType,
 		IntegrationActionType: result.IntegrationActionType,
-		StartedAt:             nodeExecutionStartedAt,
-		EndedAt:               nodeExecutionEndedAt,
+		StartedAt:             run.StartedAt,
+		EndedAt:               run.EndedAt,
+		IsCached:              result.IsCached,
 	})
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to notify node execution completed")
@@ -587,11 +937,42 @@ func (w *WorkflowExecutor) ExecuteActionNode(ctx context.Context, node domain.Wo
 		}, nil
 	}
 
+	if w.dryRun && w.ActionSideEffect(node) == domain.ActionSideEffectWrite {
+		output, err := w.StubAction(ctx, node, execution)
+		if err != nil {
+			return NodeExecutionResult{}, err
+		}
+
+		return NodeExecutionResult{
+			Output:                output,
+			IntegrationType:       domain.IntegrationType(node.IntegrationType),
+			IntegrationActionType: node.ActionNodeOpts.ActionType,
+		}, nil
+	}
+
+	output, isCached, err := w.ExecuteCached(ctx, node, execution, func(ctx context.Context) (domain.IntegrationOutput, error) {
+		return w.RunIntegration(ctx, node, execution)
+	})
+	if err != nil {
+		return NodeExecutionResult{}, err
+	}
+
+	return NodeExecutionResult{
+		Output:                output,
+		IntegrationType:       domain.IntegrationType(node.IntegrationType),
+		IntegrationActionType: node.ActionNodeOpts.ActionType,
+		IsCached:              isCached,
+	}, nil
+}
+
+// RunIntegration creates the integration of an action node and executes its action with
+// the retry policy of the node.
+func (w *WorkflowExecutor) RunIntegration(ctx context.Context, node domain.WorkflowNode, execution NodeExecutionTask) (domain.IntegrationOutput, error) {
 	integrationCreator, err := w.integrationSelector.SelectCreator(ctx, domain.SelectIntegrationParams{
 		IntegrationType: node.IntegrationType,
 	})
 	if err != nil {
-		return NodeExecutionResult{}, err
+		return domain.IntegrationOutput{}, err
 	}
 
 	credentialID, exists := node.IntegrationSettings["credential_id"]
@@ -601,7 +982,7 @@ func (w *WorkflowExecutor) ExecuteActionNode(ctx context.Context, node domain.Wo
 
 	credentialIDString, ok := credentialID.(string)
 	if !ok {
-		return NodeExecutionResult{}, fmt.Errorf("credential_id is not a string")
+		return domain.IntegrationOutput{}, fmt.Errorf("credential_id is not a string")
 	}
 
 	integrationExecutor, err := integrationCreator.CreateIntegration(ctx, domain.CreateIntegrationParams{
@@ -609,36 +990,46 @@ func (w *WorkflowExecutor) ExecuteActionNode(ctx context.Context, node domain.Wo
 		CredentialID: credentialIDString,
 	})
 	if err != nil {
-		return NodeExecutionResult{}, err
+		return domain.IntegrationOutput{}, err
 	}
 
-	output, err := integrationExecutor.Execute(ctx, domain.IntegrationInput{
-		NodeID:            node.ID,
-		ItemsByInputIndex: execution.ItemsByInputIndex,
-		Workflow:          &w.workflow,
-		IntegrationParams: domain.IntegrationParams{
-			Settings: node.IntegrationSettings,
-		},
-		ActionType: node.ActionNodeOpts.ActionType,
+	return w.ExecuteWithRetry(ctx, node, execution, func(ctx context.Context) (domain.IntegrationOutput, error) {
+		return integrationExecutor.Execute(ctx, domain.IntegrationInput{
+			NodeID:            node.ID,
+			ItemsByInputIndex: execution.ItemsByInputIndex,
+			Workflow:          &w.workflow,
+			IntegrationParams: domain.IntegrationParams{
+				Settings: node.IntegrationSettings,
+			},
+			ActionType: node.ActionNodeOpts.ActionType,
+			PerItemOptions: domain.PerItemOptions{
+				Concurrency:     node.Settings.ItemConcurrency,
+				RateLimit:       node.Settings.ItemRateLimit,
+				ContinueOnError: node.Settings.ContinueOnItemError,
+				RetryItem:       w.ItemRetryFunc(node),
+			},
+		})
 	})
-	if err != nil {
-
-		return NodeExecutionResult{}, err
-	}
-
-	return NodeExecutionResult{
-		Output:                output,
-		IntegrationType:       domain.IntegrationType(node.IntegrationType),
-		IntegrationActionType: node.ActionNodeOpts.ActionType,
-	}, nil
 }
 
 func (w *WorkflowExecutor) Propagate(ctx context.Context, nodeID string, output domain.IntegrationOutput) error {
 	for outputIndex, nodeItems := range output.ItemsByOutputIndex {
-		if len(nodeItems.Items) == 0 {
+		if nodeItems.Count() == 0 {
 			continue
 		}
 
+		controlEdges := w.edgeIndex.GetControlEdges(nodeID, outputIndex)
+		if len(controlEdges) > 0 {
+			controlItems, err := w.ResolveItems(ctx, domain.NodeItemsMap{outputIndex: nodeItems})
+			if err != nil {
+				return err
+			}
+
+			for _, edge := range controlEdges {
+				w.CollectControlItems(edge, controlItems[outputIndex].Items)
+			}
+		}
+
 		nodes := w.edgeIndex.GetTargetNodes(nodeID, outputIndex)
 
 		for _, node := range nodes {
@@ -646,6 +1037,7 @@ func (w *WorkflowExecutor) Propagate(ctx context.Context, nodeID string, output
 				FromNodeID:  nodeID,
 				Node:        node,
 				Items:       nodeItems.Items,
+				Ref:         nodeItems.Ref,
 				OutputIndex: outputIndex,
 			})
 			if err != nil {
@@ -662,6 +1054,7 @@ type AddTaskForDownstreamNodeParams struct {
 	Node        domain.WorkflowNode
 	OutputIndex int
 	Items       []domain.Item
+	Ref         *domain.ItemsRef // Set instead of Items when the output was spilled
 }
 
 func (w *WorkflowExecutor) AddTaskForDownstreamNode(ctx context.Context, p AddTaskForDownstreamNodeParams) error {
@@ -682,14 +1075,17 @@ func (w *WorkflowExecutor) AddTaskForDownstreamNode(ctx context.Context, p AddTa
 			Node:       node,
 			InputIndex: matchingInputIndex,
 			Items:      items,
+			Ref:        p.Ref,
 		})
 
 		return nil
 	}
 
+	nodeItems := domain.NodeItems{FromNodeID: p.FromNodeID, Items: items, Ref: p.Ref}
+
 	w.AddExecutionTask(NodeExecutionTask{
 		NodeID:            node.ID,
-		ItemsByInputIndex: domain.NewNodeItemsMap(matchingInputIndex, p.FromNodeID, items),
+		ItemsByInputIndex: domain.NodeItemsMap{matchingInputIndex: nodeItems},
 	})
 
 	return nil
@@ -714,6 +1110,7 @@ type HandleWaitingTaskParams struct {
 	Node       domain.WorkflowNode
 	InputIndex int
 	Items      []domain.Item
+	Ref        *domain.ItemsRef // Set instead of Items when the output was spilled
 }
 
 func (w *WorkflowExecutor) HandleWaitingTask(p HandleWaitingTaskParams) {
@@ -722,7 +1119,9 @@ func (w *WorkflowExecutor) HandleWaitingTask(p HandleWaitingTaskParams) {
 	if !exists {
 		newTask := NewWaitingExecutionTask(
 			p.Node.ID,
-			domain.NewNodeItemsMap(p.InputIndex, p.FromNodeID, p.Items),
+			domain.NodeItemsMap{
+				p.InputIndex: {FromNodeID: p.FromNodeID, Items: p.Items, Ref: p.Ref},
+			},
 		)
 
 		w.AddWaitingExecutionTask(newTask)
@@ -730,7 +1129,7 @@ func (w *WorkflowExecutor) HandleWaitingTask(p HandleWaitingTaskParams) {
 		return
 	}
 
-	waitingTask.AddItems(p.FromNodeID, p.InputIndex, p.Items)
+	waitingTask.AddItems(p.InputIndex, domain.NodeItems{FromNodeID: p.FromNodeID, Items: p.Items, Ref: p.Ref})
 
 	if w.ShouldResolveWaitingTask(waitingTask, p.Node) {
 		w.ResolveWaitingTask(p.Node.ID, waitingTask)
@@ -856,6 +1255,11 @@ func (w *WorkflowExecutor) HandleNodeExecutionError(p HandleNodeExecutionErrorPa
 		return NodeExecutionResult{}, p.Err
 	}
 
+	// There is no time left to run the nodes that would receive the error item.
+	if IsWorkflowTimeoutError(p.Err) {
+		return NodeExecutionResult{}, p.Err
+	}
+
 	integrationType := domain.IntegrationType(p.Node.Type)
 
 	actionType := ""
@@ -865,6 +1269,8 @@ func (w *WorkflowExecutor) HandleNodeExecutionError(p HandleNodeExecutionErrorPa
 		actionType = string(p.Node.ActionNodeOpts.ActionType)
 	case domain.NodeTypeTrigger:
 		actionType = string(p.Node.TriggerNodeOpts.EventType)
+	case domain.NodeTypeContainer:
+		actionType = string(p.Node.ContainerNodeOpts.ContainerType)
 	}
 
 	return NodeExecutionResult{
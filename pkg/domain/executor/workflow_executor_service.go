@@ -19,6 +19,10 @@ type ExecutionResult struct {
 	StatusCode int
 
 	NodeExecutionResults []domain.NodeExecutionEntry
+
+	// Queued is set when the execution waits for a free slot, it runs in the
+	// background once admitted and the other fields are empty.
+	Queued bool
 }
 
 type WorkflowExecutorService interface {
@@ -29,6 +33,8 @@ type WorkflowExecutorService interface {
 	PeekData(ctx context.Context, params PeekDataParams) (domain.PeekResult, error)
 	RerunNode(ctx context.Context, params RerunNodeParams) (ExecutionResult, error)
 	RunNode(ctx context.Context, params RunNodeParams) (RunNodeResult, error)
+	AdmissionStatus() AdmissionStatus
+	Drain(ctx context.Context, gracePeriod time.Duration) error
 }
 
 type ActiveExecution struct {
@@ -36,6 +42,7 @@ type ActiveExecution struct {
 	WorkflowID  string
 	WorkspaceID string
 	CancelFunc  context.CancelFunc
+	HandOffFunc func()
 }
 
 type StopExecutionParams struct {
@@ -68,6 +75,53 @@ func (r *ExecutionRegistry) UnregisterExecution(executionID string) {
 	delete(r.executions, executionID)
 }
 
+func (r *ExecutionRegistry) Count() int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return len(r.executions)
+}
+
+func (r *ExecutionRegistry) CountByWorkspace(workspaceID string) int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	count := 0
+	for _, execution := range r.executions {
+		if execution.WorkspaceID == workspaceID {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *ExecutionRegistry) CountByWorkflow(workflowID string) int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	count := 0
+	for _, execution := range r.executions {
+		if execution.WorkflowID == workflowID {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *ExecutionRegistry) List() []ActiveExecution {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	executions := make([]ActiveExecution, 0, len(r.executions))
+	for _, execution := range r.executions {
+		executions = append(executions, execution)
+	}
+
+	return executions
+}
+
 func (r *ExecutionRegistry) GetExecution(executionID string) (ActiveExecution, bool) {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
@@ -81,8 +135,18 @@ type workflowExecutorService struct {
 	flowbakerClient       flowbaker.ClientInterface
 	orderedEventPublisher domain.EventPublisher
 	credentialManager     domain.ExecutorCredentialManager
+	checkpointStore       CheckpointStore
+	metrics               *ExecutionMetrics
+	historyStore          ExecutionHistoryStore
+	outputCache           NodeOutputCache
+	parameterBinder       domain.IntegrationParameterBinder
+	payloadStore          PayloadStore
+	spillThresholdBytes   int64
+	schemasByType         map[domain.IntegrationType]domain.Integration
+	eventSinks            []domain.EventSink
 
 	executionRegistry ExecutionRegistry
+	admission         *admissionController
 }
 
 type WorkflowExecutorServiceDependencies struct {
@@ -90,18 +154,51 @@ type WorkflowExecutorServiceDependencies struct {
 	OrderedEventPublisher domain.EventPublisher
 	FlowbakerClient       flowbaker.ClientInterface
 	CredentialManager     domain.ExecutorCredentialManager
+	CheckpointStore       CheckpointStore       // Optional, executions are not checkpointed when nil
+	Metrics               *ExecutionMetrics     // Optional, metrics are not recorded when nil
+	HistoryStore          ExecutionHistoryStore // Optional, executions are not kept locally when nil
+	OutputCache           NodeOutputCache       // Optional, node outputs are not cached when nil
+	ParameterBinder       domain.IntegrationParameterBinder
+	PayloadStore          PayloadStore         // Optional, node outputs are kept in memory when nil
+	SpillThresholdBytes   int64                // DefaultSpillThresholdBytes when zero
+	Schemas               []domain.Integration // Optional, dry runs classify actions by their type when missing
+	EventSinks            []domain.EventSink   // Optional, events are only sent to the platform when empty
+	AdmissionLimits       AdmissionLimits
 }
 
 func NewWorkflowExecutorService(deps WorkflowExecutorServiceDependencies) WorkflowExecutorService {
 	executionRegistry := NewExecutionRegistry()
 
-	return &workflowExecutorService{
+	schemasByType := map[domain.IntegrationType]domain.Integration{}
+	for _, schema := range deps.Schemas {
+		schemasByType[schema.ID] = schema
+	}
+
+	service := &workflowExecutorService{
 		integrationSelector:   deps.IntegrationSelector,
 		orderedEventPublisher: deps.OrderedEventPublisher,
 		flowbakerClient:       deps.FlowbakerClient,
 		credentialManager:     deps.CredentialManager,
+		checkpointStore:       deps.CheckpointStore,
+		metrics:               deps.Metrics,
+		historyStore:          deps.HistoryStore,
+		outputCache:           deps.OutputCache,
+		parameterBinder:       deps.ParameterBinder,
+		payloadStore:          deps.PayloadStore,
+		spillThresholdBytes:   deps.SpillThresholdBytes,
+		schemasByType:         schemasByType,
+		eventSinks:            deps.EventSinks,
 		executionRegistry:     executionRegistry,
 	}
+
+	service.admission = newAdmissionController(deps.AdmissionLimits, &service.executionRegistry)
+
+	if deps.Metrics != nil {
+		deps.Metrics.RegisterActiveExecutions(&service.executionRegistry)
+		deps.Metrics.RegisterQueuedExecutions(service.AdmissionStatus)
+	}
+
+	return service
 }
 
 type ExecuteParams struct {
@@ -113,9 +210,23 @@ type ExecuteParams struct {
 	EnableEvents      bool
 	IsTestingWorkflow bool
 	ExecutorStateSnapshot *domain.ExecutorStateSnapshot
+	CallDepth             int  // Set when the execution was started by an Execute Workflow node
+	DryRun                bool // Write actions output the parameters they would have sent instead of running
 }
 
 func (s *workflowExecutorService) Execute(ctx context.Context, params ExecuteParams) (ExecutionResult, error) {
+	// Execute Workflow nodes check the depth before calling, this also stops chains the
+	// platform started without going through them.
+	if params.CallDepth > domain.MaxWorkflowCallDepth {
+		return ExecutionResult{}, domain.ErrMaxCallDepthExceeded
+	}
+
+	// Testing runs are started from the editor, there is nobody to resume them for.
+	var checkpointStore CheckpointStore
+	if !params.IsTestingWorkflow {
+		checkpointStore = s.checkpointStore
+	}
+
 	workflowExecutor, err := NewWorkflowExecutor(WorkflowExecutorDeps{
 		ExecutionID:           params.ExecutionID,
 		UserID:                params.UserID,
@@ -126,6 +237,17 @@ func (s *workflowExecutorService) Execute(ctx context.Context, params ExecutePar
 		ExecutorClient:        s.flowbakerClient,
 		OrderedEventPublisher: s.orderedEventPublisher,
 		ExecutorStateSnapshot: params.ExecutorStateSnapshot,
+		CheckpointStore:       checkpointStore,
+		Metrics:               s.metrics,
+		HistoryStore:          s.historyStore,
+		CallDepth:             params.CallDepth,
+		DryRun:                params.DryRun,
+		Schemas:               s.schemasByType,
+		OutputCache:           s.outputCache,
+		ParameterBinder:       s.parameterBinder,
+		PayloadStore:          s.payloadStore,
+		SpillThresholdBytes:   s.spillThresholdBytes,
+		EventSinks:            s.eventSinks,
 	})
 	if err != nil {
 		return ExecutionResult{}, err
@@ -136,33 +258,69 @@ func (s *workflowExecutorService) Execute(ctx context.Context, params ExecutePar
 		return ExecutionResult{}, err
 	}
 
-	cancelCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	cancelCtx, cancel := context.WithCancelCause(ctx)
 
 	activeExecution := ActiveExecution{
 		ExecutionID: params.ExecutionID,
 		WorkflowID:  params.Workflow.ID,
 		WorkspaceID: params.Workflow.WorkspaceID,
-		CancelFunc:  cancel,
+		CancelFunc:  func() { cancel(ErrExecutionStopped) },
+		HandOffFunc: workflowExecutor.RequestHandOff,
 	}
 
-	s.executionRegistry.RegisterExecution(activeExecution)
-	defer s.executionRegistry.UnregisterExecution(params.ExecutionID)
-
-	executionResult, err := workflowExecutor.Execute(cancelCtx, params.EventName, items)
+	admitted, err := s.admission.Admit(activeExecution, params.IsTestingWorkflow)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to execute workflow")
-
+		cancel(nil)
 		return ExecutionResult{}, err
 	}
 
-	return executionResult, nil
+	run := func() (ExecutionResult, error) {
+		defer cancel(nil)
+		defer s.admission.Release(params.ExecutionID)
+
+		executionResult, err := workflowExecutor.Execute(cancelCtx, params.EventName, items)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to execute workflow")
+
+			return ExecutionResult{}, err
+		}
+
+		return executionResult, nil
+	}
+
+	if admitted == nil {
+		return run()
+	}
+
+	log.Info().Str("execution_id", params.ExecutionID).Msg("Execution queued")
+
+	// The queued execution runs with ctx after Execute returned, callers pass a context
+	// that outlives the request.
+	go func() {
+		if !<-admitted {
+			cancel(nil)
+			return
+		}
+
+		run()
+	}()
+
+	return ExecutionResult{Queued: true}, nil
+}
+
+func (s *workflowExecutorService) AdmissionStatus() AdmissionStatus {
+	return s.admission.Status()
 }
 
 func (s *workflowExecutorService) Stop(ctx context.Context, executionID string) error {
-	execution, ok := s.executionRegistry.GetExecution(executionID)
+	// Queued executions are removed before they start, they still get a completed event
+	// like the running ones.
+	execution, ok := s.admission.Dequeue(executionID)
 	if !ok {
-		return errors.New("execution not found")
+		execution, ok = s.executionRegistry.GetExecution(executionID)
+		if !ok {
+			return errors.New("execution not found")
+		}
 	}
 
 	eventCtx := domain.NewContextWithEventOrder(ctx)
@@ -195,6 +353,7 @@ func (s *workflowExecutorService) HandlePollingEvent(ctx context.Context, event
 		WorkflowExecutionID: "",
 		EnableEvents:        false,
 		Observer:            nil,
+		StrictTemplates:     event.Workflow.Settings.StrictTemplates,
 	})
 
 	integrationPoller, err := s.integrationSelector.SelectPoller(ctx, domain.SelectIntegrationParams{
@@ -340,6 +499,12 @@ func (s *workflowExecutorService) RerunNode(ctx context.Context, params RerunNod
 		IsTestingWorkflow:     true,
 		ExecutorClient:        s.flowbakerClient,
 		OrderedEventPublisher: s.orderedEventPublisher,
+		Metrics:               s.metrics,
+		OutputCache:           s.outputCache,
+		ParameterBinder:       s.parameterBinder,
+		PayloadStore:          s.payloadStore,
+		SpillThresholdBytes:   s.spillThresholdBytes,
+		EventSinks:            s.eventSinks,
 	})
 	if err != nil {
 		return ExecutionResult{}, err
@@ -353,6 +518,7 @@ func (s *workflowExecutorService) RerunNode(ctx context.Context, params RerunNod
 		EnableEvents:        true,
 		Observer:            workflowExecutor.observer,
 		IsReExecution:       true,
+		StrictTemplates:     params.Workflow.Settings.StrictTemplates,
 	})
 
 	executionEntry := params.NodeExecutionEntry
@@ -395,6 +561,8 @@ func (s *workflowExecutorService) RunNode(ctx context.Context, params RunNodePar
 		IsTestingWorkflow:     true,
 		ExecutorClient:        s.flowbakerClient,
 		OrderedEventPublisher: s.orderedEventPublisher,
+		Metrics:               s.metrics,
+		EventSinks:            s.eventSinks,
 	})
 	if err != nil {
 		return RunNodeResult{}, err
@@ -407,6 +575,7 @@ func (s *workflowExecutorService) RunNode(ctx context.Context, params RunNodePar
 		WorkflowExecutionID: params.ExecutionID,
 		EnableEvents:        true,
 		Observer:            workflowExecutor.observer,
+		StrictTemplates:     params.Workflow.Settings.StrictTemplates,
 	})
 
 	var items []domain.Item
@@ -82,6 +82,7 @@ const (
 	IntegrationType_InputTrigger         IntegrationType = "input_trigger"
 	IntegrationType_Loop                 IntegrationType = "loop"
 	IntegrationType_Sleep                IntegrationType = "sleep"
+	IntegrationType_ExecuteWorkflow      IntegrationType = "execute_workflow"
 )
 
 type Integration struct {
@@ -164,6 +165,7 @@ var (
 	NodeHandleTypeDefault     NodeHandleType = "default"
 	NodeHandleTypeSuccess     NodeHandleType = "success"
 	NodeHandleTypeDestructive NodeHandleType = "destructive"
+	NodeHandleTypeError       NodeHandleType = "error"
 )
 
 var (
@@ -198,6 +200,7 @@ type IntegrationAction struct {
 	CombinedContexts              []ActionUsageContext                  `json:"combined_contexts" bson:"combined_contexts"`
 	IsNonAvailableForDefaultOAuth bool                                  `json:"is_non_available_for_default_oauth" bson:"is_non_available_for_default_oauth"`
 	Decoration                    NodeDecoration                        `json:"decoration" bson:"decoration"`
+	SideEffect                    ActionSideEffect                      `json:"side_effect,omitempty" bson:"side_effect,omitempty"` // Classified from ActionType when empty
 }
 
 type IntegrationContainerType string
@@ -228,6 +231,26 @@ type IntegrationContainer struct {
 	Controls         []ContainerControl                    `json:"controls" bson:"controls"`
 }
 
+func (c IntegrationContainer) GetControlByID(controlID string) (ContainerControl, bool) {
+	for _, control := range c.Controls {
+		if control.ID == controlID {
+			return control, true
+		}
+	}
+
+	return ContainerControl{}, false
+}
+
+func (c IntegrationContainer) GetControlByRole(role ContainerControlRole) (ContainerControl, bool) {
+	for _, control := range c.Controls {
+		if control.Role == role {
+			return control, true
+		}
+	}
+
+	return ContainerControl{}, false
+}
+
 type IntegrationEmbeddingModel struct {
 	ID          string `json:"id" bson:"id"`
 	Name        string `json:"name" bson:"name"`
@@ -241,6 +264,7 @@ type IntegrationInput struct {
 	IntegrationParams IntegrationParams
 	ActionType        IntegrationActionType
 	Workflow          *Workflow
+	PerItemOptions    PerItemOptions
 }
 
 func (i IntegrationInput) GetAllItems() []Item {
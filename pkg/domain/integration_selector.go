@@ -142,7 +142,7 @@ func (s *integrationSelector) SelectPoller(ctx context.Context, params SelectInt
 }
 
 func (s *integrationSelector) RegisterHTTPOAuthClientProvider(integrationType IntegrationType, httpClientProvider HTTPOauthClientProvider) {
-	s.httpOauthClientProvidersByType[integrationType] = httpClientProvider
+	s.httpOauthClientProvidersByType[integrationType] = tracedHTTPOAuthClientProvider{provider: httpClientProvider}
 }
 
 func (s *integrationSelector) SelectHTTPOAuthClientProvider(ctx context.Context, params SelectIntegrationParams) (HTTPOauthClientProvider, error) {
@@ -155,7 +155,7 @@ func (s *integrationSelector) SelectHTTPOAuthClientProvider(ctx context.Context,
 }
 
 func (s *integrationSelector) RegisterHTTPDefaultClientProvider(integrationType IntegrationType, httpClientProvider HTTPDefaultClientProvider) {
-	s.httpDefaultClientProvidersByType[integrationType] = httpClientProvider
+	s.httpDefaultClientProvidersByType[integrationType] = tracedHTTPDefaultClientProvider{provider: httpClientProvider}
 }
 
 func (s *integrationSelector) SelectHTTPDefaultClientProvider(ctx context.Context, params SelectIntegrationParams) (HTTPDefaultClientProvider, error) {
@@ -42,9 +42,20 @@ func DomainNodeItemsToFlowbaker(dni domain.NodeItems) flowbaker.NodeItems {
 		items[i] = flowbaker.Item(item)
 	}
 
+	var ref *flowbaker.ItemsRef
+	if dni.Ref != nil {
+		ref = &flowbaker.ItemsRef{
+			ID:          dni.Ref.ID,
+			WorkspaceID: dni.Ref.WorkspaceID,
+			Count:       dni.Ref.Count,
+			SizeInBytes: dni.Ref.SizeInBytes,
+		}
+	}
+
 	return flowbaker.NodeItems{
 		FromNodeID: dni.FromNodeID,
 		Items:      items,
+		Ref:        ref,
 	}
 }
 
@@ -67,6 +78,8 @@ func DomainNodeExecutionEntryToFlowbaker(de domain.NodeExecutionEntry) flowbaker
 		Error:              de.Error,
 		Timestamp:          de.Timestamp,
 		ExecutionOrder:     de.ExecutionOrder,
+		Attempt:            de.Attempt,
+		IsCached:           de.IsCached,
 	}
 }
 
@@ -114,9 +127,20 @@ func FlowbakerNodeItemsToDomain(fni flowbaker.NodeItems) domain.NodeItems {
 		items[i] = domain.Item(item)
 	}
 
+	var ref *domain.ItemsRef
+	if fni.Ref != nil {
+		ref = &domain.ItemsRef{
+			ID:          fni.Ref.ID,
+			WorkspaceID: fni.Ref.WorkspaceID,
+			Count:       fni.Ref.Count,
+			SizeInBytes: fni.Ref.SizeInBytes,
+		}
+	}
+
 	return domain.NodeItems{
 		FromNodeID: fni.FromNodeID,
 		Items:      items,
+		Ref:        ref,
 	}
 }
 
@@ -139,6 +163,8 @@ func FlowbakerNodeExecutionEntryToDomain(fe flowbaker.NodeExecutionEntry) domain
 		Error:              fe.Error,
 		Timestamp:          fe.Timestamp,
 		ExecutionOrder:     fe.ExecutionOrder,
+		Attempt:            fe.Attempt,
+		IsCached:           fe.IsCached,
 	}
 }
 
@@ -171,6 +197,9 @@ func ExecutorWorkflowToDomain(w *executortypes.Workflow) domain.Workflow {
 		Edges:        w.Edges,
 		Settings: domain.WorkflowSettings{
 			NodeExecutionLimit: w.Settings.NodeExecutionLimit,
+			MaxDuration:        time.Duration(w.Settings.MaxDurationMs) * time.Millisecond,
+			MaxConcurrency:     w.Settings.MaxConcurrency,
+			StrictTemplates:    w.Settings.StrictTemplates,
 		},
 		LastUpdatedAt:    time.Unix(w.LastUpdatedAt, 0),
 		ActivationStatus: domain.WorkflowActivationStatus(w.ActivationStatus),
@@ -190,6 +219,13 @@ func ExecutorWorkflowNodeToDomain(n executortypes.WorkflowNode) domain.WorkflowN
 			ReturnErrorAsItem:       n.Settings.ReturnErrorAsItem,
 			OverwriteExecutionLimit: n.Settings.OverwriteExecutionLimit,
 			ExecutionLimit:          n.Settings.ExecutionLimit,
+			RetryPolicy:             ExecutorRetryPolicyToDomain(n.Settings.RetryPolicy),
+			Timeout:                 time.Duration(n.Settings.TimeoutMs) * time.Millisecond,
+			ItemConcurrency:         n.Settings.ItemConcurrency,
+			ItemRateLimit:           n.Settings.ItemRateLimit,
+			ContinueOnItemError:     n.Settings.ContinueOnItemError,
+			CacheOutput:             n.Settings.CacheOutput,
+			CacheTTL:                time.Duration(n.Settings.CacheTTLMs) * time.Millisecond,
 		},
 		ExpressionSelectedProperties: n.ExpressionSelectedProperties,
 		ProvidedByAgent:              n.ProvidedByAgent,
@@ -205,6 +241,26 @@ func ExecutorWorkflowNodeToDomain(n executortypes.WorkflowNode) domain.WorkflowN
 		TriggerNodeOpts: domain.TriggerNodeOpts{
 			EventType: domain.IntegrationTriggerEventType(n.TriggerNodeOpts.EventType),
 		},
+		ContainerNodeOpts: domain.ContainerNodeOpts{
+			ContainerType: domain.IntegrationContainerType(n.ContainerNodeOpts.ContainerType),
+		},
+	}
+}
+
+// ExecutorRetryPolicyToDomain converts an executor RetryPolicy to domain.RetryPolicy
+func ExecutorRetryPolicyToDomain(p executortypes.RetryPolicy) domain.RetryPolicy {
+	retryOn := make([]domain.RetryErrorClass, len(p.RetryOn))
+	for i, errorClass := range p.RetryOn {
+		retryOn[i] = domain.RetryErrorClass(errorClass)
+	}
+
+	return domain.RetryPolicy{
+		MaxAttempts:  p.MaxAttempts,
+		BackoffType:  domain.RetryBackoffType(p.BackoffType),
+		InitialDelay: time.Duration(p.InitialDelayMs) * time.Millisecond,
+		MaxDelay:     time.Duration(p.MaxDelayMs) * time.Millisecond,
+		Jitter:       p.Jitter,
+		RetryOn:      retryOn,
 	}
 }
 
@@ -232,6 +288,9 @@ func DomainWorkflowToExecutor(w domain.Workflow) executortypes.Workflow {
 		Edges:        w.Edges,
 		Settings: executortypes.WorkflowSettings{
 			NodeExecutionLimit: w.Settings.NodeExecutionLimit,
+			MaxDurationMs:      w.Settings.MaxDuration.Milliseconds(),
+			MaxConcurrency:     w.Settings.MaxConcurrency,
+			StrictTemplates:    w.Settings.StrictTemplates,
 		},
 		LastUpdatedAt:    w.LastUpdatedAt.Unix(),
 		ActivationStatus: executortypes.WorkflowActivationStatus(w.ActivationStatus),
@@ -254,6 +313,13 @@ func DomainWorkflowNodesToExecutor(nodes []domain.WorkflowNode) []executortypes.
 				ReturnErrorAsItem:       node.Settings.ReturnErrorAsItem,
 				OverwriteExecutionLimit: node.Settings.OverwriteExecutionLimit,
 				ExecutionLimit:          node.Settings.ExecutionLimit,
+				RetryPolicy:             DomainRetryPolicyToExecutor(node.Settings.RetryPolicy),
+				TimeoutMs:               node.Settings.Timeout.Milliseconds(),
+				ItemConcurrency:         node.Settings.ItemConcurrency,
+				ItemRateLimit:           node.Settings.ItemRateLimit,
+				ContinueOnItemError:     node.Settings.ContinueOnItemError,
+				CacheOutput:             node.Settings.CacheOutput,
+				CacheTTLMs:              node.Settings.CacheTTL.Milliseconds(),
 			},
 			ExpressionSelectedProperties: node.ExpressionSelectedProperties,
 			ProvidedByAgent:              node.ProvidedByAgent,
@@ -267,11 +333,31 @@ func DomainWorkflowNodesToExecutor(nodes []domain.WorkflowNode) []executortypes.
 			TriggerNodeOpts: executortypes.TriggerNodeOpts{
 				EventType: executortypes.IntegrationTriggerEventType(node.TriggerNodeOpts.EventType),
 			},
+			ContainerNodeOpts: executortypes.ContainerNodeOpts{
+				ContainerType: string(node.ContainerNodeOpts.ContainerType),
+			},
 		}
 	}
 	return executorNodes
 }
 
+// DomainRetryPolicyToExecutor converts a domain.RetryPolicy to executortypes.RetryPolicy
+func DomainRetryPolicyToExecutor(p domain.RetryPolicy) executortypes.RetryPolicy {
+	retryOn := make([]string, len(p.RetryOn))
+	for i, errorClass := range p.RetryOn {
+		retryOn[i] = string(errorClass)
+	}
+
+	return executortypes.RetryPolicy{
+		MaxAttempts:    p.MaxAttempts,
+		BackoffType:    string(p.BackoffType),
+		InitialDelayMs: p.InitialDelay.Milliseconds(),
+		MaxDelayMs:     p.MaxDelay.Milliseconds(),
+		Jitter:         p.Jitter,
+		RetryOn:        retryOn,
+	}
+}
+
 // DomainWorkflowTypeToExecutor converts a domain.WorkflowType to executortypes.WorkflowType
 func DomainWorkflowTypeToExecutor(workflowType domain.WorkflowType) executortypes.WorkflowType {
 	switch workflowType {
@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultItemConcurrency = 1
+	MaxItemConcurrency     = 50
+)
+
+// ItemErrorOutputIndex is the output index that receives the items a per item action
+// failed for, when the node continues on item errors. It is negative so that it never
+// collides with the outputs an action declares or routes items to.
+const ItemErrorOutputIndex = -1
+
+// ItemErrorOutputHandle is the output handle of ItemErrorOutputIndex. Action nodes that
+// continue on item errors have it next to the outputs their action declares.
+var ItemErrorOutputHandle = NodeHandle{
+	Index:        ItemErrorOutputIndex,
+	Type:         NodeHandleTypeError,
+	Position:     NodeHandlePositionRight,
+	Text:         "Failed items",
+	UsageContext: UsageContextWorkflow,
+}
+
+// GetOutputHandles returns the output handles of a node of the action in the usage
+// context. Nodes that continue on item errors have ItemErrorOutputHandle after the
+// outputs the action declares, so that their failed items can be connected.
+func (a IntegrationAction) GetOutputHandles(usageContext ActionUsageContext, settings NodeSettings) []NodeHandle {
+	handles := slices.Clone(a.HandlesByContext[usageContext].Output)
+
+	if usageContext == UsageContextWorkflow && settings.ContinueOnItemError {
+		handles = append(handles, ItemErrorOutputHandle)
+	}
+
+	return handles
+}
+
+// PerItemOptions controls how per item actions go through the input items. Items are
+// processed one at a time by default, output order always follows the input order.
+type PerItemOptions struct {
+	Concurrency int
+	// RateLimit is the maximum number of items started per second, 0 means no limit.
+	RateLimit float64
+	// ContinueOnError sends the items that fail to ItemErrorOutputIndex instead of
+	// failing the node.
+	ContinueOnError bool
+	// RetryItem is called when an item fails, the item alone is attempted again when it
+	// returns true. Items are not retried when it is nil.
+	RetryItem ItemRetryFunc
+}
+
+// ItemRetryFunc decides whether an item that failed on the given attempt (1-based) is
+// attempted again, it returns once the item can be retried.
+type ItemRetryFunc func(ctx context.Context, item Item, attempt int, err error) bool
+
+// ItemRetryError is the error of an item that was given to RetryItem, the items of the
+// node that succeeded must not run again because of it.
+type ItemRetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e ItemRetryError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("failed after %d attempts: %s", e.Attempts, e.Err)
+	}
+
+	return e.Err.Error()
+}
+
+func (e ItemRetryError) Unwrap() error {
+	return e.Err
+}
+
+func (o PerItemOptions) GetConcurrency() int {
+	if o.Concurrency < 1 {
+		return DefaultItemConcurrency
+	}
+
+	return min(o.Concurrency, MaxItemConcurrency)
+}
+
+func (o PerItemOptions) newLimiter() *rate.Limiter {
+	if o.RateLimit <= 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(o.RateLimit), 1)
+}
+
+// perItemResults holds the results of a per item action in the order of the items. When
+// the action continues on item errors, the error of a failed item is kept at its index.
+type perItemResults[T any] struct {
+	Results  []T
+	ItemErrs []error
+}
+
+// FailedItems returns the items that failed along with their error message.
+func (r perItemResults[T]) FailedItems(items []Item) []Item {
+	failedItems := []Item{}
+
+	for i, err := range r.ItemErrs {
+		if err == nil {
+			continue
+		}
+
+		failedItems = append(failedItems, FailedItem{
+			Item:         items[i],
+			ErrorMessage: err.Error(),
+		})
+	}
+
+	return failedItems
+}
+
+// Succeeded returns the results of the items that did not fail.
+func (r perItemResults[T]) Succeeded() []T {
+	results := make([]T, 0, len(r.Results))
+
+	for i, result := range r.Results {
+		if r.ItemErrs[i] != nil {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// SetFailedItems adds the failed items to the error output of the node, if there are any.
+func (r perItemResults[T]) SetFailedItems(itemsByOutputIndex NodeItemsMap, nodeID string, items []Item) NodeItemsMap {
+	failedItems := r.FailedItems(items)
+	if len(failedItems) == 0 {
+		return itemsByOutputIndex
+	}
+
+	return itemsByOutputIndex.Set(ItemErrorOutputIndex, nodeID, failedItems)
+}
+
+// retryItem wraps fn so that an item is attempted until it succeeds or retry gives up.
+func retryItem[T any](retry ItemRetryFunc, fn func(ctx context.Context, item Item) (T, error)) func(ctx context.Context, item Item) (T, error) {
+	return func(ctx context.Context, item Item) (T, error) {
+		for attempt := 1; ; attempt++ {
+			result, err := fn(ctx, item)
+			if err == nil {
+				return result, nil
+			}
+
+			if ctx.Err() != nil || !retry(ctx, item, attempt, err) {
+				return result, ItemRetryError{Attempts: attempt, Err: err}
+			}
+		}
+	}
+}
+
+// forEachItem calls fn for every item and returns the results in the order of the items.
+// Unless the options continue on error, the first error stops items that have not started
+// yet and cancels the context of the ones that are running.
+func forEachItem[T any](ctx context.Context, options PerItemOptions, items []Item, fn func(ctx context.Context, item Item) (T, error)) (perItemResults[T], error) {
+	results := perItemResults[T]{
+		Results:  make([]T, len(items)),
+		ItemErrs: make([]error, len(items)),
+	}
+
+	if options.RetryItem != nil {
+		fn = retryItem(options.RetryItem, fn)
+	}
+
+	limiter := options.newLimiter()
+
+	concurrency := options.GetConcurrency()
+
+	if concurrency == 1 {
+		for i, item := range items {
+			if limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					return perItemResults[T]{}, err
+				}
+			}
+
+			result, err := fn(ctx, item)
+			if err != nil {
+				if !options.ContinueOnError || ctx.Err() != nil {
+					return perItemResults[T]{}, err
+				}
+
+				results.ItemErrs[i] = err
+
+				continue
+			}
+
+			results.Results[i] = result
+		}
+
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	for i, item := range items {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				break
+			}
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(index int, item Item) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, err := fn(ctx, item)
+			if err != nil {
+				if options.ContinueOnError && ctx.Err() == nil {
+					results.ItemErrs[index] = err
+					return
+				}
+
+				errOnce.Do(func() {
+					firstErr = err
+					cancel(err)
+				})
+
+				return
+			}
+
+			results.Results[index] = result
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return perItemResults[T]{}, firstErr
+	}
+
+	if ctx.Err() != nil {
+		return perItemResults[T]{}, context.Cause(ctx)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntegrationAction_GetOutputHandles(t *testing.T) {
+	successHandle := NodeHandle{Index: 0, Type: NodeHandleTypeSuccess, UsageContext: UsageContextWorkflow}
+
+	action := IntegrationAction{
+		ActionType: "send",
+		HandlesByContext: map[ActionUsageContext]ContextHandles{
+			UsageContextWorkflow: {Output: []NodeHandle{successHandle}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		usageContext ActionUsageContext
+		settings     NodeSettings
+		expected     []NodeHandle
+	}{
+		{
+			name:         "declared outputs",
+			usageContext: UsageContextWorkflow,
+			expected:     []NodeHandle{successHandle},
+		},
+		{
+			name:         "continues on item errors",
+			usageContext: UsageContextWorkflow,
+			settings:     NodeSettings{ContinueOnItemError: true},
+			expected:     []NodeHandle{successHandle, ItemErrorOutputHandle},
+		},
+		{
+			name:         "tool usage context",
+			usageContext: UsageContextTool,
+			settings:     NodeSettings{ContinueOnItemError: true},
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := action.GetOutputHandles(tt.usageContext, tt.settings); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("GetOutputHandles() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+
+	if len(action.HandlesByContext[UsageContextWorkflow].Output) != 1 {
+		t.Errorf("GetOutputHandles() changed the handles of the action")
+	}
+}
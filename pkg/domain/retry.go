@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type RetryBackoffType string
+
+const (
+	RetryBackoffTypeFixed       RetryBackoffType = "fixed"
+	RetryBackoffTypeExponential RetryBackoffType = "exponential"
+)
+
+type RetryErrorClass string
+
+const (
+	RetryErrorClassAny         RetryErrorClass = "any"
+	RetryErrorClassTimeout     RetryErrorClass = "timeout"
+	RetryErrorClassNetwork     RetryErrorClass = "network"
+	RetryErrorClassRateLimit   RetryErrorClass = "rate_limit"
+	RetryErrorClassServerError RetryErrorClass = "server_error"
+	RetryErrorClassOther       RetryErrorClass = "other"
+)
+
+// DefaultRetryErrorClasses are retried when a policy does not list the classes it retries
+// on. Errors of the other classes, such as 4xx responses, are not fixed by trying again.
+var DefaultRetryErrorClasses = []RetryErrorClass{
+	RetryErrorClassTimeout,
+	RetryErrorClassNetwork,
+	RetryErrorClassRateLimit,
+	RetryErrorClassServerError,
+}
+
+const (
+	DefaultRetryInitialDelay = 1 * time.Second
+	DefaultRetryMaxDelay     = 5 * time.Minute
+	MaxRetryAttempts         = 10
+)
+
+// RetryPolicy describes how many times a failed node is attempted and how long the
+// executor waits between attempts. A policy with MaxAttempts <= 1 disables retries.
+type RetryPolicy struct {
+	MaxAttempts  int
+	BackoffType  RetryBackoffType
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       bool
+	RetryOn      []RetryErrorClass
+}
+
+func (p RetryPolicy) IsEnabled() bool {
+	return p.MaxAttempts > 1
+}
+
+func (p RetryPolicy) GetMaxAttempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return min(p.MaxAttempts, MaxRetryAttempts)
+}
+
+// ShouldRetry reports whether another attempt should be made after the given attempt
+// (1-based) failed with err.
+func (p RetryPolicy) ShouldRetry(attempt int, err error) bool {
+	if err == nil || attempt >= p.GetMaxAttempts() {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	retryOn := p.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = DefaultRetryErrorClasses
+	}
+
+	if slices.Contains(retryOn, RetryErrorClassAny) {
+		return true
+	}
+
+	return slices.Contains(retryOn, ClassifyError(err))
+}
+
+// GetDelay returns how long to wait before the attempt following the given failed attempt.
+func (p RetryPolicy) GetDelay(attempt int) time.Duration {
+	initialDelay := p.InitialDelay
+	if initialDelay <= 0 {
+		initialDelay = DefaultRetryInitialDelay
+	}
+
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := initialDelay
+
+	if p.BackoffType == RetryBackoffTypeExponential {
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+	}
+
+	delay = min(delay, maxDelay)
+
+	if p.Jitter && delay > 0 {
+		half := delay / 2
+		delay = half + rand.N(half+1)
+	}
+
+	return delay
+}
+
+var statusCodePattern = regexp.MustCompile(`(?i)status(?:\s+code)?:?\s*(\d{3})\b`)
+
+type statusCoder interface {
+	StatusCode() int
+}
+
+// ClassifyError maps an integration error to the retry class it belongs to. Integrations
+// mostly return plain errors, so HTTP status codes are also read from the error message.
+func ClassifyError(err error) RetryErrorClass {
+	if err == nil {
+		return RetryErrorClassOther
+	}
+
+	if statusCode, ok := GetErrorStatusCode(err); ok {
+		switch {
+		case statusCode == 429:
+			return RetryErrorClassRateLimit
+		case statusCode == 408:
+			return RetryErrorClassTimeout
+		case statusCode >= 500:
+			return RetryErrorClassServerError
+		default:
+			return RetryErrorClassOther
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return RetryErrorClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return RetryErrorClassTimeout
+		}
+
+		return RetryErrorClassNetwork
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return RetryErrorClassNetwork
+	}
+
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case strings.Contains(message, "timeout"), strings.Contains(message, "deadline exceeded"):
+		return RetryErrorClassTimeout
+	case strings.Contains(message, "connection reset"), strings.Contains(message, "connection refused"), strings.Contains(message, "no such host"):
+		return RetryErrorClassNetwork
+	case strings.Contains(message, "rate limit"), strings.Contains(message, "too many requests"):
+		return RetryErrorClassRateLimit
+	}
+
+	return RetryErrorClassOther
+}
+
+func GetErrorStatusCode(err error) (int, bool) {
+	var coder statusCoder
+	if errors.As(err, &coder) {
+		return coder.StatusCode(), true
+	}
+
+	matches := statusCodePattern.FindStringSubmatch(err.Error())
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	statusCode, convErr := strconv.Atoi(matches[1])
+	if convErr != nil {
+		return 0, false
+	}
+
+	return statusCode, true
+}
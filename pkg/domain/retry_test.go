@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected RetryErrorClass
+	}{
+		{
+			name:     "rate limited",
+			err:      fmt.Errorf("pipedrive API error (status 429): slow down"),
+			expected: RetryErrorClassRateLimit,
+		},
+		{
+			name:     "bad gateway",
+			err:      fmt.Errorf("HTTP request failed with status 502: 502 Bad Gateway"),
+			expected: RetryErrorClassServerError,
+		},
+		{
+			name:     "status with colon",
+			err:      fmt.Errorf("append failed with status: 503"),
+			expected: RetryErrorClassServerError,
+		},
+		{
+			name:     "client error",
+			err:      fmt.Errorf("Jira API returned status 404"),
+			expected: RetryErrorClassOther,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("failed to make request: %w", context.DeadlineExceeded),
+			expected: RetryErrorClassTimeout,
+		},
+		{
+			name:     "connection reset",
+			err:      errors.New("read tcp 10.0.0.1:443: connection reset by peer"),
+			expected: RetryErrorClassNetwork,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("field_path cannot be empty"),
+			expected: RetryErrorClassOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.expected {
+				t.Errorf("ClassifyError() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	serverErr := errors.New("request failed with status 500")
+	clientErr := errors.New("request failed with status 400")
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		err      error
+		expected bool
+	}{
+		{
+			name:     "disabled policy",
+			policy:   RetryPolicy{},
+			attempt:  1,
+			err:      serverErr,
+			expected: false,
+		},
+		{
+			name:     "retry any error",
+			policy:   RetryPolicy{MaxAttempts: 3, RetryOn: []RetryErrorClass{RetryErrorClassAny}},
+			attempt:  1,
+			err:      clientErr,
+			expected: true,
+		},
+		{
+			name:     "default retries transient errors",
+			policy:   RetryPolicy{MaxAttempts: 3},
+			attempt:  1,
+			err:      serverErr,
+			expected: true,
+		},
+		{
+			name:     "default does not retry client errors",
+			policy:   RetryPolicy{MaxAttempts: 3},
+			attempt:  1,
+			err:      clientErr,
+			expected: false,
+		},
+		{
+			name:     "attempts exhausted",
+			policy:   RetryPolicy{MaxAttempts: 3},
+			attempt:  3,
+			err:      serverErr,
+			expected: false,
+		},
+		{
+			name:     "matching error class",
+			policy:   RetryPolicy{MaxAttempts: 3, RetryOn: []RetryErrorClass{RetryErrorClassServerError}},
+			attempt:  1,
+			err:      serverErr,
+			expected: true,
+		},
+		{
+			name:     "non matching error class",
+			policy:   RetryPolicy{MaxAttempts: 3, RetryOn: []RetryErrorClass{RetryErrorClassServerError}},
+			attempt:  1,
+			err:      clientErr,
+			expected: false,
+		},
+		{
+			name:     "cancelled",
+			policy:   RetryPolicy{MaxAttempts: 3},
+			attempt:  1,
+			err:      context.Canceled,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ShouldRetry(tt.attempt, tt.err); got != tt.expected {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_GetDelay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:  5,
+		BackoffType:  RetryBackoffTypeExponential,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     500 * time.Millisecond,
+	}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+
+	for i, want := range expected {
+		if got := policy.GetDelay(i + 1); got != want {
+			t.Errorf("GetDelay(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+
+	policy.Jitter = true
+
+	for attempt := 1; attempt <= 4; attempt++ {
+		got := policy.GetDelay(attempt)
+		if got < expected[attempt-1]/2 || got > expected[attempt-1] {
+			t.Errorf("GetDelay(%d) with jitter = %v, want within [%v, %v]", attempt, got, expected[attempt-1]/2, expected[attempt-1])
+		}
+	}
+}
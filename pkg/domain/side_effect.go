@@ -0,0 +1,64 @@
+package domain
+
+import "strings"
+
+// ActionSideEffect tells whether an action changes anything outside of the execution.
+// Dry runs execute read actions and stub write actions.
+type ActionSideEffect string
+
+const (
+	ActionSideEffectRead  ActionSideEffect = "read"
+	ActionSideEffectWrite ActionSideEffect = "write"
+)
+
+// readActionPrefixes are the verbs of action types that only read data.
+var readActionPrefixes = []string{
+	"get_",
+	"list_",
+	"find_",
+	"search_",
+	"download_",
+	"decode_",
+	"notion_get_",
+	"notion_search_",
+	"teams_get_",
+	"org_get_",
+	"user_get_",
+}
+
+// readActionTypes are action types that only read data without a read verb prefix,
+// mostly the commands of key value stores.
+var readActionTypes = map[IntegrationActionType]struct{}{
+	"get": {}, "search": {}, "select": {}, "scrape": {}, "exists": {}, "query_folder": {},
+	"keys": {}, "ttl": {}, "type": {}, "strlen": {},
+	"hget": {}, "hgetall": {}, "hkeys": {}, "hlen": {}, "hvals": {}, "hexists": {},
+	"lindex": {}, "llen": {}, "lrange": {},
+	"scard": {}, "sismember": {}, "smembers": {},
+	"zcard": {}, "zrange": {}, "zrangebyscore": {}, "zrank": {}, "zrevrange": {}, "zrevrangebyscore": {}, "zscore": {},
+}
+
+// GetSideEffect returns the side effect set on the action, or classifies its action
+// type when it has none.
+func (a IntegrationAction) GetSideEffect() ActionSideEffect {
+	if a.SideEffect != "" {
+		return a.SideEffect
+	}
+
+	return ClassifyActionType(a.ActionType)
+}
+
+// ClassifyActionType classifies an action by the verb of its type. Actions that can
+// not be recognized as reads are writes, so that a dry run never performs them.
+func ClassifyActionType(actionType IntegrationActionType) ActionSideEffect {
+	if _, ok := readActionTypes[actionType]; ok {
+		return ActionSideEffectRead
+	}
+
+	for _, prefix := range readActionPrefixes {
+		if strings.HasPrefix(string(actionType), prefix) {
+			return ActionSideEffectRead
+		}
+	}
+
+	return ActionSideEffectWrite
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestIntegrationAction_GetSideEffect(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   IntegrationAction
+		expected ActionSideEffect
+	}{
+		{
+			name:     "read verb prefix",
+			action:   IntegrationAction{ActionType: "get_many_issues"},
+			expected: ActionSideEffectRead,
+		},
+		{
+			name:     "read command without prefix",
+			action:   IntegrationAction{ActionType: "hgetall"},
+			expected: ActionSideEffectRead,
+		},
+		{
+			name:     "write verb",
+			action:   IntegrationAction{ActionType: "send_message"},
+			expected: ActionSideEffectWrite,
+		},
+		{
+			name:     "unknown verb",
+			action:   IntegrationAction{ActionType: "execute_query"},
+			expected: ActionSideEffectWrite,
+		},
+		{
+			name:     "set on the action",
+			action:   IntegrationAction{ActionType: "delete_field", SideEffect: ActionSideEffectRead},
+			expected: ActionSideEffectRead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.action.GetSideEffect(); got != tt.expected {
+				t.Errorf("GetSideEffect() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
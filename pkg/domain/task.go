@@ -35,6 +35,7 @@ type ExecuteWorkflowTask struct {
 	ExecutionID     string       `json:"execution_id,omitempty"`
 	IsResume        bool         `json:"is_resume,omitempty"`
 	ScheduledJobID  string       `json:"scheduled_job_id,omitempty"`
+	CallDepth       int          `json:"call_depth,omitempty"` // Copied by the platform to the call_depth of the StartExecutionRequest it sends for the task
 }
 
 func (t ExecuteWorkflowTask) GetType() TaskType {
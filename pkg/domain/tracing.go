@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// TraceHTTPClient makes the requests of client propagate the trace context of their
+// request context and record a client span each. Clients that are already traced are
+// returned as they are, so wrapping twice does not nest spans.
+func TraceHTTPClient(client *http.Client) *http.Client {
+	if client == nil {
+		return nil
+	}
+
+	if _, ok := client.Transport.(*otelhttp.Transport); ok {
+		return client
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	traced := *client
+	traced.Transport = otelhttp.NewTransport(base)
+
+	return &traced
+}
+
+type tracedHTTPDefaultClientProvider struct {
+	provider HTTPDefaultClientProvider
+}
+
+func (p tracedHTTPDefaultClientProvider) GetHTTPDefaultClient(credential *Credential) (*http.Client, error) {
+	client, err := p.provider.GetHTTPDefaultClient(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return TraceHTTPClient(client), nil
+}
+
+type tracedHTTPOAuthClientProvider struct {
+	provider HTTPOauthClientProvider
+}
+
+func (p tracedHTTPOAuthClientProvider) GetHTTPOAuthClient(credential *OAuthAccountWithSensitiveData) (*http.Client, error) {
+	client, err := p.provider.GetHTTPOAuthClient(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return TraceHTTPClient(client), nil
+}
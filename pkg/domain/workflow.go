@@ -25,10 +25,18 @@ const (
 )
 
 type WorkflowEdge struct {
-	SourceNodeID string `json:"source_node_id"`
-	SourceIndex  int    `json:"source_index"`
-	TargetNodeID string `json:"target_node_id"`
-	TargetIndex  int    `json:"target_index"`
+	SourceNodeID    string `json:"source_node_id"`
+	SourceIndex     int    `json:"source_index"`
+	SourceControlID string `json:"source_control_id,omitempty"`
+	TargetNodeID    string `json:"target_node_id"`
+	TargetIndex     int    `json:"target_index"`
+	TargetControlID string `json:"target_control_id,omitempty"`
+}
+
+// IsControlEdge reports whether the edge starts from or ends at a container control
+// instead of a regular node handle.
+func (e WorkflowEdge) IsControlEdge() bool {
+	return e.SourceControlID != "" || e.TargetControlID != ""
 }
 
 type Workflow struct {
@@ -48,6 +56,11 @@ type Workflow struct {
 
 type WorkflowSettings struct {
 	NodeExecutionLimit int
+	MaxDuration        time.Duration
+	// MaxConcurrency enables concurrent execution of ready nodes when greater than 1.
+	MaxConcurrency int
+	// StrictTemplates fails a node when one of its {{ }} expressions fails to evaluate.
+	StrictTemplates bool
 }
 
 func (w Workflow) IsActive() bool {
@@ -123,6 +136,10 @@ func (w Workflow) GetConnectedInputIndices(nodeID string) []int {
 	seen := map[int]struct{}{}
 	indices := make([]int, 0)
 	for _, edge := range w.Edges {
+		if edge.IsControlEdge() {
+			continue
+		}
+
 		if edge.TargetNodeID == nodeID {
 			if _, ok := seen[edge.TargetIndex]; !ok {
 				seen[edge.TargetIndex] = struct{}{}
@@ -135,6 +152,10 @@ func (w Workflow) GetConnectedInputIndices(nodeID string) []int {
 
 func (w Workflow) FindEdge(targetNodeID, sourceNodeID string, sourceIndex int) (WorkflowEdge, bool) {
 	for _, edge := range w.Edges {
+		if edge.IsControlEdge() {
+			continue
+		}
+
 		if edge.TargetNodeID == targetNodeID && edge.SourceNodeID == sourceNodeID && edge.SourceIndex == sourceIndex {
 			return edge, true
 		}
@@ -142,6 +163,16 @@ func (w Workflow) FindEdge(targetNodeID, sourceNodeID string, sourceIndex int) (
 	return WorkflowEdge{}, false
 }
 
+func (w Workflow) GetControlOutgoingEdges(containerNodeID, controlID string) []WorkflowEdge {
+	edges := make([]WorkflowEdge, 0)
+	for _, edge := range w.Edges {
+		if edge.SourceNodeID == containerNodeID && edge.SourceControlID == controlID {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
 func (w Workflow) GetSourceNodesForInput(targetNodeID string, targetIndex int) []string {
 	nodeIDs := make([]string, 0)
 	for _, edge := range w.Edges {
@@ -158,25 +189,46 @@ type SourceOutput struct {
 }
 
 type EdgeIndex struct {
-	targetNodesBySourceOutput map[SourceOutput][]WorkflowNode
+	targetNodesBySourceOutput  map[SourceOutput][]WorkflowNode
+	controlEdgesBySourceOutput map[SourceOutput][]WorkflowEdge
 }
 
 func NewEdgeIndex(w Workflow) EdgeIndex {
 	m := make(map[SourceOutput][]WorkflowNode)
+	controlEdges := make(map[SourceOutput][]WorkflowEdge)
 
 	for _, edge := range w.Edges {
 		key := SourceOutput{NodeID: edge.SourceNodeID, OutputIndex: edge.SourceIndex}
 
+		// Edges leaving a container control are followed by the container itself,
+		// edges entering one are collected by the running container scope.
+		if edge.SourceControlID != "" {
+			continue
+		}
+
+		if edge.TargetControlID != "" {
+			controlEdges[key] = append(controlEdges[key], edge)
+			continue
+		}
+
 		if node, ok := w.GetNodeByID(edge.TargetNodeID); ok {
 			m[key] = append(m[key], node)
 		}
 	}
 
 	return EdgeIndex{
-		targetNodesBySourceOutput: m,
+		targetNodesBySourceOutput:  m,
+		controlEdgesBySourceOutput: controlEdges,
 	}
 }
 
+func (idx EdgeIndex) GetControlEdges(nodeID string, outputIndex int) []WorkflowEdge {
+	return idx.controlEdgesBySourceOutput[SourceOutput{
+		NodeID:      nodeID,
+		OutputIndex: outputIndex,
+	}]
+}
+
 func (idx EdgeIndex) GetTargetNodes(nodeID string, outputIndex int) []WorkflowNode {
 	sourceOutput := SourceOutput{
 		NodeID:      nodeID,
@@ -195,8 +247,9 @@ func (idx EdgeIndex) GetTargetNodes(nodeID string, outputIndex int) []WorkflowNo
 type NodeType string
 
 const (
-	NodeTypeTrigger NodeType = "trigger"
-	NodeTypeAction  NodeType = "action"
+	NodeTypeTrigger   NodeType = "trigger"
+	NodeTypeAction    NodeType = "action"
+	NodeTypeContainer NodeType = "container"
 )
 
 type WorkflowNode struct {
@@ -213,8 +266,9 @@ type WorkflowNode struct {
 	UsageContext                 string
 	ParentID                     string
 
-	TriggerNodeOpts TriggerNodeOpts `json:"trigger_node_opts,omitempty"`
-	ActionNodeOpts  ActionNodeOpts  `json:"action_node_opts,omitempty"`
+	TriggerNodeOpts   TriggerNodeOpts   `json:"trigger_node_opts,omitempty"`
+	ActionNodeOpts    ActionNodeOpts    `json:"action_node_opts,omitempty"`
+	ContainerNodeOpts ContainerNodeOpts `json:"container_node_opts,omitempty"`
 }
 
 type TriggerNodeOpts struct {
@@ -225,10 +279,21 @@ type ActionNodeOpts struct {
 	ActionType IntegrationActionType `json:"action_type,omitempty"`
 }
 
+type ContainerNodeOpts struct {
+	ContainerType IntegrationContainerType `json:"container_type,omitempty"`
+}
+
 type NodeSettings struct {
 	ReturnErrorAsItem       bool
 	OverwriteExecutionLimit bool
 	ExecutionLimit          int
+	RetryPolicy             RetryPolicy
+	Timeout                 time.Duration
+	ItemConcurrency         int
+	ItemRateLimit           float64
+	ContinueOnItemError     bool
+	CacheOutput             bool          // Reuses the output of earlier runs with the same parameters and input items
+	CacheTTL                time.Duration // How long a cached output is reused, DefaultNodeOutputCacheTTL when zero
 }
 
 type NodePositions struct {
@@ -39,24 +39,13 @@ func (ao *DefaultArrayOperations) Filter(array []interface{}, callbackNode ast.N
 
 	var result []interface{}
 
-	for _, item := range array {
+	for i, item := range array {
 		// Create new context with current item bound to parameter
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
-		}
-
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
-		}
+		itemContext := context.NewScope()
 
-		// Bind arrow function parameter to current array item
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				paramName := ident.Name.String()
-				itemContext.Variables[paramName] = item
-			}
+		// Bind arrow function parameters to current array item and index
+		if err := ao.bindParameters(arrowFn, itemContext, item, float64(i)); err != nil {
+			return nil, err
 		}
 
 		// Execute arrow function body
@@ -93,28 +82,11 @@ func (ao *DefaultArrayOperations) Map(array []interface{}, callbackNode ast.Node
 
 	for i, item := range array {
 		// Create new context with current item bound to parameter
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
-		}
-
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
-		}
+		itemContext := context.NewScope()
 
-		// Bind arrow function parameters
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				paramName := ident.Name.String()
-				itemContext.Variables[paramName] = item
-			}
-		}
-		if len(arrowFn.ParameterList.List) > 1 {
-			if ident, ok := arrowFn.ParameterList.List[1].Target.(*ast.Identifier); ok {
-				indexParamName := ident.Name.String()
-				itemContext.Variables[indexParamName] = float64(i) // JavaScript uses numbers for indices
-			}
+		// Bind arrow function parameters, JavaScript uses numbers for indices
+		if err := ao.bindParameters(arrowFn, itemContext, item, float64(i)); err != nil {
+			return nil, err
 		}
 
 		// Execute arrow function body
@@ -136,24 +108,13 @@ func (ao *DefaultArrayOperations) Find(array []interface{}, callbackNode ast.Nod
 		return nil, fmt.Errorf("find requires an arrow function")
 	}
 
-	for _, item := range array {
+	for i, item := range array {
 		// Create new context with current item bound to parameter
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
-		}
-
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
-		}
+		itemContext := context.NewScope()
 
-		// Bind arrow function parameter to current array item
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				paramName := ident.Name.String()
-				itemContext.Variables[paramName] = item
-			}
+		// Bind arrow function parameters to current array item and index
+		if err := ao.bindParameters(arrowFn, itemContext, item, float64(i)); err != nil {
+			return nil, err
 		}
 
 		// Execute arrow function body
@@ -178,24 +139,13 @@ func (ao *DefaultArrayOperations) Some(array []interface{}, callbackNode ast.Nod
 		return false, fmt.Errorf("some requires an arrow function")
 	}
 
-	for _, item := range array {
+	for i, item := range array {
 		// Create new context with current item bound to parameter
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
-		}
+		itemContext := context.NewScope()
 
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
-		}
-
-		// Bind arrow function parameter to current array item
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				paramName := ident.Name.String()
-				itemContext.Variables[paramName] = item
-			}
+		// Bind arrow function parameters to current array item and index
+		if err := ao.bindParameters(arrowFn, itemContext, item, float64(i)); err != nil {
+			return false, err
 		}
 
 		// Execute arrow function body
@@ -220,24 +170,13 @@ func (ao *DefaultArrayOperations) Every(array []interface{}, callbackNode ast.No
 		return false, fmt.Errorf("every requires an arrow function")
 	}
 
-	for _, item := range array {
+	for i, item := range array {
 		// Create new context with current item bound to parameter
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
-		}
+		itemContext := context.NewScope()
 
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
-		}
-
-		// Bind arrow function parameter to current array item
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				paramName := ident.Name.String()
-				itemContext.Variables[paramName] = item
-			}
+		// Bind arrow function parameters to current array item and index
+		if err := ao.bindParameters(arrowFn, itemContext, item, float64(i)); err != nil {
+			return false, err
 		}
 
 		// Execute arrow function body
@@ -278,46 +217,175 @@ func (ao *DefaultArrayOperations) Reduce(array []interface{}, callbackNode ast.N
 		item := array[i]
 
 		// Create new context with current item bound to parameters
-		itemContext := &types.ExpressionContext{
-			Item:      context.Item,
-			Variables: make(map[string]interface{}),
+		itemContext := context.NewScope()
+
+		// Bind arrow function parameters (accumulator, currentValue, index)
+		if err := ao.bindParameters(arrowFn, itemContext, accumulator, item, float64(i)); err != nil {
+			return nil, err
 		}
 
-		// Copy existing variables
-		for k, v := range context.Variables {
-			itemContext.Variables[k] = v
+		// Execute arrow function body
+		bodyResult, err := ao.executor.executeNode(arrowFn.Body, itemContext)
+		if err != nil {
+			return nil, err
 		}
 
-		// Bind arrow function parameters (accumulator, currentValue, index)
-		if len(arrowFn.ParameterList.List) > 0 {
-			if ident, ok := arrowFn.ParameterList.List[0].Target.(*ast.Identifier); ok {
-				accParamName := ident.Name.String()
-				itemContext.Variables[accParamName] = accumulator
+		accumulator = bodyResult
+	}
+
+	return accumulator, nil
+}
+
+// bindParameters binds the arguments of a callback to the parameters of arrowFn. Parameters
+// can be identifiers, rest parameters and object or array patterns with default values.
+func (ao *DefaultArrayOperations) bindParameters(arrowFn *ast.ArrowFunctionLiteral, scope *types.ExpressionContext, args ...interface{}) error {
+	for i, param := range arrowFn.ParameterList.List {
+		var value interface{}
+		if i < len(args) {
+			value = args[i]
+		}
+
+		if err := ao.bindTarget(param.Target, param.Initializer, value, scope); err != nil {
+			return err
+		}
+	}
+
+	if arrowFn.ParameterList.Rest != nil {
+		rest := []interface{}{}
+		if len(args) > len(arrowFn.ParameterList.List) {
+			rest = append(rest, args[len(arrowFn.ParameterList.List):]...)
+		}
+
+		return ao.bindTarget(arrowFn.ParameterList.Rest, nil, rest, scope)
+	}
+
+	return nil
+}
+
+// bindTarget binds value to an identifier or destructures it into a pattern, the
+// initializer is used when value is undefined.
+func (ao *DefaultArrayOperations) bindTarget(target ast.Expression, initializer ast.Expression, value interface{}, scope *types.ExpressionContext) error {
+	if value == nil && initializer != nil {
+		defaultValue, err := ao.executor.executeNode(initializer, scope)
+		if err != nil {
+			return err
+		}
+		value = defaultValue
+	}
+
+	switch t := target.(type) {
+	case *ast.Identifier:
+		scope.Variables[t.Name.String()] = value
+		return nil
+	case *ast.AssignExpression:
+		// Pattern element with a default value like {a = 1} or [a = 1]
+		return ao.bindTarget(t.Left, t.Right, value, scope)
+	case *ast.ObjectPattern:
+		if value == nil {
+			return fmt.Errorf("cannot destructure undefined")
+		}
+
+		bound := make(map[string]bool)
+
+		for _, prop := range t.Properties {
+			switch p := prop.(type) {
+			case *ast.PropertyShort:
+				name := p.Name.Name.String()
+				bound[name] = true
+
+				propValue, err := ao.executor.propertyAccess(value, name)
+				if err != nil {
+					return err
+				}
+
+				if err := ao.bindTarget(&p.Name, p.Initializer, propValue, scope); err != nil {
+					return err
+				}
+			case *ast.PropertyKeyed:
+				key, err := ao.patternKey(p, scope)
+				if err != nil {
+					return err
+				}
+				bound[key] = true
+
+				propValue, err := ao.executor.propertyAccess(value, key)
+				if err != nil {
+					return err
+				}
+
+				if err := ao.bindTarget(p.Value, nil, propValue, scope); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported destructuring property: %T", prop)
 			}
 		}
-		if len(arrowFn.ParameterList.List) > 1 {
-			if ident, ok := arrowFn.ParameterList.List[1].Target.(*ast.Identifier); ok {
-				itemParamName := ident.Name.String()
-				itemContext.Variables[itemParamName] = item
+
+		if t.Rest != nil {
+			rest := make(map[string]interface{})
+			if object, ok := value.(map[string]interface{}); ok {
+				for k, v := range object {
+					if !bound[k] {
+						rest[k] = v
+					}
+				}
 			}
+
+			return ao.bindTarget(t.Rest, nil, rest, scope)
 		}
-		if len(arrowFn.ParameterList.List) > 2 {
-			if ident, ok := arrowFn.ParameterList.List[2].Target.(*ast.Identifier); ok {
-				indexParamName := ident.Name.String()
-				itemContext.Variables[indexParamName] = float64(i)
+
+		return nil
+	case *ast.ArrayPattern:
+		if value == nil {
+			return fmt.Errorf("cannot destructure undefined")
+		}
+
+		for i, element := range t.Elements {
+			if element == nil {
+				continue
+			}
+
+			elementValue, err := ao.executor.propertyAccess(value, float64(i))
+			if err != nil {
+				return err
+			}
+
+			if err := ao.bindTarget(element, nil, elementValue, scope); err != nil {
+				return err
 			}
 		}
 
-		// Execute arrow function body
-		bodyResult, err := ao.executor.executeNode(arrowFn.Body, itemContext)
-		if err != nil {
-			return nil, err
+		if t.Rest != nil {
+			rest := []interface{}{}
+			if array, ok := value.([]interface{}); ok && len(array) > len(t.Elements) {
+				rest = append(rest, array[len(t.Elements):]...)
+			}
+
+			return ao.bindTarget(t.Rest, nil, rest, scope)
 		}
 
-		accumulator = bodyResult
+		return nil
+	default:
+		return fmt.Errorf("unsupported arrow function parameter: %T", target)
 	}
+}
 
-	return accumulator, nil
+// patternKey returns the property name of a keyed pattern property like {id: userId}
+func (ao *DefaultArrayOperations) patternKey(property *ast.PropertyKeyed, scope *types.ExpressionContext) (string, error) {
+	switch k := property.Key.(type) {
+	case *ast.Identifier:
+		return k.Name.String(), nil
+	case *ast.StringLiteral:
+		return k.Value.String(), nil
+	case *ast.NumberLiteral:
+		return fmt.Sprintf("%g", k.Value), nil
+	default:
+		key, err := ao.executor.executeNode(property.Key, scope)
+		if err != nil {
+			return "", err
+		}
+		return ao.executor.converter.ToString(key), nil
+	}
 }
 
 // isTruthy converts a value to boolean using JavaScript truthiness rules
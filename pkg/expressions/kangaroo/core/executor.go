@@ -2,6 +2,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -15,6 +16,10 @@ import (
 	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
 )
 
+// errOptionalChainNullish ends an optional chain like a?.b.c when the value before ?.
+// is null or undefined, the whole chain then evaluates to undefined.
+var errOptionalChainNullish = errors.New("optional chain reached a nullish value")
+
 // ASTExecutor provides safe AST execution without eval()
 type ASTExecutor struct {
 	functionRegistry types.FunctionRegistry
@@ -236,6 +241,27 @@ func (e *ASTExecutor) executeNode(node ast.Node, context *types.ExpressionContex
 		return e.executeObjectLiteral(n, context)
 	case *ast.ExpressionBody:
 		return e.executeNode(n.Expression, context)
+	case *ast.BlockStatement:
+		return e.executeBlockBody(n, context)
+	case *ast.TemplateLiteral:
+		return e.executeTemplateLiteral(n, context)
+	case *ast.RegExpLiteral:
+		return types.NewRegExp(n.Pattern, n.Flags)
+	case *ast.OptionalChain:
+		value, err := e.executeNode(n.Expression, context)
+		if errors.Is(err, errOptionalChainNullish) {
+			return nil, nil
+		}
+		return value, err
+	case *ast.Optional:
+		value, err := e.executeNode(n.Expression, context)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, errOptionalChainNullish
+		}
+		return value, nil
 	default:
 		return nil, fmt.Errorf("unsupported node type: %T", node)
 	}
@@ -250,6 +276,15 @@ func (e *ASTExecutor) executeIdentifier(node *ast.Identifier, context *types.Exp
 		switch name {
 		case "item":
 			return context.Item, nil
+		case "$node":
+			if context.Nodes == nil {
+				return nil, nil
+			}
+			return context.Nodes, nil
+		case "$execution":
+			return context.Execution, nil
+		case "$workflow":
+			return context.Workflow, nil
 		default:
 			if context.Variables != nil {
 				if value, exists := context.Variables[name]; exists {
@@ -325,6 +360,14 @@ func (e *ASTExecutor) executeBracketExpression(node *ast.BracketExpression, cont
 
 // executeCallExpression executes function call expressions
 func (e *ASTExecutor) executeCallExpression(node *ast.CallExpression, context *types.ExpressionContext) (interface{}, error) {
+	if optional, ok := node.Callee.(*ast.Optional); ok {
+		// obj.method?.() is called like obj.method(), registered methods always exist
+		return e.executeCallExpression(&ast.CallExpression{
+			Callee:       optional.Expression,
+			ArgumentList: node.ArgumentList,
+		}, context)
+	}
+
 	if ident, ok := node.Callee.(*ast.Identifier); ok {
 		// Direct function call: func()
 		args := make([]interface{}, len(node.ArgumentList))
@@ -564,20 +607,40 @@ func (e *ASTExecutor) executeUnaryExpression(node *ast.UnaryExpression, context
 	}
 }
 
-// executeArrayLiteral executes array literals ([1, 2, 3])
+// executeArrayLiteral executes array literals ([1, 2, 3], [...items, 4])
 func (e *ASTExecutor) executeArrayLiteral(node *ast.ArrayLiteral, context *types.ExpressionContext) (interface{}, error) {
-	result := make([]interface{}, len(node.Value))
+	result := make([]interface{}, 0, len(node.Value))
+
+	for _, element := range node.Value {
+		if element == nil {
+			result = append(result, nil)
+			continue
+		}
 
-	for i, element := range node.Value {
-		if element != nil {
-			val, err := e.executeNode(element, context)
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			val, err := e.executeNode(spread.Expression, context)
 			if err != nil {
 				return nil, err
 			}
-			result[i] = val
-		} else {
-			result[i] = nil
+
+			switch v := val.(type) {
+			case []interface{}:
+				result = append(result, v...)
+			case string:
+				for _, char := range v {
+					result = append(result, string(char))
+				}
+			default:
+				return nil, fmt.Errorf("cannot spread %s into an array", e.converter.GetJavaScriptType(val))
+			}
+			continue
 		}
+
+		val, err := e.executeNode(element, context)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, val)
 	}
 
 	return result, nil
@@ -590,6 +653,33 @@ func (e *ASTExecutor) executeObjectLiteral(node *ast.ObjectLiteral, context *typ
 	for _, prop := range node.Value {
 		var key string
 
+		switch p := prop.(type) {
+		case *ast.SpreadElement:
+			val, err := e.executeNode(p.Expression, context)
+			if err != nil {
+				return nil, err
+			}
+
+			switch v := val.(type) {
+			case map[string]interface{}:
+				for k, item := range v {
+					result[k] = item
+				}
+			case []interface{}:
+				for i, item := range v {
+					result[strconv.Itoa(i)] = item
+				}
+			}
+			continue
+		case *ast.PropertyShort:
+			val, err := e.executeIdentifier(&p.Name, context)
+			if err != nil {
+				return nil, err
+			}
+			result[p.Name.Name.String()] = val
+			continue
+		}
+
 		// Cast to PropertyKeyed to access Key and Value
 		property, ok := prop.(*ast.PropertyKeyed)
 		if !ok {
@@ -623,6 +713,49 @@ func (e *ASTExecutor) executeObjectLiteral(node *ast.ObjectLiteral, context *typ
 	return result, nil
 }
 
+// executeTemplateLiteral executes template literals (`Hello ${item.name}`)
+func (e *ASTExecutor) executeTemplateLiteral(node *ast.TemplateLiteral, context *types.ExpressionContext) (interface{}, error) {
+	if node.Tag != nil {
+		return nil, fmt.Errorf("tagged templates are not supported")
+	}
+
+	var builder strings.Builder
+
+	for i, element := range node.Elements {
+		builder.WriteString(element.Parsed.String())
+
+		if i < len(node.Expressions) {
+			val, err := e.executeNode(node.Expressions[i], context)
+			if err != nil {
+				return nil, err
+			}
+			builder.WriteString(e.converter.ToString(val))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// executeBlockBody executes block bodied arrow functions (x => { return x * 2 }), the
+// body may only hold a return statement as expressions can not declare variables.
+func (e *ASTExecutor) executeBlockBody(node *ast.BlockStatement, context *types.ExpressionContext) (interface{}, error) {
+	for _, statement := range node.List {
+		switch s := statement.(type) {
+		case *ast.EmptyStatement:
+			continue
+		case *ast.ReturnStatement:
+			if s.Argument == nil {
+				return nil, nil
+			}
+			return e.executeNode(s.Argument, context)
+		default:
+			return nil, fmt.Errorf("arrow function bodies can only contain a return statement")
+		}
+	}
+
+	return nil, nil
+}
+
 // executeFunction executes a registered function
 func (e *ASTExecutor) executeFunction(name string, args []interface{}, context *types.ExpressionContext) (interface{}, error) {
 	fn, ok := e.functionRegistry.Get(name)
@@ -697,6 +830,17 @@ func (e *ASTExecutor) propertyAccess(object, property interface{}) (interface{},
 	case map[string]interface{}:
 		propStr := e.converter.ToString(property)
 		result = obj[propStr]
+	case types.PropertyResolver:
+		return obj.ResolveProperty(e.converter.ToString(property))
+	case *types.RegExp:
+		switch e.converter.ToString(property) {
+		case "source":
+			result = obj.Source
+		case "flags":
+			result = obj.Flags
+		case "global":
+			result = obj.Global
+		}
 	case string:
 		propStr := e.converter.ToString(property)
 		if propStr == "length" {
@@ -840,7 +984,7 @@ func (e *ASTExecutor) extractAccessedVariables(node ast.Node, context *types.Exp
 			name := ident.Name.String()
 			if context != nil {
 				switch name {
-				case "item":
+				case "item", "$node", "$execution", "$workflow":
 					variables[name] = true
 				default:
 					if context.Variables != nil {
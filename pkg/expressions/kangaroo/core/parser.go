@@ -416,24 +416,42 @@ func (p *ASTParser) walkAST(node ast.Node, visitor func(ast.Node, int), depth in
 		}
 	case *ast.ObjectLiteral:
 		for _, prop := range n.Value {
-			if keyedProp, ok := prop.(*ast.PropertyKeyed); ok {
-				p.walkAST(keyedProp.Key, visitor, depth+1)
-				p.walkAST(keyedProp.Value, visitor, depth+1)
+			switch property := prop.(type) {
+			case *ast.PropertyKeyed:
+				p.walkAST(property.Key, visitor, depth+1)
+				p.walkAST(property.Value, visitor, depth+1)
+			case *ast.PropertyShort:
+				p.walkAST(&property.Name, visitor, depth+1)
+			case *ast.SpreadElement:
+				p.walkAST(property.Expression, visitor, depth+1)
 			}
 		}
 	case *ast.UnaryExpression:
 		p.walkAST(n.Operand, visitor, depth+1)
+	case *ast.TemplateLiteral:
+		for _, expr := range n.Expressions {
+			p.walkAST(expr, visitor, depth+1)
+		}
+	case *ast.OptionalChain:
+		p.walkAST(n.Expression, visitor, depth+1)
+	case *ast.Optional:
+		p.walkAST(n.Expression, visitor, depth+1)
+	case *ast.SpreadElement:
+		p.walkAST(n.Expression, visitor, depth+1)
 	}
 }
 
 // isContextVariable checks if identifier is a context variable
 func (p *ASTParser) isContextVariable(name string) bool {
 	contextVars := map[string]bool{
-		"item":      true,
-		"inputs":    true,
-		"outputs":   true,
-		"node":      true,
-		"execution": true,
+		"item":       true,
+		"inputs":     true,
+		"outputs":    true,
+		"node":       true,
+		"execution":  true,
+		"$node":      true,
+		"$execution": true,
+		"$workflow":  true,
 		// Built-in constants
 		"true":      false,
 		"false":     false,
@@ -538,6 +556,16 @@ func getNodeTypeName(node ast.Node) string {
 		return "ArrowFunctionLiteral"
 	case *ast.ExpressionBody:
 		return "ExpressionBody"
+	case *ast.TemplateLiteral:
+		return "TemplateLiteral"
+	case *ast.RegExpLiteral:
+		return "RegExpLiteral"
+	case *ast.OptionalChain:
+		return "OptionalChain"
+	case *ast.Optional:
+		return "Optional"
+	case *ast.SpreadElement:
+		return "SpreadElement"
 	default:
 		return "Unknown"
 	}
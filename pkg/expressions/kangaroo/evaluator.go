@@ -117,7 +117,7 @@ func (k *Kangaroo) Evaluate(expression string, context *types.ExpressionContext)
 		}
 
 		// If it failed due to syntax error and looks like plain text (not JavaScript), treat as literal string
-		if !result.Success && result.ErrorType == types.ErrorTypeSyntax && k.looksLikePlainText(trimmed) {
+		if !result.Success && result.ErrorType == types.ErrorTypeSyntax && !k.options.StrictTemplates && k.looksLikePlainText(trimmed) {
 			return &types.EvaluationResult{
 				Success: true,
 				Value:   trimmed,
@@ -251,9 +251,18 @@ func (k *Kangaroo) ClearCaches() {
 func (k *Kangaroo) evaluateTemplate(template string, context *types.ExpressionContext) (*types.EvaluationResult, error) {
 	var processedExpressions []types.ProcessedExpression
 
+	// Expressions are replaced from the end of the template, so the failure that is kept
+	// is the one of the first failing expression
+	var failure *types.EvaluationResult
+
 	result := core.ReplaceTemplateExpressions(template, func(expression string, match *types.TemplateMatch) string {
 		evalResult, err := k.evaluateExpression(expression, context)
 		if err != nil {
+			failure = &types.EvaluationResult{
+				Success:   false,
+				Error:     fmt.Sprintf("expression '%s' failed: %s", expression, err.Error()),
+				ErrorType: types.ErrorTypeRuntime,
+			}
 			return fmt.Sprintf("[ERROR: %s]", err.Error())
 		}
 
@@ -265,6 +274,11 @@ func (k *Kangaroo) evaluateTemplate(template string, context *types.ExpressionCo
 		})
 
 		if !evalResult.Success {
+			failure = &types.EvaluationResult{
+				Success:   false,
+				Error:     fmt.Sprintf("expression '%s' failed: %s", expression, evalResult.Error),
+				ErrorType: evalResult.ErrorType,
+			}
 			return fmt.Sprintf("[ERROR: %s]", evalResult.Error)
 		}
 
@@ -275,6 +289,10 @@ func (k *Kangaroo) evaluateTemplate(template string, context *types.ExpressionCo
 		return k.toString(evalResult.Value)
 	})
 
+	if failure != nil && k.options.StrictTemplates {
+		return failure, nil
+	}
+
 	return &types.EvaluationResult{
 		Success: true,
 		Value:   result,
@@ -0,0 +1,433 @@
+package functions
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
+)
+
+const (
+	// maxRandomLength limits the size of random bytes and strings
+	maxRandomLength = 4096
+
+	alphanumericAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+	// crockfordAlphabet is the base32 alphabet of ULIDs
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// registerHashFunctions registers digest, HMAC, random and identifier functions. Digests
+// are hex encoded unless "base64" or "base64url" is given as encoding.
+func (r *DefaultFunctionRegistry) registerHashFunctions() {
+	digestFunctions := []struct {
+		name      string
+		algorithm string
+	}{
+		{"Crypto.md5", "md5"},
+		{"Crypto.sha1", "sha1"},
+		{"Crypto.sha256", "sha256"},
+		{"Crypto.sha512", "sha512"},
+	}
+
+	for _, digest := range digestFunctions {
+		algorithm := digest.algorithm
+
+		r.Register(&types.SafeFunction{
+			Name:        digest.name,
+			Description: fmt.Sprintf("%s digest of a string, hex encoded by default", strings.ToUpper(algorithm)),
+			Category:    types.CategoryCrypto,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{fmt.Sprintf(`%s(item.body, "base64")`, digest.name)},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.digest(algorithm, args[0], args[1:])
+			},
+		})
+	}
+
+	hashFunctions := []*types.SafeFunction{
+		{
+			Name:        "Crypto.hash",
+			Description: "Digest of a string with md5, sha1, sha256 or sha512, hex encoded by default",
+			Category:    types.CategoryCrypto,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Crypto.hash("sha256", item.body)`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.digest(r.converter.ToString(args[0]), args[1], args[2:])
+			},
+		},
+		{
+			Name:        "Crypto.hmac",
+			Description: "HMAC of a string with a key using md5, sha1, sha256 or sha512, hex encoded by default. The key is used as text unless its encoding is given, so that the output of another HMAC can be the key",
+			Category:    types.CategoryCrypto,
+			MinArgs:     3,
+			MaxArgs:     5,
+			Examples: []string{
+				`Crypto.hmac("sha256", item.secret, item.timestamp + "." + JSON.stringify(item.body))`,
+				`Crypto.hmac("sha256", Crypto.hmac("sha256", "AWS4" + item.secretKey, item.date), item.region, "hex", "hex")`,
+			},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				newHash, err := hashByName(r.converter.ToString(args[0]))
+				if err != nil {
+					return nil, err
+				}
+
+				key, err := decodeKey(r.converter.ToString(args[1]), r.keyEncodingArg(args, 4))
+				if err != nil {
+					return nil, err
+				}
+
+				mac := hmac.New(newHash, key)
+				mac.Write([]byte(r.converter.ToString(args[2])))
+
+				return encodeBytes(mac.Sum(nil), r.encodingArg(args, 3))
+			},
+		},
+		{
+			Name:        "Crypto.timingSafeEqual",
+			Description: "Compare two strings in constant time, for signatures and tokens",
+			Category:    types.CategoryCrypto,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Examples:    []string{`Crypto.timingSafeEqual(item.headers["x-signature"], Crypto.hmac("sha256", item.secret, item.rawBody))`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				a := []byte(r.converter.ToString(args[0]))
+				b := []byte(r.converter.ToString(args[1]))
+
+				return subtle.ConstantTimeCompare(a, b) == 1, nil
+			},
+		},
+		{
+			Name:        "Crypto.randomBytes",
+			Description: "Cryptographically secure random bytes, hex encoded by default",
+			Category:    types.CategoryCrypto,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{`Crypto.randomBytes(16, "base64url")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				length := r.converter.ToInt(args[0])
+				if length < 1 || length > maxRandomLength {
+					return nil, fmt.Errorf("length must be between 1 and %d", maxRandomLength)
+				}
+
+				data := make([]byte, length)
+				if _, err := rand.Read(data); err != nil {
+					return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+				}
+
+				return encodeBytes(data, r.encodingArg(args, 1))
+			},
+		},
+		{
+			Name:        "Crypto.randomString",
+			Description: "Cryptographically secure random string of letters and digits, or of the given alphabet",
+			Category:    types.CategoryCrypto,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{`Crypto.randomString(32)`, `Crypto.randomString(6, "0123456789")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				length := r.converter.ToInt(args[0])
+				if length < 1 || length > maxRandomLength {
+					return nil, fmt.Errorf("length must be between 1 and %d", maxRandomLength)
+				}
+
+				alphabet := []rune(alphanumericAlphabet)
+				if len(args) > 1 && args[1] != nil {
+					alphabet = []rune(r.converter.ToString(args[1]))
+				}
+
+				if len(alphabet) < 2 {
+					return nil, fmt.Errorf("alphabet must have at least 2 characters")
+				}
+
+				result := make([]rune, length)
+				alphabetSize := big.NewInt(int64(len(alphabet)))
+
+				for i := range result {
+					index, err := rand.Int(rand.Reader, alphabetSize)
+					if err != nil {
+						return nil, fmt.Errorf("failed to generate random string: %w", err)
+					}
+
+					result[i] = alphabet[index.Int64()]
+				}
+
+				return string(result), nil
+			},
+		},
+		{
+			Name:        "Crypto.uuidv7",
+			Description: "Generate a time ordered UUID v7",
+			Category:    types.CategoryCrypto,
+			MinArgs:     0,
+			MaxArgs:     0,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				id, err := uuid.NewV7()
+				if err != nil {
+					return nil, fmt.Errorf("failed to generate uuid: %w", err)
+				}
+
+				return id.String(), nil
+			},
+		},
+		{
+			Name:        "Crypto.ulid",
+			Description: "Generate a lexicographically sortable ULID",
+			Category:    types.CategoryCrypto,
+			MinArgs:     0,
+			MaxArgs:     0,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return newULID(time.Now())
+			},
+		},
+	}
+
+	for _, fn := range hashFunctions {
+		r.Register(fn)
+	}
+}
+
+// registerEncodingFunctions registers URL, hex and URL safe base64 encoding functions
+func (r *DefaultFunctionRegistry) registerEncodingFunctions() {
+	encodingFunctions := []*types.SafeFunction{
+		{
+			Name:        "Crypto.urlEncode",
+			Description: "Percent-encode a string for URLs, every character except A-Z a-z 0-9 - _ . ~ is encoded (RFC 3986)",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Examples:    []string{`"https://example.com/search?q=" + Crypto.urlEncode(item.query)`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return percentEncode(r.converter.ToString(args[0])), nil
+			},
+		},
+		{
+			Name:        "Crypto.urlDecode",
+			Description: "Decode a percent-encoded string, + is decoded as a space",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				decoded, err := url.QueryUnescape(r.converter.ToString(args[0]))
+				if err != nil {
+					return nil, fmt.Errorf("failed to url decode: %w", err)
+				}
+
+				return decoded, nil
+			},
+		},
+		{
+			Name:        "Crypto.hexEncode",
+			Description: "Hex encode string",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return hex.EncodeToString([]byte(r.converter.ToString(args[0]))), nil
+			},
+		},
+		{
+			Name:        "Crypto.hexDecode",
+			Description: "Hex decode string",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				decoded, err := hex.DecodeString(r.converter.ToString(args[0]))
+				if err != nil {
+					return nil, fmt.Errorf("failed to hex decode: %w", err)
+				}
+
+				return string(decoded), nil
+			},
+		},
+		{
+			Name:        "Crypto.base64UrlEncode",
+			Description: "URL safe base64 encode string without padding",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return base64.RawURLEncoding.EncodeToString([]byte(r.converter.ToString(args[0]))), nil
+			},
+		},
+		{
+			Name:        "Crypto.base64UrlDecode",
+			Description: "URL safe base64 decode string, with or without padding",
+			Category:    types.CategoryEncoding,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				str := strings.TrimRight(r.converter.ToString(args[0]), "=")
+
+				decoded, err := base64.RawURLEncoding.DecodeString(str)
+				if err != nil {
+					return nil, fmt.Errorf("failed to base64url decode: %w", err)
+				}
+
+				return string(decoded), nil
+			},
+		},
+	}
+
+	for _, fn := range encodingFunctions {
+		r.Register(fn)
+	}
+}
+
+func (r *DefaultFunctionRegistry) digest(algorithm string, value interface{}, rest []interface{}) (interface{}, error) {
+	newHash, err := hashByName(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHash()
+	h.Write([]byte(r.converter.ToString(value)))
+
+	return encodeBytes(h.Sum(nil), r.encodingArg(rest, 0))
+}
+
+// encodingArg reads the optional output encoding at index, hex when it is missing
+func (r *DefaultFunctionRegistry) encodingArg(args []interface{}, index int) string {
+	if index >= len(args) || args[index] == nil {
+		return "hex"
+	}
+
+	return r.converter.ToString(args[index])
+}
+
+// keyEncodingArg reads the optional key encoding at index, text when it is missing
+func (r *DefaultFunctionRegistry) keyEncodingArg(args []interface{}, index int) string {
+	if index >= len(args) || args[index] == nil {
+		return "text"
+	}
+
+	return r.converter.ToString(args[index])
+}
+
+func hashByName(algorithm string) (func() hash.Hash, error) {
+	switch strings.ToLower(strings.ReplaceAll(algorithm, "-", "")) {
+	case "md5":
+		return md5.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm %q", algorithm)
+	}
+}
+
+func encodeBytes(data []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", "hex":
+		return hex.EncodeToString(data), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(data), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q, expected hex, base64 or base64url", encoding)
+	}
+}
+
+func decodeKey(key string, encoding string) ([]byte, error) {
+	var decoded []byte
+	var err error
+
+	switch strings.ToLower(encoding) {
+	case "", "text", "utf8":
+		return []byte(key), nil
+	case "hex":
+		decoded, err = hex.DecodeString(key)
+	case "base64":
+		decoded, err = base64.StdEncoding.DecodeString(key)
+	case "base64url":
+		decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+	default:
+		return nil, fmt.Errorf("unsupported key encoding %q, expected text, hex, base64 or base64url", encoding)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s key: %w", encoding, err)
+	}
+
+	return decoded, nil
+}
+
+// percentEncode encodes every byte except the unreserved characters of RFC 3986, as
+// request signing schemes like OAuth 1.0 and AWS SigV4 expect.
+func percentEncode(s string) string {
+	const upperHex = "0123456789ABCDEF"
+
+	var builder strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			builder.WriteByte(c)
+			continue
+		}
+
+		builder.WriteByte('%')
+		builder.WriteByte(upperHex[c>>4])
+		builder.WriteByte(upperHex[c&15])
+	}
+
+	return builder.String()
+}
+
+// newULID returns a ULID, a 48 bit millisecond timestamp followed by 80 random bits in
+// Crockford's base32.
+func newULID(t time.Time) (string, error) {
+	var data [16]byte
+
+	var timestamp [8]byte
+	binary.BigEndian.PutUint64(timestamp[:], uint64(t.UnixMilli()))
+	copy(data[:6], timestamp[2:])
+
+	if _, err := rand.Read(data[6:]); err != nil {
+		return "", fmt.Errorf("failed to generate ulid: %w", err)
+	}
+
+	// 26 characters hold 130 bits, the first two bits are always zero
+	encoded := make([]byte, 26)
+
+	for i := range encoded {
+		var value byte
+
+		for bit := 0; bit < 5; bit++ {
+			position := i*5 + bit - 2
+
+			value <<= 1
+			if position >= 0 && data[position/8]&(0x80>>(position%8)) != 0 {
+				value |= 1
+			}
+		}
+
+		encoded[i] = crockfordAlphabet[value]
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,1170 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // The executor image has no zoneinfo, so the zones are embedded
+
+	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
+)
+
+// isoLayout is the layout dates are returned in, ISO 8601 with milliseconds and the
+// offset of the zone the date is in.
+const isoLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// maxBusinessDaySpan limits the days business day functions walk through.
+const maxBusinessDaySpan = 100000
+
+// dateInputLayouts are tried in order for date strings. Strings without an offset are
+// wall times in the zone given to the function, or UTC.
+var dateInputLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// formatTokens are the pattern tokens of Date.format and Date.fromFormat, longest first
+// so that e.g. MMMM is not read as MM twice. Text in [brackets] is not replaced.
+var formatTokens = []string{
+	"YYYY", "GGGG", "MMMM", "dddd", "MMM", "ddd", "SSS",
+	"YY", "MM", "DD", "HH", "hh", "mm", "ss", "ZZ", "WW",
+	"M", "D", "d", "E", "Q", "H", "h", "m", "s", "A", "a", "Z", "z", "W", "X", "x",
+}
+
+// parseLayoutByToken maps pattern tokens to Go layouts for Date.fromFormat.
+var parseLayoutByToken = map[string]string{
+	"YYYY": "2006", "YY": "06",
+	"MMMM": "January", "MMM": "Jan", "MM": "01", "M": "1",
+	"DD": "02", "D": "2",
+	"dddd": "Monday", "ddd": "Mon",
+	"HH": "15", "H": "15", "hh": "03", "h": "3",
+	"mm": "04", "m": "4", "ss": "05", "s": "5", "SSS": "000",
+	"A": "PM", "a": "pm",
+	"Z": "Z07:00", "ZZ": "Z0700", "z": "MST",
+}
+
+var weekdaysByName = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// registerDateTimeFunctions registers the timezone aware date functions. Dates are
+// accepted as ISO strings or milliseconds since the epoch and returned as ISO strings,
+// the optional timezone argument is an IANA zone like "Europe/Istanbul".
+func (r *DefaultFunctionRegistry) registerDateTimeFunctions() {
+	dateTimeFunctions := []*types.SafeFunction{
+		{
+			Name:        "Date.nowISO",
+			Description: "Current date as ISO string, in the given timezone or UTC",
+			Category:    types.CategoryDate,
+			MinArgs:     0,
+			MaxArgs:     1,
+			Examples:    []string{`Date.nowISO("Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				loc, err := r.locationArg(args, 0)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(time.Now().In(orUTC(loc))), nil
+			},
+		},
+		{
+			Name:        "Date.toISO",
+			Description: "Convert a date to an ISO string, optionally in a timezone",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{`Date.toISO(1767250800000, "America/New_York")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(t), nil
+			},
+		},
+		{
+			Name:        "Date.toMillis",
+			Description: "Convert a date to milliseconds since the epoch",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     1,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.toTime(args[0], nil)
+				if err != nil {
+					return nil, err
+				}
+
+				return float64(t.UnixMilli()), nil
+			},
+		},
+		{
+			Name:        "Date.format",
+			Description: "Format a date with a pattern like YYYY-MM-DD HH:mm, optionally in a timezone",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.format(item.createdAt, "dddd, D MMMM YYYY [at] HH:mm", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatPattern(t, r.converter.ToString(args[1])), nil
+			},
+		},
+		{
+			Name:        "Date.fromFormat",
+			Description: "Parse a date string with a pattern like DD.MM.YYYY HH:mm, optionally as wall time in a timezone",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.fromFormat("02.03.2026 09:00", "DD.MM.YYYY HH:mm", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				loc, err := r.locationArg(args, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				layout, err := parseLayout(r.converter.ToString(args[1]))
+				if err != nil {
+					return nil, err
+				}
+
+				t, err := time.ParseInLocation(layout, strings.TrimSpace(r.converter.ToString(args[0])), orUTC(loc))
+				if err != nil {
+					return nil, fmt.Errorf("date %q does not match the pattern: %w", r.converter.ToString(args[0]), err)
+				}
+
+				if loc != nil {
+					t = t.In(loc)
+				}
+
+				return formatISO(t), nil
+			},
+		},
+		{
+			Name:        "Date.add",
+			Description: "Add an amount of a unit (millisecond, second, minute, hour, day, week, month, quarter, year) to a date",
+			Category:    types.CategoryDate,
+			MinArgs:     3,
+			MaxArgs:     4,
+			Examples:    []string{`Date.add(item.dueAt, 2, "weeks")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.addToDate(args, 1)
+			},
+		},
+		{
+			Name:        "Date.subtract",
+			Description: "Subtract an amount of a unit (millisecond, second, minute, hour, day, week, month, quarter, year) from a date",
+			Category:    types.CategoryDate,
+			MinArgs:     3,
+			MaxArgs:     4,
+			Examples:    []string{`Date.subtract(Date.now(), 1, "month")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.addToDate(args, -1)
+			},
+		},
+		{
+			Name:        "Date.startOf",
+			Description: "Start of the second, minute, hour, day, week (Monday), month, quarter or year of a date",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.startOf(Date.now(), "week", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				start, err := startOf(t, r.converter.ToString(args[1]))
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(start), nil
+			},
+		},
+		{
+			Name:        "Date.endOf",
+			Description: "Last millisecond of the second, minute, hour, day, week (Sunday), month, quarter or year of a date",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.endOf(Date.now(), "month", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				end, err := endOf(t, r.converter.ToString(args[1]))
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(end), nil
+			},
+		},
+		{
+			Name:        "Date.set",
+			Description: "Set parts of a date from an object with year, month, day, hour, minute, second and millisecond",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.set(Date.now(), {hour: 9, minute: 0, second: 0, millisecond: 0}, "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				values := r.converter.ToMap(args[1])
+				if values == nil {
+					return nil, fmt.Errorf("Date.set requires an object of date parts")
+				}
+
+				parts := map[string]int{
+					"year": t.Year(), "month": int(t.Month()), "day": t.Day(),
+					"hour": t.Hour(), "minute": t.Minute(), "second": t.Second(),
+					"millisecond": t.Nanosecond() / int(time.Millisecond),
+				}
+
+				for key, value := range values {
+					if _, ok := parts[key]; !ok {
+						return nil, fmt.Errorf("unknown date part %q", key)
+					}
+
+					parts[key] = r.converter.ToInt(value)
+				}
+
+				return formatISO(time.Date(
+					parts["year"], time.Month(parts["month"]), parts["day"],
+					parts["hour"], parts["minute"], parts["second"], parts["millisecond"]*int(time.Millisecond),
+					t.Location(),
+				)), nil
+			},
+		},
+		{
+			Name:        "Date.toZone",
+			Description: "Convert a date to a timezone, keeping the instant",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Examples:    []string{`Date.toZone("2026-03-02T09:00:00Z", "Asia/Tokyo")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(t), nil
+			},
+		},
+		{
+			Name:        "Date.setZone",
+			Description: "Move a date to a timezone, keeping the wall clock time",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Examples:    []string{`Date.setZone("2026-03-02T09:00:00Z", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.toTime(args[0], nil)
+				if err != nil {
+					return nil, err
+				}
+
+				loc, err := r.locationArg(args, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatISO(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), orUTC(loc))), nil
+			},
+		},
+		{
+			Name:        "Date.isoWeek",
+			Description: "ISO 8601 week number of a date",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				_, week := t.ISOWeek()
+				return float64(week), nil
+			},
+		},
+		{
+			Name:        "Date.weekday",
+			Description: "ISO weekday of a date, 1 for Monday to 7 for Sunday",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				return float64(isoWeekday(t)), nil
+			},
+		},
+		{
+			Name:        "Date.previous",
+			Description: "The closest given weekday before a date, keeping the time of day",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.previous(Date.now(), "monday", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.moveToWeekday(args, -1)
+			},
+		},
+		{
+			Name:        "Date.next",
+			Description: "The closest given weekday after a date, keeping the time of day",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.next(Date.now(), "friday")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return r.moveToWeekday(args, 1)
+			},
+		},
+		{
+			Name:        "Date.diff",
+			Description: "Difference of date2 minus date1 in a unit, milliseconds by default. Months, quarters and years are whole",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     3,
+			Examples:    []string{`Date.diff(item.createdAt, Date.now(), "hours")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t1, err := r.toTime(args[0], nil)
+				if err != nil {
+					return nil, err
+				}
+
+				t2, err := r.toTime(args[1], nil)
+				if err != nil {
+					return nil, err
+				}
+
+				unit := "millisecond"
+				if len(args) > 2 && args[2] != nil {
+					unit = r.converter.ToString(args[2])
+				}
+
+				return dateDiff(t1, t2, unit)
+			},
+		},
+		{
+			Name:        "Date.compare",
+			Description: "Compare two dates, -1 when date1 is earlier, 1 when it is later and 0 when they are equal",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t1, t2, err := r.datePair(args)
+				if err != nil {
+					return nil, err
+				}
+
+				return float64(t1.Compare(t2)), nil
+			},
+		},
+		{
+			Name:        "Date.isBefore",
+			Description: "Check if date1 is before date2",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t1, t2, err := r.datePair(args)
+				if err != nil {
+					return nil, err
+				}
+
+				return t1.Before(t2), nil
+			},
+		},
+		{
+			Name:        "Date.isAfter",
+			Description: "Check if date1 is after date2",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t1, t2, err := r.datePair(args)
+				if err != nil {
+					return nil, err
+				}
+
+				return t1.After(t2), nil
+			},
+		},
+		{
+			Name:        "Date.isSame",
+			Description: "Check if two dates are equal, or in the same unit like day or month in an optional timezone",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     4,
+			Examples:    []string{`Date.isSame(item.createdAt, Date.now(), "day", "Europe/Istanbul")`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				loc, err := r.locationArg(args, 3)
+				if err != nil {
+					return nil, err
+				}
+
+				t1, err := r.toTime(args[0], orUTC(loc))
+				if err != nil {
+					return nil, err
+				}
+
+				t2, err := r.toTime(args[1], orUTC(loc))
+				if err != nil {
+					return nil, err
+				}
+
+				if len(args) < 3 || args[2] == nil {
+					return t1.Equal(t2), nil
+				}
+
+				start1, err := startOf(t1, r.converter.ToString(args[2]))
+				if err != nil {
+					return nil, err
+				}
+
+				start2, err := startOf(t2, r.converter.ToString(args[2]))
+				if err != nil {
+					return nil, err
+				}
+
+				return start1.Equal(start2), nil
+			},
+		},
+		{
+			Name:        "Date.isBusinessDay",
+			Description: "Check if a date is a weekday and not one of the holidays (YYYY-MM-DD)",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     3,
+			Examples:    []string{`Date.isBusinessDay(item.dueAt, "Europe/Istanbul", ["2026-04-23"])`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				return isBusinessDay(t, r.holidaysArg(args, 2)), nil
+			},
+		},
+		{
+			Name:        "Date.addBusinessDays",
+			Description: "Add business days to a date, skipping weekends and the holidays (YYYY-MM-DD)",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     4,
+			Examples:    []string{`Date.addBusinessDays(Date.now(), 3, "Europe/Istanbul", ["2026-04-23"])`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.dateArgs(args, 0, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				days := r.converter.ToInt(args[1])
+				if days > maxBusinessDaySpan || days < -maxBusinessDaySpan {
+					return nil, fmt.Errorf("can not add more than %d business days", maxBusinessDaySpan)
+				}
+
+				holidays := r.holidaysArg(args, 3)
+
+				step := 1
+				if days < 0 {
+					step = -1
+				}
+
+				for remaining := days * step; remaining > 0; {
+					t = t.AddDate(0, 0, step)
+
+					if isBusinessDay(t, holidays) {
+						remaining--
+					}
+				}
+
+				return formatISO(t), nil
+			},
+		},
+		{
+			Name:        "Date.diffBusinessDays",
+			Description: "Number of business days after date1 up to and including date2, negative when date2 is earlier",
+			Category:    types.CategoryDate,
+			MinArgs:     2,
+			MaxArgs:     4,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				loc, err := r.locationArg(args, 2)
+				if err != nil {
+					return nil, err
+				}
+
+				t1, err := r.toTime(args[0], orUTC(loc))
+				if err != nil {
+					return nil, err
+				}
+
+				t2, err := r.toTime(args[1], orUTC(loc))
+				if err != nil {
+					return nil, err
+				}
+
+				holidays := r.holidaysArg(args, 3)
+
+				day1, _ := startOf(t1, "day")
+				day2, _ := startOf(t2.In(t1.Location()), "day")
+
+				sign := 1
+				if day2.Before(day1) {
+					day1, day2 = day2, day1
+					sign = -1
+				}
+
+				count := 0
+				for day, i := day1.AddDate(0, 0, 1), 0; !day.After(day2); day, i = day.AddDate(0, 0, 1), i+1 {
+					if i >= maxBusinessDaySpan {
+						return nil, fmt.Errorf("dates are more than %d days apart", maxBusinessDaySpan)
+					}
+
+					if isBusinessDay(day, holidays) {
+						count++
+					}
+				}
+
+				return float64(sign * count), nil
+			},
+		},
+		{
+			Name:        "Date.humanizeDuration",
+			Description: "Describe milliseconds like \"2 days, 3 hours\" with at most the given number of units (2)",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{`Date.humanizeDuration(Date.diff(item.startedAt, item.endedAt))`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				milliseconds, err := r.converter.ToNumber(args[0])
+				if err != nil || math.IsNaN(milliseconds) {
+					return nil, fmt.Errorf("duration must be a number of milliseconds")
+				}
+
+				maxUnits := 2
+				if len(args) > 1 && args[1] != nil {
+					maxUnits = r.converter.ToInt(args[1])
+				}
+
+				return humanizeDuration(time.Duration(milliseconds)*time.Millisecond, maxUnits), nil
+			},
+		},
+		{
+			Name:        "Date.relative",
+			Description: "Describe a date relative to now or a base date, like \"3 days ago\" or \"in 2 hours\"",
+			Category:    types.CategoryDate,
+			MinArgs:     1,
+			MaxArgs:     2,
+			Examples:    []string{`Date.relative(item.createdAt)`},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				t, err := r.toTime(args[0], nil)
+				if err != nil {
+					return nil, err
+				}
+
+				base := time.Now()
+				if len(args) > 1 && args[1] != nil {
+					if base, err = r.toTime(args[1], nil); err != nil {
+						return nil, err
+					}
+				}
+
+				difference := t.Sub(base)
+				if difference > -45*time.Second && difference < 45*time.Second {
+					return "just now", nil
+				}
+
+				if difference < 0 {
+					return humanizeDuration(-difference, 1) + " ago", nil
+				}
+
+				return "in " + humanizeDuration(difference, 1), nil
+			},
+		},
+	}
+
+	for _, fn := range dateTimeFunctions {
+		r.Register(fn)
+	}
+}
+
+// dateArgs reads the date at dateIndex in the timezone at locationIndex, or in the
+// zone of the date when no timezone is given.
+func (r *DefaultFunctionRegistry) dateArgs(args []interface{}, dateIndex, locationIndex int) (time.Time, error) {
+	loc, err := r.locationArg(args, locationIndex)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return r.toTime(args[dateIndex], loc)
+}
+
+func (r *DefaultFunctionRegistry) datePair(args []interface{}) (time.Time, time.Time, error) {
+	t1, err := r.toTime(args[0], nil)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	t2, err := r.toTime(args[1], nil)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return t1, t2, nil
+}
+
+// toTime converts a date argument to a time. Numbers are milliseconds since the epoch
+// and strings without an offset are wall times in loc. The result is in loc, or in the
+// zone of the string when loc is nil.
+func (r *DefaultFunctionRegistry) toTime(value interface{}, loc *time.Location) (time.Time, error) {
+	var t time.Time
+
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, fmt.Errorf("date is required")
+	case time.Time:
+		t = v
+	case float64, int, int64, int32:
+		milliseconds, _ := r.converter.ToNumber(v)
+		if math.IsNaN(milliseconds) || math.IsInf(milliseconds, 0) {
+			return time.Time{}, fmt.Errorf("invalid date: %v", v)
+		}
+
+		t = time.UnixMilli(int64(milliseconds)).UTC()
+	default:
+		str := strings.TrimSpace(r.converter.ToString(v))
+
+		parsed := false
+		for _, layout := range dateInputLayouts {
+			if result, err := time.ParseInLocation(layout, str, orUTC(loc)); err == nil {
+				t = result
+				parsed = true
+				break
+			}
+		}
+
+		if !parsed {
+			return time.Time{}, fmt.Errorf("invalid date: %q", str)
+		}
+	}
+
+	if loc != nil {
+		t = t.In(loc)
+	}
+
+	return t, nil
+}
+
+// locationArg reads the optional timezone at index, nil when it is missing or empty.
+func (r *DefaultFunctionRegistry) locationArg(args []interface{}, index int) (*time.Location, error) {
+	if index >= len(args) || args[index] == nil {
+		return nil, nil
+	}
+
+	name := strings.TrimSpace(r.converter.ToString(args[index]))
+	if name == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+
+	return loc, nil
+}
+
+// holidaysArg reads the optional array of YYYY-MM-DD holidays at index.
+func (r *DefaultFunctionRegistry) holidaysArg(args []interface{}, index int) map[string]bool {
+	holidays := map[string]bool{}
+
+	if index >= len(args) || args[index] == nil {
+		return holidays
+	}
+
+	for _, holiday := range r.converter.ToArray(args[index]) {
+		holidays[r.converter.ToString(holiday)] = true
+	}
+
+	return holidays
+}
+
+func (r *DefaultFunctionRegistry) addToDate(args []interface{}, sign float64) (interface{}, error) {
+	t, err := r.dateArgs(args, 0, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := r.converter.ToNumber(args[1])
+	if err != nil || math.IsNaN(amount) {
+		return nil, fmt.Errorf("amount must be a number")
+	}
+
+	result, err := addToTime(t, sign*amount, r.converter.ToString(args[2]))
+	if err != nil {
+		return nil, err
+	}
+
+	return formatISO(result), nil
+}
+
+func (r *DefaultFunctionRegistry) moveToWeekday(args []interface{}, direction int) (interface{}, error) {
+	t, err := r.dateArgs(args, 0, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	weekday, err := r.toWeekday(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	t = t.AddDate(0, 0, direction)
+	for t.Weekday() != weekday {
+		t = t.AddDate(0, 0, direction)
+	}
+
+	return formatISO(t), nil
+}
+
+// toWeekday accepts weekday names like "monday" or "mon" and ISO numbers from 1 for
+// Monday to 7 for Sunday.
+func (r *DefaultFunctionRegistry) toWeekday(value interface{}) (time.Weekday, error) {
+	if number, err := r.converter.ToNumber(value); err == nil && number >= 1 && number <= 7 {
+		return time.Weekday(int(number) % 7), nil
+	}
+
+	weekday, ok := weekdaysByName[strings.ToLower(r.converter.ToString(value))]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday: %v", value)
+	}
+
+	return weekday, nil
+}
+
+func formatISO(t time.Time) string {
+	return t.Format(isoLayout)
+}
+
+func orUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// normalizeUnit accepts singular, plural and short unit names.
+func normalizeUnit(unit string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "ms", "millisecond", "milliseconds":
+		return "millisecond", nil
+	case "s", "sec", "second", "seconds":
+		return "second", nil
+	case "min", "minute", "minutes":
+		return "minute", nil
+	case "h", "hour", "hours":
+		return "hour", nil
+	case "d", "day", "days":
+		return "day", nil
+	case "w", "week", "weeks", "isoweek":
+		return "week", nil
+	case "month", "months":
+		return "month", nil
+	case "q", "quarter", "quarters":
+		return "quarter", nil
+	case "y", "year", "years":
+		return "year", nil
+	default:
+		return "", fmt.Errorf("unknown date unit %q", unit)
+	}
+}
+
+var durationByUnit = map[string]time.Duration{
+	"millisecond": time.Millisecond,
+	"second":      time.Second,
+	"minute":      time.Minute,
+	"hour":        time.Hour,
+}
+
+// addToTime adds amount units to t. Days and larger units are calendar units, so adding a
+// day across a daylight saving change keeps the wall clock time.
+func addToTime(t time.Time, amount float64, unit string) (time.Time, error) {
+	unit, err := normalizeUnit(unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if duration, ok := durationByUnit[unit]; ok {
+		return t.Add(time.Duration(amount * float64(duration))), nil
+	}
+
+	if amount != math.Trunc(amount) {
+		return time.Time{}, fmt.Errorf("amount of %ss must be a whole number", unit)
+	}
+
+	n := int(amount)
+
+	switch unit {
+	case "day":
+		return t.AddDate(0, 0, n), nil
+	case "week":
+		return t.AddDate(0, 0, 7*n), nil
+	case "month":
+		return addMonths(t, n), nil
+	case "quarter":
+		return addMonths(t, 3*n), nil
+	default:
+		return addMonths(t, 12*n), nil
+	}
+}
+
+// addMonths adds months to t and clamps the day to the end of the resulting month, so
+// January 31 plus one month is the last day of February.
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+
+	day := t.Day()
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func startOf(t time.Time, unit string) (time.Time, error) {
+	unit, err := normalizeUnit(unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	year, month, day := t.Date()
+	loc := t.Location()
+
+	switch unit {
+	case "millisecond":
+		return t.Truncate(time.Millisecond), nil
+	case "second":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	case "minute":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, loc), nil
+	case "hour":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc), nil
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case "week":
+		return time.Date(year, month, day-(isoWeekday(t)-1), 0, 0, 0, 0, loc), nil
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc), nil
+	case "quarter":
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, loc), nil
+	default:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+}
+
+func endOf(t time.Time, unit string) (time.Time, error) {
+	start, err := startOf(t, unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next, err := addToTime(start, 1, unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return next.Add(-time.Millisecond), nil
+}
+
+func dateDiff(t1, t2 time.Time, unit string) (interface{}, error) {
+	unit, err := normalizeUnit(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	difference := t2.Sub(t1)
+
+	switch unit {
+	case "millisecond":
+		return float64(difference.Milliseconds()), nil
+	case "second", "minute", "hour":
+		return difference.Seconds() / durationByUnit[unit].Seconds(), nil
+	case "day":
+		return difference.Hours() / 24, nil
+	case "week":
+		return difference.Hours() / (24 * 7), nil
+	case "month":
+		return float64(monthsBetween(t1, t2)), nil
+	case "quarter":
+		return float64(monthsBetween(t1, t2) / 3), nil
+	default:
+		return float64(monthsBetween(t1, t2) / 12), nil
+	}
+}
+
+// monthsBetween counts the whole months from t1 to t2.
+func monthsBetween(t1, t2 time.Time) int {
+	t2 = t2.In(t1.Location())
+
+	months := (t2.Year()-t1.Year())*12 + int(t2.Month()-t1.Month())
+
+	if months > 0 && addMonths(t1, months).After(t2) {
+		months--
+	}
+	if months < 0 && addMonths(t1, months).Before(t2) {
+		months++
+	}
+
+	return months
+}
+
+// isoWeekday returns 1 for Monday to 7 for Sunday.
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+
+	return int(t.Weekday())
+}
+
+func isBusinessDay(t time.Time, holidays map[string]bool) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	return !holidays[t.Format("2006-01-02")]
+}
+
+var humanizeUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// humanizeDuration describes d with at most maxUnits of the largest units, e.g.
+// "1 day, 2 hours". Months are 30 days and years are 365 days.
+func humanizeDuration(d time.Duration, maxUnits int) string {
+	if d < 0 {
+		d = -d
+	}
+	if maxUnits < 1 {
+		maxUnits = 1
+	}
+
+	parts := []string{}
+
+	for _, unit := range humanizeUnits {
+		if len(parts) == maxUnits {
+			break
+		}
+
+		count := d / unit.duration
+		if count == 0 {
+			if len(parts) > 0 {
+				break
+			}
+			continue
+		}
+
+		d -= count * unit.duration
+
+		name := unit.name
+		if count != 1 {
+			name += "s"
+		}
+
+		parts = append(parts, strconv.FormatInt(int64(count), 10)+" "+name)
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatPattern formats t with day.js style tokens, e.g. "YYYY-MM-DD HH:mm:ss Z".
+func formatPattern(t time.Time, pattern string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '[' {
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				builder.WriteString(pattern[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+
+		token := matchFormatToken(pattern[i:])
+		if token == "" {
+			builder.WriteByte(pattern[i])
+			i++
+			continue
+		}
+
+		builder.WriteString(formatToken(t, token))
+		i += len(token)
+	}
+
+	return builder.String()
+}
+
+func matchFormatToken(pattern string) string {
+	for _, token := range formatTokens {
+		if strings.HasPrefix(pattern, token) {
+			return token
+		}
+	}
+
+	return ""
+}
+
+func formatToken(t time.Time, token string) string {
+	isoYear, isoWeek := t.ISOWeek()
+
+	switch token {
+	case "YYYY":
+		return fmt.Sprintf("%04d", t.Year())
+	case "YY":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "GGGG":
+		return fmt.Sprintf("%04d", isoYear)
+	case "Q":
+		return strconv.Itoa((int(t.Month())-1)/3 + 1)
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "DD":
+		return fmt.Sprintf("%02d", t.Day())
+	case "D":
+		return strconv.Itoa(t.Day())
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "d":
+		return strconv.Itoa(int(t.Weekday()))
+	case "E":
+		return strconv.Itoa(isoWeekday(t))
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "hh":
+		return t.Format("03")
+	case "h":
+		return t.Format("3")
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+		return strconv.Itoa(t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+		return strconv.Itoa(t.Second())
+	case "SSS":
+		return fmt.Sprintf("%03d", t.Nanosecond()/int(time.Millisecond))
+	case "A":
+		return t.Format("PM")
+	case "a":
+		return t.Format("pm")
+	case "Z":
+		return t.Format("-07:00")
+	case "ZZ":
+		return t.Format("-0700")
+	case "z":
+		return t.Format("MST")
+	case "WW":
+		return fmt.Sprintf("%02d", isoWeek)
+	case "W":
+		return strconv.Itoa(isoWeek)
+	case "X":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "x":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return token
+	}
+}
+
+// parseLayout converts a day.js style pattern to a Go layout. Tokens that can not be
+// parsed, like week numbers, are rejected.
+func parseLayout(pattern string) (string, error) {
+	var builder strings.Builder
+
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '[' {
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				builder.WriteString(pattern[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+
+		token := matchFormatToken(pattern[i:])
+		if token == "" {
+			builder.WriteByte(pattern[i])
+			i++
+			continue
+		}
+
+		layout, ok := parseLayoutByToken[token]
+		if !ok {
+			return "", fmt.Errorf("pattern token %q can not be used to parse dates", token)
+		}
+
+		builder.WriteString(layout)
+		i += len(token)
+	}
+
+	return builder.String(), nil
+}
@@ -169,9 +169,12 @@ func (r *DefaultFunctionRegistry) registerDefaultFunctions() {
 	r.registerObjectFunctions()
 	r.registerMathFunctions()
 	r.registerDateFunctions()
+	r.registerDateTimeFunctions()
 	r.registerJsonFunctions()
 	r.registerWorkflowFunctions()
 	r.registerCryptoFunctions()
+	r.registerHashFunctions()
+	r.registerEncodingFunctions()
 	r.registerArrayUtilityFunctions()
 	r.registerStringUtilityFunctions()
 	r.registerConditionalFunctions()
@@ -270,12 +273,84 @@ func (r *DefaultFunctionRegistry) registerStringFunctions() {
 				}
 
 				str := r.converter.ToString(args[0])
-				search := r.converter.ToString(args[1])
 				replace := r.converter.ToString(args[2])
 
+				if re, ok := args[1].(*types.RegExp); ok {
+					template := jsReplacementTemplate(replace)
+
+					if re.Global {
+						return re.Regexp.ReplaceAllString(str, template), nil
+					}
+
+					match := re.Regexp.FindStringSubmatchIndex(str)
+					if match == nil {
+						return str, nil
+					}
+
+					return str[:match[0]] + string(re.Regexp.ExpandString(nil, template, str, match)) + str[match[1]:], nil
+				}
+
+				search := r.converter.ToString(args[1])
+
 				return strings.ReplaceAll(str, search, replace), nil
 			},
 		},
+		{
+			Name:        "match",
+			Description: "Matches string against a regular expression, returns the match and its groups or all matches with the g flag",
+			Category:    types.CategoryString,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				str := r.converter.ToString(args[0])
+
+				re, err := r.toRegExp(args[1])
+				if err != nil {
+					return nil, err
+				}
+
+				if re.Global {
+					matches := re.Regexp.FindAllString(str, -1)
+					if matches == nil {
+						return nil, nil
+					}
+
+					result := make([]interface{}, len(matches))
+					for i, match := range matches {
+						result[i] = match
+					}
+					return result, nil
+				}
+
+				match := re.Regexp.FindStringSubmatchIndex(str)
+				if match == nil {
+					return nil, nil
+				}
+
+				result := make([]interface{}, len(match)/2)
+				for i := range result {
+					if match[2*i] >= 0 {
+						result[i] = str[match[2*i]:match[2*i+1]]
+					}
+				}
+				return result, nil
+			},
+		},
+		{
+			Name:        "test",
+			Description: "Checks if a regular expression matches string",
+			Category:    types.CategoryString,
+			MinArgs:     2,
+			MaxArgs:     2,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				re, ok := args[0].(*types.RegExp)
+				if !ok {
+					return nil, fmt.Errorf("test can only be called on a regular expression")
+				}
+
+				return re.Regexp.MatchString(r.converter.ToString(args[1])), nil
+			},
+		},
 		{
 			Name:        "substring",
 			Description: "Extracts substring",
@@ -1551,3 +1626,52 @@ func flattenArray(arr []interface{}, depth int) []interface{} {
 
 	return result
 }
+
+// toRegExp accepts regular expressions and strings, strings are compiled like
+// JavaScript does for "abc".match("b+").
+func (r *DefaultFunctionRegistry) toRegExp(value interface{}) (*types.RegExp, error) {
+	if re, ok := value.(*types.RegExp); ok {
+		return re, nil
+	}
+
+	return types.NewRegExp(r.converter.ToString(value), "")
+}
+
+// jsReplacementTemplate converts the $1, $& and $<name> references of a JavaScript
+// replacement string to the ${1}, ${0} and ${name} references of regexp.Expand.
+func jsReplacementTemplate(replacement string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(replacement); i++ {
+		if replacement[i] != '$' || i+1 == len(replacement) {
+			builder.WriteByte(replacement[i])
+			continue
+		}
+
+		next := replacement[i+1]
+
+		switch {
+		case next == '&':
+			builder.WriteString("${0}")
+			i++
+		case next >= '0' && next <= '9':
+			end := i + 2
+			if end < len(replacement) && replacement[end] >= '0' && replacement[end] <= '9' {
+				end++
+			}
+			builder.WriteString("${" + replacement[i+1:end] + "}")
+			i = end - 1
+		case next == '<' && strings.IndexByte(replacement[i:], '>') > 0:
+			end := i + strings.IndexByte(replacement[i:], '>')
+			builder.WriteString("${" + replacement[i+2:end] + "}")
+			i = end
+		case next == '$':
+			builder.WriteString("$$")
+			i++
+		default:
+			builder.WriteString("$$")
+		}
+	}
+
+	return builder.String()
+}
@@ -20,6 +20,88 @@ type ExpressionContext struct {
 
 	// Variables for arrow function parameters in array operations
 	Variables map[string]interface{} `json:"variables,omitempty"`
+
+	// Outputs of previously executed nodes by node name or ID, exposed as $node
+	Nodes PropertyResolver `json:"-"`
+
+	// Execution and workflow metadata, exposed as $execution and $workflow
+	Execution map[string]interface{} `json:"execution,omitempty"`
+	Workflow  map[string]interface{} `json:"workflow,omitempty"`
+}
+
+// NewScope returns a copy of the context for the body of an arrow function, the
+// variables are copied so that parameters do not leak out of the function.
+func (c *ExpressionContext) NewScope() *ExpressionContext {
+	scope := &ExpressionContext{
+		Item:      c.Item,
+		Variables: make(map[string]interface{}, len(c.Variables)),
+		Nodes:     c.Nodes,
+		Execution: c.Execution,
+		Workflow:  c.Workflow,
+	}
+
+	for k, v := range c.Variables {
+		scope.Variables[k] = v
+	}
+
+	return scope
+}
+
+// PropertyResolver is an object whose properties are resolved when they are accessed,
+// for context values that are too expensive to build for every evaluation.
+type PropertyResolver interface {
+	ResolveProperty(name string) (interface{}, error)
+}
+
+// RegExp is the value of a regular expression literal like /^a+$/gi. Patterns use Go's
+// RE2 syntax, so lookarounds and backreferences are not supported.
+type RegExp struct {
+	Source string
+	Flags  string
+	Global bool
+	Regexp *regexp.Regexp
+}
+
+// NewRegExp compiles a regular expression literal, the i, m and s flags become inline
+// flags of the pattern and g makes match and replace work on every match.
+func NewRegExp(source, flags string) (*RegExp, error) {
+	inlineFlags := ""
+	global := false
+
+	for _, flag := range flags {
+		switch flag {
+		case 'g':
+			global = true
+		case 'i', 'm', 's':
+			inlineFlags += string(flag)
+		case 'u', 'd':
+			// Go patterns are always unicode aware and match indices are not exposed
+		default:
+			return nil, fmt.Errorf("unsupported regular expression flag %q", flag)
+		}
+	}
+
+	pattern := source
+	if inlineFlags != "" {
+		pattern = "(?" + inlineFlags + ")" + source
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression /%s/: %w", source, err)
+	}
+
+	return &RegExp{
+		Source: source,
+		Flags:  flags,
+		Global: global,
+		Regexp: compiled,
+	}, nil
+}
+
+// String returns the regular expression in literal notation
+func (r *RegExp) String() string {
+	return "/" + r.Source + "/" + r.Flags
 }
 
 // EvaluationResult represents the result of expression evaluation
@@ -63,6 +145,10 @@ type EvaluatorOptions struct {
 	EnableCaching   bool           `json:"enableCaching"`
 	MaxCacheSize    int            `json:"maxCacheSize"`
 	CollectMetrics  bool           `json:"collectMetrics"`
+
+	// StrictTemplates fails a template when one of its {{ }} expressions fails, instead
+	// of inlining "[ERROR: ...]", and no longer treats invalid syntax as plain text
+	StrictTemplates bool `json:"strictTemplates"`
 }
 
 // DefaultEvaluatorOptions returns default evaluator options
@@ -185,6 +271,7 @@ const (
 	CategoryUtility     = "utility"
 	CategoryConditional = "conditional"
 	CategoryCrypto      = "crypto"
+	CategoryEncoding    = "encoding"
 	CategoryWorkflow    = "workflow"
 )
 
@@ -3,12 +3,14 @@ package expressions
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/flowbaker/flowbaker/pkg/domain"
 	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo"
 	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
 	"github.com/rs/zerolog"
@@ -16,10 +18,11 @@ import (
 
 // KangarooBinder implements expression binding using local Kangaroo runtime
 type KangarooBinder struct {
-	evaluator      *kangaroo.Kangaroo
-	exprRegex      *regexp.Regexp
-	logger         zerolog.Logger
-	defaultTimeout time.Duration
+	evaluator       *kangaroo.Kangaroo
+	exprRegex       *regexp.Regexp
+	logger          zerolog.Logger
+	defaultTimeout  time.Duration
+	strictTemplates bool
 }
 
 // KangarooBinderOptions configures the local Kangaroo binder
@@ -27,6 +30,30 @@ type KangarooBinderOptions struct {
 	Logger          zerolog.Logger
 	DefaultTimeout  time.Duration
 	KangarooOptions *types.EvaluatorOptions
+
+	// StrictTemplates fails the binding with a TemplateError when an expression fails,
+	// it can also be enabled per workflow with the StrictTemplates workflow setting
+	StrictTemplates bool
+}
+
+// TemplateError is returned by strict template binding when a {{ }} expression fails,
+// Path is the property path of the setting the expression is in, like body.items[0].
+type TemplateError struct {
+	Path       string
+	Expression string
+	Err        error
+}
+
+func (e *TemplateError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("expression '%s' failed: %v", e.Expression, e.Err)
+	}
+
+	return fmt.Sprintf("expression '%s' at '%s' failed: %v", e.Expression, e.Path, e.Err)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
 }
 
 // DefaultLocalKangarooBinderOptions returns sensible defaults
@@ -46,19 +73,26 @@ func NewKangarooBinder(opts KangarooBinderOptions) (*KangarooBinder, error) {
 	if opts.KangarooOptions == nil {
 		opts.KangarooOptions = types.DefaultEvaluatorOptions()
 	}
+	if opts.StrictTemplates {
+		evaluatorOptions := *opts.KangarooOptions
+		evaluatorOptions.StrictTemplates = true
+		opts.KangarooOptions = &evaluatorOptions
+	}
 
 	// Create local Kangaroo evaluator
 	evaluator := kangaroo.NewKangaroo(opts.KangarooOptions)
 
 	binder := &KangarooBinder{
-		evaluator:      evaluator,
-		exprRegex:      regexp.MustCompile(`\{\{([\s\S]*?)\}\}`), // Use [\s\S] to match any character including newlines
-		logger:         opts.Logger,
-		defaultTimeout: opts.DefaultTimeout,
+		evaluator:       evaluator,
+		exprRegex:       regexp.MustCompile(`\{\{([\s\S]*?)\}\}`), // Use [\s\S] to match any character including newlines
+		logger:          opts.Logger,
+		defaultTimeout:  opts.DefaultTimeout,
+		strictTemplates: opts.StrictTemplates || opts.KangarooOptions.StrictTemplates,
 	}
 
 	opts.Logger.Info().
 		Dur("defaultTimeout", opts.DefaultTimeout).
+		Bool("strictTemplates", binder.strictTemplates).
 		Msg("Local Kangaroo binder initialized successfully")
 
 	return binder, nil
@@ -78,7 +112,7 @@ func (b *KangarooBinder) BindToStruct(ctx context.Context, item any, target any,
 	}
 
 	// Process expressions recursively
-	boundData, err := b.bindValue(ctx, item, userNodeSettings)
+	boundData, err := b.bindValue(ctx, item, "", userNodeSettings)
 	if err != nil {
 		return fmt.Errorf("binding failed: %w", err)
 	}
@@ -98,11 +132,11 @@ func (b *KangarooBinder) BindToStruct(ctx context.Context, item any, target any,
 
 // BindString processes a string that may contain expressions and returns the result
 func (b *KangarooBinder) BindString(ctx context.Context, item any, str string) (any, error) {
-	return b.bindString(ctx, item, str)
+	return b.bindString(ctx, item, "", str)
 }
 
 func (b *KangarooBinder) BindValue(ctx context.Context, item any, value any) (any, error) {
-	boundData, err := b.bindValue(ctx, item, value)
+	boundData, err := b.bindValue(ctx, item, "", value)
 	if err != nil {
 		return nil, fmt.Errorf("binding failed: %w", err)
 	}
@@ -145,15 +179,16 @@ func (b *KangarooBinder) validateInputs(target any, settings map[string]any) err
 	return nil
 }
 
-// bindValue recursively processes values and binds expressions
-func (b *KangarooBinder) bindValue(ctx context.Context, item any, value any) (any, error) {
+// bindValue recursively processes values and binds expressions, path is the property
+// path of value in the settings
+func (b *KangarooBinder) bindValue(ctx context.Context, item any, path string, value any) (any, error) {
 	switch v := value.(type) {
 	case string:
-		return b.bindString(ctx, item, v)
+		return b.bindString(ctx, item, path, v)
 	case map[string]any:
-		return b.bindMap(ctx, item, v)
+		return b.bindMap(ctx, item, path, v)
 	case []any:
-		return b.bindSlice(ctx, item, v)
+		return b.bindSlice(ctx, item, path, v)
 	default:
 		// Return non-string values as-is
 		return value, nil
@@ -161,12 +196,16 @@ func (b *KangarooBinder) bindValue(ctx context.Context, item any, value any) (an
 }
 
 // bindString processes a string that may contain expressions
-func (b *KangarooBinder) bindString(ctx context.Context, item any, str string) (any, error) {
+func (b *KangarooBinder) bindString(ctx context.Context, item any, path string, str string) (any, error) {
 	matches := b.exprRegex.FindAllStringSubmatch(str, -1)
 	if len(matches) == 0 {
 		return str, nil
 	}
 
+	if b.isStrict(ctx) {
+		return b.bindStringStrict(ctx, item, path, str, matches)
+	}
+
 	// Check if entire string is a single expression
 	if len(matches) == 1 && matches[0][0] == str {
 		// Single expression - return the actual value (will be marshaled later)
@@ -198,13 +237,66 @@ func (b *KangarooBinder) bindString(ctx context.Context, item any, str string) (
 	return result, nil
 }
 
+// bindStringStrict processes a string like bindString, but returns a TemplateError when
+// an expression fails and does not read expressions with invalid syntax as plain text
+func (b *KangarooBinder) bindStringStrict(ctx context.Context, item any, path string, str string, matches [][]string) (any, error) {
+	values := make([]any, len(matches))
+
+	for i, match := range matches {
+		expression := strings.TrimSpace(match[1])
+
+		if _, err := b.evaluator.Parse(expression); err != nil {
+			return nil, &TemplateError{Path: path, Expression: expression, Err: err}
+		}
+
+		value, err := b.evaluateExpression(ctx, item, expression)
+		if err != nil {
+			return nil, &TemplateError{Path: path, Expression: expression, Err: err}
+		}
+
+		values[i] = value
+	}
+
+	if len(matches) == 1 && matches[0][0] == str {
+		return values[0], nil
+	}
+
+	result := str
+	for i, match := range matches {
+		result = strings.ReplaceAll(result, match[0], b.valueToString(values[i]))
+	}
+
+	return result, nil
+}
+
+// isStrict reports whether failed expressions must fail the binding, either for every
+// workflow or for the workflow being executed
+func (b *KangarooBinder) isStrict(ctx context.Context) bool {
+	if b.strictTemplates {
+		return true
+	}
+
+	executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
+
+	return ok && executionContext.StrictTemplates
+}
+
 // bindMap recursively processes a map
-func (b *KangarooBinder) bindMap(ctx context.Context, item any, m map[string]any) (map[string]any, error) {
+func (b *KangarooBinder) bindMap(ctx context.Context, item any, path string, m map[string]any) (map[string]any, error) {
 	result := make(map[string]any, len(m))
 
 	for key, value := range m {
-		boundValue, err := b.bindValue(ctx, item, value)
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		boundValue, err := b.bindValue(ctx, item, keyPath, value)
 		if err != nil {
+			var templateErr *TemplateError
+			if errors.As(err, &templateErr) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("failed to bind key '%s': %w", key, err)
 		}
 		result[key] = boundValue
@@ -214,12 +306,16 @@ func (b *KangarooBinder) bindMap(ctx context.Context, item any, m map[string]any
 }
 
 // bindSlice recursively processes a slice
-func (b *KangarooBinder) bindSlice(ctx context.Context, item any, s []any) ([]any, error) {
+func (b *KangarooBinder) bindSlice(ctx context.Context, item any, path string, s []any) ([]any, error) {
 	result := make([]any, len(s))
 
 	for i, value := range s {
-		boundValue, err := b.bindValue(ctx, item, value)
+		boundValue, err := b.bindValue(ctx, item, fmt.Sprintf("%s[%d]", path, i), value)
 		if err != nil {
+			var templateErr *TemplateError
+			if errors.As(err, &templateErr) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("failed to bind index %d: %w", i, err)
 		}
 		result[i] = boundValue
@@ -231,9 +327,7 @@ func (b *KangarooBinder) bindSlice(ctx context.Context, item any, s []any) ([]an
 // evaluateExpression evaluates a Kangaroo expression using the local runtime
 func (b *KangarooBinder) evaluateExpression(ctx context.Context, item any, expression string) (any, error) {
 	// Create execution context
-	context := &types.ExpressionContext{
-		Item: item,
-	}
+	context := newExpressionContext(ctx, item)
 
 	// Evaluate expression directly
 	if b.evaluator == nil {
@@ -0,0 +1,151 @@
+package expressions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
+)
+
+// newExpressionContext creates the context of an expression evaluated for item. The
+// execution variables are only set when the binder runs inside of a workflow execution.
+func newExpressionContext(ctx context.Context, item any) *types.ExpressionContext {
+	expressionContext := &types.ExpressionContext{
+		Item: item,
+	}
+
+	executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
+	if !ok {
+		return expressionContext
+	}
+
+	execution := map[string]interface{}{
+		"id":                 executionContext.WorkflowExecutionID,
+		"triggerNodeId":      executionContext.TriggerNode.ID,
+		"triggerNodeName":    executionContext.TriggerNode.Name,
+		"isTesting":          executionContext.IsTesting,
+		"isReExecution":      executionContext.IsReExecution,
+		"isFromErrorTrigger": executionContext.IsFromErrorTrigger,
+		"callDepth":          float64(executionContext.CallDepth),
+	}
+
+	if !executionContext.StartedAt.IsZero() {
+		execution["startedAt"] = executionContext.StartedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	expressionContext.Execution = execution
+	expressionContext.Workflow = map[string]interface{}{
+		"id":          executionContext.WorkflowID,
+		"name":        executionContext.WorkflowName,
+		"workspaceId": executionContext.WorkspaceID,
+	}
+
+	if executionContext.NodeOutputs != nil {
+		expressionContext.Nodes = &nodeOutputResolver{
+			ctx:    ctx,
+			reader: executionContext.NodeOutputs,
+		}
+	}
+
+	return expressionContext
+}
+
+// nodeOutputResolver exposes the outputs of executed nodes as $node["Name"] or
+// $node.nodeID. A node output has the items of its first output as items, first and
+// last, and the items of every output index as outputs.
+type nodeOutputResolver struct {
+	ctx    context.Context
+	reader domain.NodeOutputReader
+}
+
+// ResolveProperty caches the output in the node output cache of the context, the items
+// of a node run all read the same outputs.
+func (r *nodeOutputResolver) ResolveProperty(nameOrID string) (interface{}, error) {
+	cache, hasCache := domain.GetNodeOutputCache(r.ctx)
+	if hasCache {
+		if output, ok := cache.Get(nameOrID); ok {
+			return output, nil
+		}
+	}
+
+	output, err := r.resolveProperty(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasCache {
+		cache.Set(nameOrID, output)
+	}
+
+	return output, nil
+}
+
+func (r *nodeOutputResolver) resolveProperty(nameOrID string) (interface{}, error) {
+	output, ok, err := r.reader.ReadNodeOutput(r.ctx, nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output of node %q: %w", nameOrID, err)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("node %q has not been executed", nameOrID)
+	}
+
+	maxOutputIndex := -1
+	for outputIndex := range output.ItemsByOutputIndex {
+		maxOutputIndex = max(maxOutputIndex, outputIndex)
+	}
+
+	outputs := make([]interface{}, maxOutputIndex+1)
+
+	for outputIndex := range outputs {
+		items, err := toExpressionItems(output.ItemsByOutputIndex[outputIndex].Items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read output of node %q: %w", nameOrID, err)
+		}
+
+		outputs[outputIndex] = items
+	}
+
+	items := []interface{}{}
+	if len(outputs) > 0 {
+		items = outputs[0].([]interface{})
+	}
+
+	var first, last interface{}
+	if len(items) > 0 {
+		first = items[0]
+		last = items[len(items)-1]
+	}
+
+	return map[string]interface{}{
+		"id":      output.NodeID,
+		"name":    output.NodeName,
+		"items":   items,
+		"first":   first,
+		"last":    last,
+		"outputs": outputs,
+	}, nil
+}
+
+// toExpressionItems converts items to the JSON types expressions work with, items of
+// integrations can hold Go values like structs or integers.
+func toExpressionItems(items []domain.Item) ([]interface{}, error) {
+	if len(items) == 0 {
+		return []interface{}{}, nil
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	var converted []interface{}
+	if err := json.Unmarshal(data, &converted); err != nil {
+		return nil, err
+	}
+
+	return converted, nil
+}
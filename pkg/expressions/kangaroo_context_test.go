@@ -0,0 +1,135 @@
+package expressions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+type staticNodeOutputs map[string]domain.NodeOutput
+
+func (o staticNodeOutputs) ReadNodeOutput(ctx context.Context, nameOrID string) (domain.NodeOutput, bool, error) {
+	for _, output := range o {
+		if output.NodeID == nameOrID || output.NodeName == nameOrID {
+			return output, true, nil
+		}
+	}
+
+	return domain.NodeOutput{}, false, nil
+}
+
+func newTestExecutionContext() context.Context {
+	return domain.NewContextWithWorkflowExecutionContext(context.Background(), domain.NewContextWithWorkflowExecutionContextParams{
+		WorkspaceID:         "ws_1",
+		WorkflowID:          "wf_1",
+		WorkflowName:        "Sync users",
+		WorkflowExecutionID: "exec_1",
+		IsTesting:           true,
+		TriggerNode:         domain.WorkflowNode{ID: "trigger", Name: "Every hour"},
+		StartedAt:           time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		NodeOutputs: staticNodeOutputs{
+			"fetch": {
+				NodeID:   "fetch",
+				NodeName: "Fetch users",
+				ItemsByOutputIndex: domain.NodeItemsMap{
+					0: {Items: []domain.Item{map[string]any{"email": "a@example.com"}, map[string]any{"email": "b@example.com"}}},
+					1: {Items: []domain.Item{map[string]any{"email": "c@example.com"}}},
+				},
+			},
+		},
+	})
+}
+
+func TestKangarooBinder_ExecutionVariables(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	ctx := newTestExecutionContext()
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`{{ $node["Fetch users"].first.email }}`, "a@example.com"},
+		{`{{ $node.fetch.last.email }}`, "b@example.com"},
+		{`{{ $node["Fetch users"].items[1].email }}`, "b@example.com"},
+		{`{{ $node["Fetch users"].items.length }}`, float64(2)},
+		{`{{ $node["Fetch users"].outputs[1][0].email }}`, "c@example.com"},
+		{`{{ $execution.id }}`, "exec_1"},
+		{`{{ $execution.triggerNodeName }}`, "Every hour"},
+		{`{{ $execution.startedAt }}`, "2026-03-02T09:00:00Z"},
+		{`{{ $execution.isTesting }}`, true},
+		{`{{ $workflow.name }} ({{ $workflow.id }})`, "Sync users (wf_1)"},
+	}
+
+	for _, tt := range tests {
+		got, err := binder.BindString(ctx, map[string]any{}, tt.expression)
+		if err != nil {
+			t.Errorf("BindString(%s) error = %v", tt.expression, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("BindString(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestKangarooBinder_UnexecutedNode(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	_, err = binder.BindString(newTestExecutionContext(), map[string]any{}, `{{ $node["Send email"].first }}`)
+	if err == nil || !strings.Contains(err.Error(), `node "Send email" has not been executed`) {
+		t.Errorf("BindString() error = %v, want an error naming the node", err)
+	}
+}
+
+type countingNodeOutputs struct {
+	staticNodeOutputs
+	reads int
+}
+
+func (o *countingNodeOutputs) ReadNodeOutput(ctx context.Context, nameOrID string) (domain.NodeOutput, bool, error) {
+	o.reads++
+
+	return o.staticNodeOutputs.ReadNodeOutput(ctx, nameOrID)
+}
+
+func TestKangarooBinder_CachesNodeOutputsPerNodeRun(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	reader := &countingNodeOutputs{staticNodeOutputs: staticNodeOutputs{
+		"fetch": {NodeID: "fetch", ItemsByOutputIndex: domain.NewNodeItemsMap(0, "fetch", []domain.Item{map[string]any{"email": "a@example.com"}})},
+	}}
+
+	ctx := domain.NewContextWithWorkflowExecutionContext(context.Background(), domain.NewContextWithWorkflowExecutionContextParams{
+		NodeOutputs: reader,
+	})
+	ctx = domain.NewContextWithNodeOutputCache(ctx)
+
+	for i := range 3 {
+		got, err := binder.BindString(ctx, map[string]any{"index": i}, `{{ $node.fetch.first.email }}`)
+		if err != nil {
+			t.Fatalf("BindString() error = %v", err)
+		}
+
+		if got != "a@example.com" {
+			t.Errorf("BindString() = %v, want a@example.com", got)
+		}
+	}
+
+	if reader.reads != 1 {
+		t.Errorf("node output was read %d times, want once for the node run", reader.reads)
+	}
+}
@@ -0,0 +1,139 @@
+package expressions
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestKangarooBinder_CryptoFunctions(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	item := map[string]any{
+		"secret":  "key",
+		"message": "The quick brown fox jumps over the lazy dog",
+	}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`{{ Crypto.md5("abc") }}`, "900150983cd24fb0d6963f7d28e17f72"},
+		{`{{ Crypto.sha1("abc") }}`, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{`{{ Crypto.sha256("abc") }}`, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{`{{ Crypto.sha256("abc", "base64") }}`, "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0="},
+		{`{{ Crypto.sha512("abc") }}`, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{`{{ Crypto.hash("SHA-256", "abc") }}`, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{`{{ Crypto.hmac("sha256", item.secret, item.message) }}`, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{`{{ Crypto.hmac("sha1", item.secret, item.message) }}`, "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9"},
+		{`{{ Crypto.timingSafeEqual(Crypto.hmac("sha256", item.secret, item.message), "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8") }}`, true},
+		{`{{ Crypto.timingSafeEqual("abc", "abd") }}`, false},
+		{`{{ Crypto.urlEncode("a b&c=d/é*") }}`, "a%20b%26c%3Dd%2F%C3%A9%2A"},
+		{`{{ Crypto.urlDecode("a+b%26c%3Dd%2F%C3%A9") }}`, "a b&c=d/é"},
+		{`{{ Crypto.hexEncode("hi!") }}`, "686921"},
+		{`{{ Crypto.hexDecode("686921") }}`, "hi!"},
+		{`{{ Crypto.base64UrlEncode("??>") }}`, "Pz8-"},
+		{`{{ Crypto.base64UrlDecode("Pz8-") }}`, "??>"},
+		{`{{ Crypto.base64UrlDecode("YQ==") }}`, "a"},
+		{`{{ Crypto.randomBytes(16).length }}`, float64(32)},
+		{`{{ Crypto.randomString(12).length }}`, float64(12)},
+	}
+
+	for _, tt := range tests {
+		got, err := binder.BindString(context.Background(), item, tt.expression)
+		if err != nil {
+			t.Errorf("BindString(%s) error = %v", tt.expression, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("BindString(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+// TestKangarooBinder_CryptoHMACKeyEncoding derives the AWS SigV4 signing key of the
+// example in the AWS documentation, every step uses the previous HMAC as its key.
+func TestKangarooBinder_CryptoHMACKeyEncoding(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	item := map[string]any{
+		"secretKey": "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		"date":      "20120215",
+		"region":    "us-east-1",
+		"service":   "iam",
+	}
+
+	kDate := `Crypto.hmac("sha256", "AWS4" + item.secretKey, item.date)`
+	kRegion := `Crypto.hmac("sha256", ` + kDate + `, item.region, "hex", "hex")`
+	kService := `Crypto.hmac("sha256", ` + kRegion + `, item.service, "hex", "hex")`
+	kSigning := `Crypto.hmac("sha256", ` + kService + `, "aws4_request", "hex", "hex")`
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`{{ ` + kDate + ` }}`, "969fbb94feb542b71ede6f87fe4d5fa29c789342b0f407474670f0c2489e0a0d"},
+		{`{{ ` + kRegion + ` }}`, "69daa0209cd9c5ff5c8ced464a696fd4252e981430b10e3d3fd8e2f197d7a70c"},
+		{`{{ ` + kService + ` }}`, "f72cfd46f26bc4643f06a11eabb6c0ba18780c19a8da0c31ace671265e3c87fa"},
+		{`{{ ` + kSigning + ` }}`, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"},
+		{`{{ Crypto.hmac("sha256", Crypto.hmac("sha256", "AWS4" + item.secretKey, item.date, "base64"), item.region, "hex", "base64") }}`, "69daa0209cd9c5ff5c8ced464a696fd4252e981430b10e3d3fd8e2f197d7a70c"},
+		{`{{ Crypto.hmac("sha256", "6b6579", "message", null, "hex") == Crypto.hmac("sha256", "key", "message") }}`, true},
+	}
+
+	for _, tt := range tests {
+		got, err := binder.BindString(context.Background(), item, tt.expression)
+		if err != nil {
+			t.Errorf("BindString(%s) error = %v", tt.expression, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("BindString(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+
+	if _, err := binder.BindString(context.Background(), item, `{{ Crypto.hmac("sha256", "not hex", "message", "hex", "hex") }}`); err == nil {
+		t.Error("BindString() with an invalid hex key error = nil, want an error")
+	}
+}
+
+func TestKangarooBinder_CryptoIdentifiers(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	tests := []struct {
+		expression string
+		pattern    *regexp.Regexp
+	}{
+		{`{{ Crypto.ulid() }}`, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+		{`{{ Crypto.uuidv7() }}`, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{`{{ Crypto.randomString(20, "01") }}`, regexp.MustCompile(`^[01]{20}$`)},
+		{`{{ Crypto.randomBytes(8, "base64url") }}`, regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)},
+	}
+
+	for _, tt := range tests {
+		first, err := binder.BindString(context.Background(), map[string]any{}, tt.expression)
+		if err != nil {
+			t.Fatalf("BindString(%s) error = %v", tt.expression, err)
+		}
+
+		second, _ := binder.BindString(context.Background(), map[string]any{}, tt.expression)
+
+		if !tt.pattern.MatchString(first.(string)) {
+			t.Errorf("BindString(%s) = %v, want a match of %s", tt.expression, first, tt.pattern)
+		}
+
+		if first == second {
+			t.Errorf("BindString(%s) returned %v twice", tt.expression, first)
+		}
+	}
+}
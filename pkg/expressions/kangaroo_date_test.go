@@ -0,0 +1,117 @@
+package expressions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKangarooBinder_DateFunctions(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	// Wednesday, 4 March 2026 in Istanbul (UTC+3)
+	item := map[string]any{"at": "2026-03-04T10:30:00Z"}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`{{ Date.format(item.at, "dddd, D MMMM YYYY [at] HH:mm Z", "Europe/Istanbul") }}`, "Wednesday, 4 March 2026 at 13:30 +03:00"},
+		{`{{ Date.format(item.at, "YYYY-[W]WW-E hh:mm A") }}`, "2026-W10-3 10:30 AM"},
+		{`{{ Date.fromFormat("02.03.2026 09:00", "DD.MM.YYYY HH:mm", "Europe/Istanbul") }}`, "2026-03-02T09:00:00.000+03:00"},
+		{`{{ Date.toISO(1767225600000, "America/New_York") }}`, "2025-12-31T19:00:00.000-05:00"},
+		{`{{ Date.add("2026-01-31T12:00:00Z", 1, "month") }}`, "2026-02-28T12:00:00.000Z"},
+		{`{{ Date.subtract(item.at, 90, "minutes") }}`, "2026-03-04T09:00:00.000Z"},
+		{`{{ Date.add("2026-03-28T12:00:00", 1, "day", "Europe/Berlin") }}`, "2026-03-29T12:00:00.000+02:00"},
+		{`{{ Date.startOf(item.at, "week", "Europe/Istanbul") }}`, "2026-03-02T00:00:00.000+03:00"},
+		{`{{ Date.endOf(item.at, "month") }}`, "2026-03-31T23:59:59.999Z"},
+		{`{{ Date.startOf(item.at, "quarter") }}`, "2026-01-01T00:00:00.000Z"},
+		{`{{ Date.isoWeek("2027-01-01") }}`, float64(53)},
+		{`{{ Date.weekday("2026-03-08") }}`, float64(7)},
+		{`{{ Date.diff("2026-01-31", "2026-03-30", "months") }}`, float64(1)},
+		{`{{ Date.diff(item.at, "2026-03-05T10:30:00Z", "hours") }}`, float64(24)},
+		{`{{ Date.compare("2026-03-01", item.at) }}`, float64(-1)},
+		{`{{ Date.isSame("2026-03-04T22:00:00Z", item.at, "day") }}`, true},
+		{`{{ Date.isSame("2026-03-04T22:00:00Z", item.at, "day", "Europe/Istanbul") }}`, false},
+		{`{{ Date.isAfter(item.at, "2026-03-04T10:29:59Z") }}`, true},
+		{`{{ Date.toZone(item.at, "Asia/Tokyo") }}`, "2026-03-04T19:30:00.000+09:00"},
+		{`{{ Date.setZone(item.at, "Europe/Istanbul") }}`, "2026-03-04T10:30:00.000+03:00"},
+		{`{{ Date.addBusinessDays("2026-03-06T09:00:00Z", 1) }}`, "2026-03-09T09:00:00.000Z"},
+		{`{{ Date.addBusinessDays("2026-03-06", 2, "", ["2026-03-09"]) }}`, "2026-03-11T00:00:00.000Z"},
+		{`{{ Date.diffBusinessDays("2026-03-06", "2026-03-13") }}`, float64(5)},
+		{`{{ Date.isBusinessDay("2026-03-07") }}`, false},
+		{`{{ Date.humanizeDuration(93784000) }}`, "1 day, 2 hours"},
+		{`{{ Date.humanizeDuration(93784000, 4) }}`, "1 day, 2 hours, 3 minutes, 4 seconds"},
+		{`{{ Date.relative("2026-03-01T10:30:00Z", item.at) }}`, "3 days ago"},
+		{`{{ Date.relative("2026-03-04T12:30:00Z", item.at) }}`, "in 2 hours"},
+	}
+
+	for _, tt := range tests {
+		got, err := binder.BindString(context.Background(), item, tt.expression)
+		if err != nil {
+			t.Errorf("BindString(%s) error = %v", tt.expression, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("BindString(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestKangarooBinder_LastMondayInTimezone(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	got, err := binder.BindString(context.Background(), map[string]any{},
+		`{{ Date.set(Date.previous(Date.now(), "monday", "Europe/Istanbul"), {hour: 9, minute: 0, second: 0, millisecond: 0}) }}`)
+	if err != nil {
+		t.Fatalf("BindString() error = %v", err)
+	}
+
+	lastMonday, err := time.Parse(time.RFC3339, got.(string))
+	if err != nil {
+		t.Fatalf("result %v is not an ISO date: %v", got, err)
+	}
+
+	istanbul, _ := time.LoadLocation("Europe/Istanbul")
+	now := time.Now().In(istanbul)
+
+	if !strings.HasSuffix(got.(string), "T09:00:00.000+03:00") || lastMonday.Weekday() != time.Monday {
+		t.Errorf("result = %v, want a Monday at 09:00 in Istanbul", got)
+	}
+
+	if age := now.Sub(lastMonday); age <= 0 || age > 8*24*time.Hour {
+		t.Errorf("result = %v, want the Monday before %v", got, now)
+	}
+}
+
+func TestKangarooBinder_DateFunctionErrors(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{`{{ Date.toZone("2026-03-04", "Mars/Olympus") }}`, `unknown timezone "Mars/Olympus"`},
+		{`{{ Date.add("2026-03-04", 1, "fortnight") }}`, `unknown date unit "fortnight"`},
+		{`{{ Date.fromFormat("2026-10", "GGGG-WW") }}`, `pattern token "GGGG" can not be used to parse dates`},
+		{`{{ Date.format("yesterday", "YYYY") }}`, `invalid date: "yesterday"`},
+	}
+
+	for _, tt := range tests {
+		_, err := binder.BindString(context.Background(), map[string]any{}, tt.expression)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("BindString(%s) error = %v, want %q", tt.expression, err, tt.wantErr)
+		}
+	}
+}
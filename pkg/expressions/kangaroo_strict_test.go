@@ -0,0 +1,111 @@
+package expressions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo"
+	"github.com/flowbaker/flowbaker/pkg/expressions/kangaroo/types"
+)
+
+func TestKangaroo_StrictTemplates(t *testing.T) {
+	expressionContext := &types.ExpressionContext{Item: map[string]any{"name": "Ada"}}
+	template := "Hi {{ item.name }}, {{ item.name.slice( }}"
+
+	lenient := kangaroo.NewKangaroo(types.DefaultEvaluatorOptions())
+
+	result, err := lenient.Evaluate(template, expressionContext)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	if !result.Success || !strings.Contains(result.Value.(string), "[ERROR: ") {
+		t.Errorf("Evaluate() = %+v, want the error inlined without strict templates", result)
+	}
+
+	options := types.DefaultEvaluatorOptions()
+	options.StrictTemplates = true
+	strict := kangaroo.NewKangaroo(options)
+
+	result, err = strict.Evaluate(template, expressionContext)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	if result.Success || result.ErrorType != types.ErrorTypeSyntax || !strings.Contains(result.Error, "expression 'item.name.slice(' failed") {
+		t.Errorf("Evaluate() = %+v, want a syntax error naming the expression", result)
+	}
+
+	result, err = strict.Evaluate("hello world", expressionContext)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	if result.Success {
+		t.Errorf("Evaluate() = %+v, want invalid syntax to fail instead of being read as plain text", result)
+	}
+}
+
+func TestKangarooBinder_StrictTemplates(t *testing.T) {
+	type target struct {
+		Body map[string]any `json:"body"`
+	}
+
+	settings := map[string]any{
+		"body": map[string]any{
+			"lines": []any{"Hello", "Dear {{ item.name.toUpperCase( }}"},
+		},
+	}
+
+	options := DefaultKangarooBinderOptions()
+	options.StrictTemplates = true
+
+	binder, err := NewKangarooBinder(options)
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	var bound target
+	err = binder.BindToStruct(context.Background(), map[string]any{"name": "Ada"}, &bound, settings)
+
+	var templateErr *TemplateError
+	if !errors.As(err, &templateErr) {
+		t.Fatalf("BindToStruct() error = %v, want a TemplateError", err)
+	}
+
+	if templateErr.Path != "body.lines[1]" || templateErr.Expression != "item.name.toUpperCase(" {
+		t.Errorf("TemplateError = %+v, want the path and expression of the failed setting", templateErr)
+	}
+
+	got, err := binder.BindString(context.Background(), map[string]any{"name": "Ada"}, "Dear {{ item.name }}")
+	if err != nil || got != "Dear Ada" {
+		t.Errorf("BindString() = %v, %v, want expressions that do not fail to bind", got, err)
+	}
+}
+
+func TestKangarooBinder_StrictTemplatesPerWorkflow(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	got, err := binder.BindString(context.Background(), map[string]any{}, "{{ hello world }}")
+	if err != nil || got != "hello world" {
+		t.Errorf("BindString() = %v, %v, want invalid syntax read as plain text without strict templates", got, err)
+	}
+
+	ctx := domain.NewContextWithWorkflowExecutionContext(context.Background(), domain.NewContextWithWorkflowExecutionContextParams{
+		WorkflowID:      "wf_1",
+		StrictTemplates: true,
+	})
+
+	_, err = binder.BindString(ctx, map[string]any{}, "{{ hello world }}")
+
+	var templateErr *TemplateError
+	if !errors.As(err, &templateErr) || templateErr.Expression != "hello world" {
+		t.Errorf("BindString() error = %v, want a TemplateError for the workflow with strict templates", err)
+	}
+}
@@ -0,0 +1,104 @@
+package expressions
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKangarooBinder_ModernSyntax(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	item := map[string]any{
+		"name":  "Ada",
+		"tags":  []any{"admin", "beta"},
+		"owner": map[string]any{"emails": []any{"ada@example.com"}},
+		"users": []any{
+			map[string]any{"id": float64(1), "name": "Ada", "roles": []any{"admin"}},
+			map[string]any{"id": float64(2), "name": "Grace", "roles": []any{}},
+		},
+		"phone": "+1 (555) 010-9999",
+	}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{"{{ `Hello ${item.name}, you have ${item.tags.length} tags` }}", "Hello Ada, you have 2 tags"},
+		{"{{ `${item.tags[0].toUpperCase()}!` }}", "ADMIN!"},
+		{`{{ item.owner?.emails?.[0] }}`, "ada@example.com"},
+		{`{{ item.manager?.emails?.[0] }}`, nil},
+		{`{{ item.manager?.name.toUpperCase() }}`, nil},
+		{`{{ item.manager?.emails[0] ?? "nobody" }}`, "nobody"},
+		{`{{ item.name?.toLowerCase() }}`, "ada"},
+		{`{{ [...item.tags, "new"] }}`, []any{"admin", "beta", "new"}},
+		{`{{ [..."ab"] }}`, []any{"a", "b"}},
+		{`{{ {...item.owner, name: item.name} }}`, map[string]any{"emails": []any{"ada@example.com"}, "name": "Ada"}},
+		{`{{ {...{name: "new"}, name: "old"} }}`, map[string]any{"name": "old"}},
+		{`{{ item.users.map(({id, name}) => id + ":" + name) }}`, []any{"1:Ada", "2:Grace"}},
+		{`{{ item.users.map(({name: userName, roles: [firstRole = "none"]}) => userName + "/" + firstRole) }}`, []any{"Ada/admin", "Grace/none"}},
+		{`{{ item.users.filter(({id}, index) => index > 0).map(({id, ...rest}) => rest.name) }}`, []any{"Grace"}},
+		{`{{ item.users.reduce((total, {id}) => total + id, 0) }}`, float64(3)},
+		{`{{ item.users.map(user => { return user.name.length }) }}`, []any{float64(3), float64(5)}},
+		{`{{ /^A/.test(item.name) }}`, true},
+		{`{{ /^a/i.test("Ada") }}`, true},
+		{`{{ item.phone.replace(/\D/g, "") }}`, "15550109999"},
+		{`{{ item.phone.replace(/\d/, "#") }}`, "+# (555) 010-9999"},
+		{`{{ "2026-03-04".replace(/(\d+)-(\d+)-(\d+)/, "$3.$2.$1") }}`, "04.03.2026"},
+		{`{{ "Ada Lovelace".replace(/(?<first>\w+) (?<last>\w+)/, "$<last>, $<first>") }}`, "Lovelace, Ada"},
+		{`{{ item.phone.match(/\((\d+)\)/)[1] }}`, "555"},
+		{`{{ item.phone.match(/\d+/g) }}`, []any{"1", "555", "010", "9999"}},
+		{`{{ item.name.match(/z/) }}`, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := binder.BindString(context.Background(), item, tt.expression)
+		if err != nil {
+			t.Errorf("BindString(%s) error = %v", tt.expression, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BindString(%s) = %#v, want %#v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestKangarooBinder_ModernSyntaxErrors(t *testing.T) {
+	binder, err := NewKangarooBinder(DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	item := map[string]any{"users": []any{map[string]any{"name": "Ada"}}}
+
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{`{{ item.users.map(user => { if (user.name) return 1; return 2 }) }}`, "arrow function bodies can only contain a return statement"},
+		{`{{ item.users.map(([name]) => name) }}`, ""},
+		{`{{ [1, 2].map(({name}) => name) }}`, ""},
+		{`{{ [undefined].map(({name}) => name) }}`, "cannot destructure undefined"},
+		{`{{ /(?=a)/.test("a") }}`, "invalid regular expression"},
+	}
+
+	for _, tt := range tests {
+		_, err := binder.BindString(context.Background(), item, tt.expression)
+
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("BindString(%s) error = %v, want no error", tt.expression, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("BindString(%s) error = %v, want %q", tt.expression, err, tt.wantErr)
+		}
+	}
+}
@@ -22,6 +22,7 @@ var (
 				ID:         string(IntegrationActionType_EncodeToBase64),
 				Name:       "Encode to Base64",
 				ActionType: IntegrationActionType_EncodeToBase64,
+				SideEffect: domain.ActionSideEffectRead,
 				Properties: []domain.NodeProperty{
 					{
 						Key:         "text",
@@ -37,6 +38,7 @@ var (
 				ID:         string(IntegrationActionType_DecodeFromBase64),
 				Name:       "Decode from Base64",
 				ActionType: IntegrationActionType_DecodeFromBase64,
+				SideEffect: domain.ActionSideEffectRead,
 				Properties: []domain.NodeProperty{
 					{
 						Key:         "encoded_text",
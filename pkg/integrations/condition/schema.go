@@ -123,6 +123,7 @@ var (
 				ID:         "if_else",
 				Name:       "If/Else",
 				ActionType: IntegrationActionType_IfElse,
+				SideEffect: domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
 				},
@@ -597,6 +598,7 @@ var (
 				ID:          "conditional_dispatch",
 				Name:        "Conditional Dispatch",
 				ActionType:  IntegrationActionType_ConditionalDispatch,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Switch between different actions based on a condition", SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
 				},
@@ -24,6 +24,7 @@ var (
 				ID:          "classify",
 				Name:        "Classify Content",
 				ActionType:  IntegrationActionType_Classify,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Route data to different paths based on AI classification.",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
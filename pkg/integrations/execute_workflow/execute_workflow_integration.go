@@ -0,0 +1,165 @@
+package executeworkflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+var ErrMaxCallDepthExceeded = domain.ErrMaxCallDepthExceeded
+
+type ExecuteWorkflowIntegrationCreator struct {
+	binder        domain.IntegrationParameterBinder
+	taskPublisher domain.ExecutorTaskPublisher
+}
+
+func NewExecuteWorkflowIntegrationCreator(deps domain.IntegrationDeps) domain.IntegrationCreator {
+	return &ExecuteWorkflowIntegrationCreator{
+		binder:        deps.ParameterBinder,
+		taskPublisher: deps.ExecutorTaskPublisher,
+	}
+}
+
+func (c *ExecuteWorkflowIntegrationCreator) CreateIntegration(ctx context.Context, p domain.CreateIntegrationParams) (domain.IntegrationExecutor, error) {
+	return NewExecuteWorkflowIntegration(ExecuteWorkflowIntegrationDependencies{
+		ParameterBinder: c.binder,
+		TaskPublisher:   c.taskPublisher,
+	}), nil
+}
+
+type ExecuteWorkflowIntegration struct {
+	binder        domain.IntegrationParameterBinder
+	taskPublisher domain.ExecutorTaskPublisher
+	actionManager *domain.IntegrationActionManager
+}
+
+type ExecuteWorkflowIntegrationDependencies struct {
+	ParameterBinder domain.IntegrationParameterBinder
+	TaskPublisher   domain.ExecutorTaskPublisher
+}
+
+func NewExecuteWorkflowIntegration(deps ExecuteWorkflowIntegrationDependencies) *ExecuteWorkflowIntegration {
+	integration := &ExecuteWorkflowIntegration{
+		binder:        deps.ParameterBinder,
+		taskPublisher: deps.TaskPublisher,
+	}
+
+	integration.actionManager = domain.NewIntegrationActionManager().
+		Add(ExecuteWorkflowActionType_ExecuteWorkflow, integration.ExecuteWorkflow)
+
+	return integration
+}
+
+func (i *ExecuteWorkflowIntegration) Execute(ctx context.Context, params domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	return i.actionManager.Run(ctx, params.ActionType, params)
+}
+
+type ExecuteWorkflowParams struct {
+	WorkflowID string `json:"workflow_id"`
+	Mode       string `json:"mode"`
+}
+
+// ExecuteWorkflow calls the workflow once with all input items as its trigger payload.
+// The platform runs the called workflow like any other and has to start it with the
+// CallDepth of the task, one more than the depth of this execution, so that executors
+// can reject chains deeper than MaxWorkflowCallDepth.
+func (i *ExecuteWorkflowIntegration) ExecuteWorkflow(ctx context.Context, params domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	executionContext, ok := domain.GetWorkflowExecutionContext(ctx)
+	if !ok {
+		return domain.IntegrationOutput{}, errors.New("workflow execution context not found")
+	}
+
+	if executionContext.CallDepth >= domain.MaxWorkflowCallDepth {
+		return domain.IntegrationOutput{}, ErrMaxCallDepthExceeded
+	}
+
+	allItems := params.GetAllItems()
+
+	bindItem := domain.Item(map[string]any{})
+	if len(allItems) > 0 {
+		bindItem = allItems[0]
+	}
+
+	p := ExecuteWorkflowParams{}
+
+	if err := i.binder.BindToStruct(ctx, bindItem, &p, params.IntegrationParams.Settings); err != nil {
+		return domain.IntegrationOutput{}, fmt.Errorf("failed to bind execute workflow parameters: %w", err)
+	}
+
+	if p.WorkflowID == "" {
+		return domain.IntegrationOutput{}, errors.New("workflow id is required")
+	}
+
+	payload, err := json.Marshal(allItems)
+	if err != nil {
+		return domain.IntegrationOutput{}, fmt.Errorf("failed to marshal workflow payload: %w", err)
+	}
+
+	userID := ""
+	if executionContext.UserID != nil {
+		userID = *executionContext.UserID
+	}
+
+	task := domain.ExecuteWorkflowTask{
+		WorkspaceID:  executionContext.WorkspaceID,
+		WorkflowID:   p.WorkflowID,
+		UserID:       userID,
+		WorkflowType: domain.WorkflowTypeDefault,
+		Payload:      string(payload),
+		CallDepth:    executionContext.CallDepth + 1,
+	}
+
+	if p.Mode == ModeFireAndForget {
+		if err := i.taskPublisher.EnqueueTask(ctx, executionContext.WorkspaceID, task); err != nil {
+			return domain.IntegrationOutput{}, fmt.Errorf("failed to start workflow %s: %w", p.WorkflowID, err)
+		}
+
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, params.NodeID, allItems),
+		}, nil
+	}
+
+	result, err := i.taskPublisher.EnqueueTaskAndWait(ctx, executionContext.WorkspaceID, task)
+	if err != nil {
+		return domain.IntegrationOutput{}, fmt.Errorf("failed to execute workflow %s: %w", p.WorkflowID, err)
+	}
+
+	outputItems, err := resultToItems(result)
+	if err != nil {
+		return domain.IntegrationOutput{}, fmt.Errorf("failed to read result of workflow %s: %w", p.WorkflowID, err)
+	}
+
+	return domain.IntegrationOutput{
+		ItemsByOutputIndex: domain.NewNodeItemsMap(0, params.NodeID, outputItems),
+	}, nil
+}
+
+// resultToItems turns the response of the called workflow into items, an array is
+// output item by item and any other value as a single item.
+func resultToItems(result []byte) ([]domain.Item, error) {
+	if len(result) == 0 {
+		return []domain.Item{}, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(result, &value); err != nil {
+		return nil, err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return []domain.Item{}, nil
+	case []any:
+		items := make([]domain.Item, 0, len(value))
+		for _, item := range value {
+			items = append(items, item)
+		}
+
+		return items, nil
+	default:
+		return []domain.Item{value}, nil
+	}
+}
@@ -0,0 +1,200 @@
+package executeworkflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/expressions"
+)
+
+type fakeTaskPublisher struct {
+	enqueued []domain.ExecuteWorkflowTask
+	waited   []domain.ExecuteWorkflowTask
+	result   []byte
+	err      error
+}
+
+func (p *fakeTaskPublisher) EnqueueTask(ctx context.Context, workspaceID string, task domain.Task) error {
+	p.enqueued = append(p.enqueued, task.(domain.ExecuteWorkflowTask))
+
+	return p.err
+}
+
+func (p *fakeTaskPublisher) EnqueueTaskAndWait(ctx context.Context, workspaceID string, task domain.Task) ([]byte, error) {
+	p.waited = append(p.waited, task.(domain.ExecuteWorkflowTask))
+
+	return p.result, p.err
+}
+
+type failingBinder struct {
+	domain.IntegrationParameterBinder
+}
+
+func (failingBinder) BindToStruct(ctx context.Context, item any, params any, expressions map[string]any) error {
+	return errors.New("unknown variable")
+}
+
+func TestExecuteWorkflowIntegration_ExecuteWorkflow(t *testing.T) {
+	binder, err := expressions.NewKangarooBinder(expressions.DefaultKangarooBinderOptions())
+	if err != nil {
+		t.Fatalf("NewKangarooBinder() error = %v", err)
+	}
+
+	items := []domain.Item{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}}
+
+	tests := []struct {
+		name         string
+		binder       domain.IntegrationParameterBinder
+		settings     map[string]any
+		callDepth    int
+		publisher    *fakeTaskPublisher
+		wantItems    []domain.Item
+		wantErr      string
+		wantEnqueued int
+		wantWaited   int
+	}{
+		{
+			name:       "wait for result",
+			settings:   map[string]any{"workflow_id": "wf_called", "mode": ModeWaitForResult},
+			callDepth:  2,
+			publisher:  &fakeTaskPublisher{result: []byte(`[{"total": 3}, {"total": 4}]`)},
+			wantItems:  []domain.Item{map[string]any{"total": 3.0}, map[string]any{"total": 4.0}},
+			wantWaited: 1,
+		},
+		{
+			name:         "fire and forget",
+			settings:     map[string]any{"workflow_id": "wf_called", "mode": ModeFireAndForget},
+			publisher:    &fakeTaskPublisher{},
+			wantItems:    items,
+			wantEnqueued: 1,
+		},
+		{
+			name:      "max call depth",
+			settings:  map[string]any{"workflow_id": "wf_called"},
+			callDepth: domain.MaxWorkflowCallDepth,
+			publisher: &fakeTaskPublisher{},
+			wantErr:   ErrMaxCallDepthExceeded.Error(),
+		},
+		{
+			name:      "binding error",
+			binder:    failingBinder{},
+			settings:  map[string]any{"workflow_id": "{{ missing }}"},
+			publisher: &fakeTaskPublisher{},
+			wantErr:   "failed to bind execute workflow parameters: unknown variable",
+		},
+		{
+			name:      "missing workflow id",
+			settings:  map[string]any{},
+			publisher: &fakeTaskPublisher{},
+			wantErr:   "workflow id is required",
+		},
+		{
+			name:       "called workflow fails",
+			settings:   map[string]any{"workflow_id": "wf_called"},
+			publisher:  &fakeTaskPublisher{err: errors.New("workflow failed")},
+			wantErr:    "failed to execute workflow wf_called: workflow failed",
+			wantWaited: 1,
+		},
+		{
+			name:       "invalid result",
+			settings:   map[string]any{"workflow_id": "wf_called"},
+			publisher:  &fakeTaskPublisher{result: []byte(`{`)},
+			wantErr:    "failed to read result of workflow wf_called",
+			wantWaited: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testBinder := tt.binder
+			if testBinder == nil {
+				testBinder = binder
+			}
+
+			integration := NewExecuteWorkflowIntegration(ExecuteWorkflowIntegrationDependencies{
+				ParameterBinder: testBinder,
+				TaskPublisher:   tt.publisher,
+			})
+
+			userID := "user_1"
+
+			ctx := domain.NewContextWithWorkflowExecutionContext(context.Background(), domain.NewContextWithWorkflowExecutionContextParams{
+				UserID:      &userID,
+				WorkspaceID: "ws_1",
+				CallDepth:   tt.callDepth,
+			})
+
+			output, err := integration.Execute(ctx, domain.IntegrationInput{
+				NodeID:            "call",
+				ActionType:        ExecuteWorkflowActionType_ExecuteWorkflow,
+				ItemsByInputIndex: domain.NewNodeItemsMap(0, "trigger", items),
+				IntegrationParams: domain.IntegrationParams{Settings: tt.settings},
+			})
+
+			if len(tt.publisher.enqueued) != tt.wantEnqueued || len(tt.publisher.waited) != tt.wantWaited {
+				t.Errorf("enqueued %d and waited for %d tasks, want %d and %d", len(tt.publisher.enqueued), len(tt.publisher.waited), tt.wantEnqueued, tt.wantWaited)
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if got := output.ItemsByOutputIndex[0].Items; !reflect.DeepEqual(got, tt.wantItems) {
+				t.Errorf("output = %v, want %v", got, tt.wantItems)
+			}
+
+			task := append(tt.publisher.enqueued, tt.publisher.waited...)[0]
+
+			var payload []domain.Item
+			if err := json.Unmarshal([]byte(task.Payload.(string)), &payload); err != nil {
+				t.Fatalf("task payload %v is not a JSON array: %v", task.Payload, err)
+			}
+
+			if task.WorkspaceID != "ws_1" || task.WorkflowID != "wf_called" || task.UserID != userID || task.CallDepth != tt.callDepth+1 || !reflect.DeepEqual(payload, items) {
+				t.Errorf("task = %+v, want a call of wf_called one level deeper with the input items", task)
+			}
+		})
+	}
+}
+
+func TestResultToItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		want    []domain.Item
+		wantErr bool
+	}{
+		{name: "empty", result: "", want: []domain.Item{}},
+		{name: "null", result: "null", want: []domain.Item{}},
+		{name: "array", result: `[{"id": 1}, 2]`, want: []domain.Item{map[string]any{"id": 1.0}, 2.0}},
+		{name: "object", result: `{"id": 1}`, want: []domain.Item{map[string]any{"id": 1.0}}},
+		{name: "scalar", result: `"done"`, want: []domain.Item{"done"}},
+		{name: "invalid", result: `[1,`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resultToItems([]byte(tt.result))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resultToItems() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resultToItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
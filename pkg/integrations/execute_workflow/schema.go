@@ -0,0 +1,68 @@
+package executeworkflow
+
+import "github.com/flowbaker/flowbaker/pkg/domain"
+
+const (
+	ExecuteWorkflowActionType_ExecuteWorkflow domain.IntegrationActionType = "execute_workflow"
+
+	IntegrationTriggerType_WorkflowCalled domain.IntegrationTriggerEventType = "workflow_called"
+
+	ModeWaitForResult = "wait_for_result"
+	ModeFireAndForget = "fire_and_forget"
+)
+
+var Schema = domain.Integration{
+	ID:                   domain.IntegrationType_ExecuteWorkflow,
+	Name:                 "Execute Workflow",
+	Description:          "Call another workflow and use its result, so shared logic lives in one workflow",
+	CredentialProperties: []domain.NodeProperty{},
+	Triggers: []domain.IntegrationTrigger{
+		{
+			ID:          "on_workflow_called",
+			Name:        "When Called by Another Workflow",
+			EventType:   IntegrationTriggerType_WorkflowCalled,
+			Description: "Triggered by an Execute Workflow node, the items of the calling node are the input",
+			Properties:  []domain.NodeProperty{},
+		},
+	},
+	Actions: []domain.IntegrationAction{
+		{
+			ID:          string(ExecuteWorkflowActionType_ExecuteWorkflow),
+			Name:        "Execute Workflow",
+			ActionType:  ExecuteWorkflowActionType_ExecuteWorkflow,
+			Description: "Run a workflow with the input items as its trigger payload",
+			SupportedContexts: []domain.ActionUsageContext{
+				domain.UsageContextWorkflow,
+			},
+			Properties: []domain.NodeProperty{
+				{
+					Key:         "workflow_id",
+					Name:        "Workflow",
+					Description: "ID of the workflow to call, it starts from its When Called by Another Workflow trigger",
+					Required:    true,
+					Type:        domain.NodePropertyType_String,
+				},
+				{
+					Key:         "mode",
+					Name:        "Mode",
+					Description: "Whether to wait for the called workflow to finish",
+					Required:    true,
+					Type:        domain.NodePropertyType_String,
+					Options: []domain.NodePropertyOption{
+						{
+							Label:       "Wait for Result",
+							Description: "Output the response of the called workflow, or the output of its last node",
+							Value:       ModeWaitForResult,
+						},
+						{
+							Label:       "Fire and Forget",
+							Description: "Start the called workflow and pass the input items through",
+							Value:       ModeFireAndForget,
+						},
+					},
+					Default: ModeWaitForResult,
+				},
+			},
+		},
+	},
+}
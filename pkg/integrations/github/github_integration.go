@@ -163,7 +163,7 @@ func NewGithubIntegration(ctx context.Context, deps GithubIntegrationDependencie
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: oauthAccount.AccessToken},
 	)
-	tc := oauth2.NewClient(context.Background(), ts)
+	tc := domain.TraceHTTPClient(oauth2.NewClient(ctx, ts))
 	integration.githubClient = github.NewClient(tc)
 
 	return integration, nil
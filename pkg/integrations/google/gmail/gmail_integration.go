@@ -88,7 +88,7 @@ func (g *GmailIntegration) getClient(ctx context.Context, oauthAccount domain.OA
 	}
 
 	ts := oauth2.StaticTokenSource(token)
-	client := oauth2.NewClient(ctx, ts)
+	client := domain.TraceHTTPClient(oauth2.NewClient(ctx, ts))
 	return client, nil
 }
 
@@ -115,10 +115,10 @@ func NewGoogleDriveIntegration(ctx context.Context, deps GoogleDriveIntegrationD
 		TokenType:    "Bearer",
 	}
 
-	client := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(token))
+	client := domain.TraceHTTPClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)))
 
 	if integration.driveService == nil {
-		integration.driveService, err = drive.NewService(context.Background(), option.WithHTTPClient(client))
+		integration.driveService, err = drive.NewService(ctx, option.WithHTTPClient(client))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create drive service: %w", err)
 		}
@@ -91,17 +91,17 @@ func NewGoogleSheetsIntegration(ctx context.Context, deps GoogleSheetsIntegratio
 		Expiry:       tokens.Expiry,
 	}
 
-	client := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(token))
+	client := domain.TraceHTTPClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)))
 
 	if integration.sheetsService == nil {
-		integration.sheetsService, err = sheets.NewService(context.Background(), option.WithHTTPClient(client))
+		integration.sheetsService, err = sheets.NewService(ctx, option.WithHTTPClient(client))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create sheets service: %w", err)
 		}
 	}
 
 	if integration.driveService == nil {
-		integration.driveService, err = drive.NewService(context.Background(), option.WithHTTPClient(client))
+		integration.driveService, err = drive.NewService(ctx, option.WithHTTPClient(client))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create drive service: %w", err)
 		}
@@ -346,7 +346,7 @@ func (g *GoogleSheetsIntegration) getClient(ctx context.Context, oauthAccount do
 		RefreshToken: oauthAccount.SensitiveData.RefreshToken,
 	}
 	ts := oauth2.StaticTokenSource(token)
-	client := oauth2.NewClient(ctx, ts)
+	client := domain.TraceHTTPClient(oauth2.NewClient(ctx, ts))
 	return client, nil
 }
 
@@ -239,7 +239,7 @@ func (i *HTTPIntegration) Execute(ctx context.Context, params domain.Integration
 		return domain.IntegrationOutput{}, err
 	}
 
-	i.client = client
+	i.client = domain.TraceHTTPClient(client)
 
 	actionFunc, ok := i.actionFuncs[params.ActionType]
 	if !ok {
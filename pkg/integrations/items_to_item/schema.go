@@ -19,6 +19,7 @@ var (
 				ID:          string(IntegrationActionType_ItemsToItem),
 				Name:        "Items To Item",
 				ActionType:  IntegrationActionType_ItemsToItem,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Convert an array of items to a single item",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
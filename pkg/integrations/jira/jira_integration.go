@@ -348,7 +348,7 @@ func (i *JiraIntegration) initializeJiraClient(ctx context.Context) (*jira.Clien
 		TokenType:   "Bearer",
 	})
 
-	httpClient := oauth2.NewClient(ctx, tokenSource)
+	httpClient := domain.TraceHTTPClient(oauth2.NewClient(ctx, tokenSource))
 
 	// For OAuth 2.0 (3LO), use the Atlassian API gateway directly
 	// The go-jira library will append the API paths to this base URL
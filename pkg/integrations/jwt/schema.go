@@ -69,6 +69,7 @@ var (
 			{
 				ID:          "create_token",
 				ActionType:  ActionCreateToken,
+				SideEffect:  domain.ActionSideEffectRead,
 				Name:        "Create Token",
 				Description: "Create a JWT token",
 				Properties: []domain.NodeProperty{
@@ -92,6 +93,7 @@ var (
 			{
 				ID:          "decode_token",
 				ActionType:  ActionDecodeToken,
+				SideEffect:  domain.ActionSideEffectRead,
 				Name:        "Decode Token",
 				Description: "Decode a JWT token",
 				Properties: []domain.NodeProperty{
@@ -23,6 +23,7 @@ var (
 				ID:          string(IntegrationActionType_SetField),
 				Name:        "Set Field",
 				ActionType:  IntegrationActionType_SetField,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Set or update a field value in the item. Supports nested paths (e.g., 'user.profile.name') and dynamic expressions (e.g., '{{item.original_field}}')",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
@@ -75,6 +76,7 @@ var (
 				ID:          string(IntegrationActionType_SetMultipleFields),
 				Name:        "Set Multiple Fields",
 				ActionType:  IntegrationActionType_SetMultipleFields,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Set or update multiple field values at once. Supports nested paths and dynamic expressions for each field",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
@@ -141,6 +143,7 @@ var (
 				ID:          string(IntegrationActionType_DeleteField),
 				Name:        "Delete Field",
 				ActionType:  IntegrationActionType_DeleteField,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Remove a field from the item. Supports nested paths (e.g., 'user.profile.name')",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
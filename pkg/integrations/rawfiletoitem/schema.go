@@ -64,6 +64,7 @@ var (
 				ID:          string(IntegrationActionType_ConvertRawFileToItem),
 				Name:        "Convert Raw File to Item",
 				ActionType:  IntegrationActionType_ConvertRawFileToItem,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Converts a raw file to items. Supports JSON, NDJSON, CSV, TSV, Excel (XLSX), XML, and YAML formats. Can auto-detect format or use a specified format.",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
@@ -22,6 +22,7 @@ var Schema = domain.Integration{
 			ID:          string(SleepActionType_Sleep),
 			Name:        "Sleep",
 			ActionType:  SleepActionType_Sleep,
+			SideEffect:  domain.ActionSideEffectRead,
 			Description: "Pause workflow until duration elapses",
 			Properties: []domain.NodeProperty{
 				{
@@ -87,7 +87,7 @@ func (i *SleepIntegration) Sleep(ctx context.Context, params domain.IntegrationI
 	if !ok {
 		return domain.IntegrationOutput{}, fmt.Errorf("sleep: workflow execution context not found")
 	}
-	execCtx.EmitSignal(domain.PauseSignal{
+	execCtx.EmitNodeSignal(params.NodeID, domain.PauseSignal{
 		WakeAt: time.Now().Add(duration),
 	})
 
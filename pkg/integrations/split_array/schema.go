@@ -21,6 +21,7 @@ var (
 				ID:          string(IntegrationActionType_SplitArray),
 				Name:        "Split Array",
 				ActionType:  IntegrationActionType_SplitArray,
+				SideEffect:  domain.ActionSideEffectRead,
 				Description: "Extract an array from a field path and output each array element as a separate item. Supports nested paths (e.g., 'data.results.items')",
 				SupportedContexts: []domain.ActionUsageContext{
 					domain.UsageContextWorkflow,
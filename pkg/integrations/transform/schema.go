@@ -38,6 +38,7 @@ var (
 				ID:                string(IntegrationActionType_InnerJoin),
 				Name:              "Merge Matching Items",
 				ActionType:        IntegrationActionType_InnerJoin,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				HandlesByContext:  twoInputOneOutputHandles,
 				Properties:        commonProperties,
@@ -47,6 +48,7 @@ var (
 				ID:                string(IntegrationActionType_OuterJoin),
 				Name:              "Merge and Keep Unmatched",
 				ActionType:        IntegrationActionType_OuterJoin,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				HandlesByContext:  twoInputOneOutputHandles,
 				Properties:        commonProperties,
@@ -56,6 +58,7 @@ var (
 				ID:                string(IntegrationActionType_LeftJoin),
 				Name:              "Merge and Keep Left",
 				ActionType:        IntegrationActionType_LeftJoin,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				HandlesByContext:  twoInputOneOutputHandles,
 				Properties:        commonProperties,
@@ -65,6 +68,7 @@ var (
 				ID:                string(IntegrationActionType_RightJoin),
 				Name:              "Merge and Keep Right",
 				ActionType:        IntegrationActionType_RightJoin,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				HandlesByContext:  twoInputOneOutputHandles,
 				Properties:        commonProperties,
@@ -74,6 +78,7 @@ var (
 				ID:                string(IntegrationActionType_ExcludeMatching),
 				Name:              "Exclude Matching Items",
 				ActionType:        IntegrationActionType_ExcludeMatching,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				HandlesByContext:  twoInputOneOutputHandles,
 				Properties:        commonProperties[:1],
@@ -83,6 +88,7 @@ var (
 				ID:                string(IntegrationActionType_Append),
 				Name:              "Append All",
 				ActionType:        IntegrationActionType_Append,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				Properties:        []domain.NodeProperty{},
 				HandlesByContext:  twoInputOneOutputHandles,
@@ -92,6 +98,7 @@ var (
 				ID:                string(IntegrationActionType_MergeByOrder),
 				Name:              "Merge by Order",
 				ActionType:        IntegrationActionType_MergeByOrder,
+				SideEffect:        domain.ActionSideEffectRead,
 				SupportedContexts: []domain.ActionUsageContext{domain.UsageContextWorkflow},
 				Properties: []domain.NodeProperty{
 					{
@@ -0,0 +1,204 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/flowbaker/flowbaker/pkg/clients/flowbaker"
+)
+
+var ErrNotAvailableOffline = errors.New("not available when running without a workspace")
+
+// Client is a flowbaker.ClientInterface that runs without the platform. Completed and
+// paused executions are kept in memory, events are dropped and every other call fails
+// with ErrNotAvailableOffline.
+type Client struct {
+	mutex sync.Mutex
+
+	completedExecutions []flowbaker.CompleteExecutionRequest
+	pausedExecutions    []flowbaker.PauseExecutionRequest
+}
+
+var _ flowbaker.ClientInterface = (*Client)(nil)
+
+func NewClient() *Client {
+	return &Client{}
+}
+
+func notAvailable(operation string) error {
+	return fmt.Errorf("%s: %w", operation, ErrNotAvailableOffline)
+}
+
+func (c *Client) CompleteWorkflowExecution(ctx context.Context, req *flowbaker.CompleteExecutionRequest) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.completedExecutions = append(c.completedExecutions, *req)
+
+	return nil
+}
+
+func (c *Client) PauseWorkflowExecution(ctx context.Context, req *flowbaker.PauseExecutionRequest) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pausedExecutions = append(c.pausedExecutions, *req)
+
+	return nil
+}
+
+func (c *Client) GetCompletedExecutions() []flowbaker.CompleteExecutionRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]flowbaker.CompleteExecutionRequest{}, c.completedExecutions...)
+}
+
+func (c *Client) GetPausedExecutions() []flowbaker.PauseExecutionRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]flowbaker.PauseExecutionRequest{}, c.pausedExecutions...)
+}
+
+func (c *Client) PublishExecutionEvent(ctx context.Context, workspaceID string, req *flowbaker.PublishEventRequest) error {
+	return nil
+}
+
+func (c *Client) PersistNodeExecution(ctx context.Context, workspaceID string, req *flowbaker.PersistNodeExecutionRequest) error {
+	return nil
+}
+
+func (c *Client) EnqueueTask(ctx context.Context, workspaceID string, req *flowbaker.EnqueueTaskRequest) (*flowbaker.EnqueueTaskResponse, error) {
+	return nil, notAvailable("EnqueueTask")
+}
+
+func (c *Client) EnqueueTaskAndWait(ctx context.Context, workspaceID string, req *flowbaker.EnqueueTaskAndWaitRequest) (*flowbaker.EnqueueTaskAndWaitResponse, error) {
+	return nil, notAvailable("EnqueueTaskAndWait")
+}
+
+func (c *Client) CreateExecutorRegistration(ctx context.Context, req *flowbaker.CreateExecutorRegistrationRequest) (*flowbaker.CreateExecutorRegistrationResponse, error) {
+	return nil, notAvailable("CreateExecutorRegistration")
+}
+
+func (c *Client) VerifyExecutorRegistration(ctx context.Context, workspaceID, code string) (*flowbaker.Executor, error) {
+	return nil, notAvailable("VerifyExecutorRegistration")
+}
+
+func (c *Client) GetWorkspaceExecutors(ctx context.Context, workspaceID string) ([]flowbaker.Executor, error) {
+	return nil, notAvailable("GetWorkspaceExecutors")
+}
+
+func (c *Client) GetWorkspace(ctx context.Context, workspaceID string) (*flowbaker.Workspace, error) {
+	return nil, notAvailable("GetWorkspace")
+}
+
+func (c *Client) GetWorkspaces(ctx context.Context) ([]flowbaker.Workspace, error) {
+	return nil, notAvailable("GetWorkspaces")
+}
+
+func (c *Client) GetCredential(ctx context.Context, workspaceID, credentialID string) (*flowbaker.EncryptedCredential, error) {
+	return nil, notAvailable("GetCredential")
+}
+
+func (c *Client) GetFullCredential(ctx context.Context, workspaceID, credentialID string) (*flowbaker.EncryptedFullCredential, error) {
+	return nil, notAvailable("GetFullCredential")
+}
+
+func (c *Client) BindParametersToStruct(ctx context.Context, workspaceID string, item any, nodeSettings map[string]any) ([]byte, error) {
+	return nil, notAvailable("BindParametersToStruct")
+}
+
+func (c *Client) GetExecutionFile(ctx context.Context, req flowbaker.GetExecutionFileRequest) (flowbaker.ExecutionWorkspaceFile, error) {
+	return flowbaker.ExecutionWorkspaceFile{}, notAvailable("GetExecutionFile")
+}
+
+func (c *Client) UploadFile(ctx context.Context, req *flowbaker.UploadFileRequest) (*flowbaker.UploadFileResponse, error) {
+	return nil, notAvailable("UploadFile")
+}
+
+func (c *Client) StreamFile(ctx context.Context, req *flowbaker.StreamFileRequest) (*flowbaker.StreamFileResponse, error) {
+	return nil, notAvailable("StreamFile")
+}
+
+func (c *Client) GetFileReader(ctx context.Context, req *flowbaker.GetFileReaderRequest) (*flowbaker.GetFileReaderResult, error) {
+	return nil, notAvailable("GetFileReader")
+}
+
+func (c *Client) GetFileInfo(ctx context.Context, req *flowbaker.GetFileInfoRequest) (*flowbaker.GetFileInfoResponse, error) {
+	return nil, notAvailable("GetFileInfo")
+}
+
+func (c *Client) CreateFileWriter(ctx context.Context, req *flowbaker.CreateFileWriterRequest) (*flowbaker.CreateFileWriterResult, error) {
+	return nil, notAvailable("CreateFileWriter")
+}
+
+func (c *Client) PersistFile(ctx context.Context, req *flowbaker.PersistFileRequest) (*flowbaker.PersistFileResponse, error) {
+	return nil, notAvailable("PersistFile")
+}
+
+func (c *Client) ListWorkspaceFiles(ctx context.Context, req *flowbaker.ListWorkspaceFilesRequest) (*flowbaker.ListWorkspaceFilesResponse, error) {
+	return nil, notAvailable("ListWorkspaceFiles")
+}
+
+func (c *Client) DeleteFile(ctx context.Context, req *flowbaker.DeleteFileRequest) (*flowbaker.DeleteFileResponse, error) {
+	return nil, notAvailable("DeleteFile")
+}
+
+func (c *Client) ListFolders(ctx context.Context, req *flowbaker.ListFoldersRequest) (*flowbaker.ListFoldersResponse, error) {
+	return nil, notAvailable("ListFolders")
+}
+
+func (c *Client) GetOAuthAccount(ctx context.Context, workspaceID, oauthAccountID string) (*flowbaker.GetOAuthAccountResponse, error) {
+	return nil, notAvailable("GetOAuthAccount")
+}
+
+func (c *Client) UpdateOAuthAccountMetadata(ctx context.Context, workspaceID, oauthAccountID string, req *flowbaker.UpdateOAuthAccountMetadataRequest) (*flowbaker.UpdateOAuthAccountMetadataResponse, error) {
+	return nil, notAvailable("UpdateOAuthAccountMetadata")
+}
+
+func (c *Client) GetIntegrations(ctx context.Context) ([]byte, error) {
+	return nil, notAvailable("GetIntegrations")
+}
+
+func (c *Client) GetIntegration(ctx context.Context, integrationType string) ([]byte, error) {
+	return nil, notAvailable("GetIntegration")
+}
+
+func (c *Client) GetWorkspaceKnowledges(ctx context.Context, workspaceID string) ([]byte, error) {
+	return nil, notAvailable("GetWorkspaceKnowledges")
+}
+
+func (c *Client) GetKnowledge(ctx context.Context, workspaceID, knowledgeID string) ([]byte, error) {
+	return nil, notAvailable("GetKnowledge")
+}
+
+func (c *Client) GetKnowledgeFiles(ctx context.Context, workspaceID, knowledgeID string) ([]byte, error) {
+	return nil, notAvailable("GetKnowledgeFiles")
+}
+
+func (c *Client) GetKnowledgeFile(ctx context.Context, workspaceID, knowledgeID, fileID string) ([]byte, error) {
+	return nil, notAvailable("GetKnowledgeFile")
+}
+
+func (c *Client) SearchKnowledge(ctx context.Context, workspaceID, knowledgeID string, req *flowbaker.SearchKnowledgeRequest) ([]byte, error) {
+	return nil, notAvailable("SearchKnowledge")
+}
+
+func (c *Client) SaveAgentConversation(ctx context.Context, workspaceID string, conversation *flowbaker.AgentConversation) (*flowbaker.SaveAgentConversationResponse, error) {
+	return nil, notAvailable("SaveAgentConversation")
+}
+
+func (c *Client) GetAgentConversation(ctx context.Context, req *flowbaker.GetAgentConversationRequest) (*flowbaker.GetAgentConversationResponse, error) {
+	return nil, notAvailable("GetAgentConversation")
+}
+
+func (c *Client) GetRoutes(ctx context.Context, req flowbaker.GetRoutesRequest) (flowbaker.GetRoutesResponse, error) {
+	return flowbaker.GetRoutesResponse{}, notAvailable("GetRoutes")
+}
+
+func (c *Client) ClassifyContent(ctx context.Context, workspaceID string, req *flowbaker.ClassifyContentRequest) (*flowbaker.ClassifyContentResponse, error) {
+	return nil, notAvailable("ClassifyContent")
+}
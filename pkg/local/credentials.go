@@ -0,0 +1,233 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+// CredentialEnvPrefix is the prefix of environment variables holding a credential
+// payload as JSON, e.g. FLOWBAKER_CREDENTIAL_SLACK_PROD for the credential "slack-prod".
+const CredentialEnvPrefix = "FLOWBAKER_CREDENTIAL_"
+
+var (
+	ErrCredentialNotFound   = errors.New("credential not found")
+	ErrOAuthAccountNotFound = errors.New("oauth account not found")
+)
+
+// CredentialsFile is the format of the local credentials file, in JSON or YAML.
+type CredentialsFile struct {
+	Credentials   map[string]LocalCredential   `json:"credentials"`
+	OAuthAccounts map[string]LocalOAuthAccount `json:"oauth_accounts"`
+}
+
+type LocalCredential struct {
+	Name            string         `json:"name"`
+	Type            string         `json:"type"`
+	IntegrationType string         `json:"integration_type"`
+	OAuthAccountID  string         `json:"oauth_account_id"`
+	Payload         map[string]any `json:"payload"`
+}
+
+type LocalOAuthAccount struct {
+	Name      string         `json:"name"`
+	OAuthType string         `json:"oauth_type"`
+	ClientID  string         `json:"client_id"`
+	Metadata  map[string]any `json:"metadata"`
+}
+
+// CredentialManager is a domain.ExecutorCredentialManager that serves credentials from
+// a local file and the environment instead of the platform.
+type CredentialManager struct {
+	mutex sync.RWMutex
+
+	credentials   map[string]LocalCredential
+	oauthAccounts map[string]LocalOAuthAccount
+}
+
+var _ domain.ExecutorCredentialManager = (*CredentialManager)(nil)
+
+type NewCredentialManagerParams struct {
+	// Path of the credentials file, optional.
+	Path string
+	// Environ holds the environment in "key=value" form, see os.Environ.
+	Environ []string
+}
+
+func NewCredentialManager(params NewCredentialManagerParams) (*CredentialManager, error) {
+	credentialsFile := CredentialsFile{}
+
+	if params.Path != "" {
+		data, err := os.ReadFile(params.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+
+		if err := UnmarshalJSONOrYAML(data, &credentialsFile); err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %s: %w", params.Path, err)
+		}
+	}
+
+	credentials := map[string]LocalCredential{}
+	maps.Copy(credentials, credentialsFile.Credentials)
+
+	oauthAccounts := map[string]LocalOAuthAccount{}
+	maps.Copy(oauthAccounts, credentialsFile.OAuthAccounts)
+
+	envPayloads, err := credentialPayloadsFromEnviron(params.Environ)
+	if err != nil {
+		return nil, err
+	}
+
+	for envKey, payload := range envPayloads {
+		credentialID := envKey
+
+		for id := range credentials {
+			if credentialEnvKey(id) == envKey {
+				credentialID = id
+			}
+		}
+
+		credential := credentials[credentialID]
+		credential.Payload = payload
+
+		credentials[credentialID] = credential
+	}
+
+	return &CredentialManager{
+		credentials:   credentials,
+		oauthAccounts: oauthAccounts,
+	}, nil
+}
+
+func (m *CredentialManager) getCredential(credentialID string) (LocalCredential, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	credential, ok := m.credentials[credentialID]
+	if !ok {
+		credential, ok = m.credentials[credentialEnvKey(credentialID)]
+	}
+
+	if !ok {
+		return LocalCredential{}, fmt.Errorf("%w: %s, add it to the credentials file or set %s%s", ErrCredentialNotFound, credentialID, CredentialEnvPrefix, credentialEnvKey(credentialID))
+	}
+
+	return credential, nil
+}
+
+func (m *CredentialManager) GetDecryptedCredential(ctx context.Context, credentialID string) ([]byte, error) {
+	credential, err := m.getCredential(credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := credential.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	return json.Marshal(payload)
+}
+
+func (m *CredentialManager) GetFullCredential(ctx context.Context, credentialID string) (domain.Credential, error) {
+	credential, err := m.getCredential(credentialID)
+	if err != nil {
+		return domain.Credential{}, err
+	}
+
+	credentialType := domain.CredentialType(credential.Type)
+	if credentialType == "" {
+		credentialType = domain.CredentialTypeDefault
+	}
+
+	return domain.Credential{
+		ID:               credentialID,
+		Name:             credential.Name,
+		Type:             credentialType,
+		IntegrationType:  domain.IntegrationType(credential.IntegrationType),
+		DecryptedPayload: credential.Payload,
+		OAuthAccountID:   credential.OAuthAccountID,
+	}, nil
+}
+
+func (m *CredentialManager) GetOAuthAccount(ctx context.Context, oauthAccountID string) (domain.OAuthAccount, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	account, ok := m.oauthAccounts[oauthAccountID]
+	if !ok {
+		return domain.OAuthAccount{}, fmt.Errorf("%w: %s", ErrOAuthAccountNotFound, oauthAccountID)
+	}
+
+	return domain.OAuthAccount{
+		ID:        oauthAccountID,
+		OAuthName: account.Name,
+		OAuthType: domain.OAuthType(account.OAuthType),
+		Metadata:  maps.Clone(account.Metadata),
+		ClientID:  account.ClientID,
+	}, nil
+}
+
+// UpdateOAuthAccountMetadata keeps the metadata in memory for the rest of the run, the
+// credentials file is left untouched.
+func (m *CredentialManager) UpdateOAuthAccountMetadata(ctx context.Context, oauthAccountID string, metadata map[string]interface{}) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	account, ok := m.oauthAccounts[oauthAccountID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOAuthAccountNotFound, oauthAccountID)
+	}
+
+	account.Metadata = metadata
+	m.oauthAccounts[oauthAccountID] = account
+
+	return nil
+}
+
+func credentialPayloadsFromEnviron(environ []string) (map[string]map[string]any, error) {
+	payloads := map[string]map[string]any{}
+
+	for _, entry := range environ {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, CredentialEnvPrefix) {
+			continue
+		}
+
+		envKey := strings.TrimPrefix(key, CredentialEnvPrefix)
+		if envKey == "" {
+			continue
+		}
+
+		payload := map[string]any{}
+		if err := json.Unmarshal([]byte(value), &payload); err != nil {
+			return nil, fmt.Errorf("%s must hold a JSON object: %w", key, err)
+		}
+
+		payloads[envKey] = payload
+	}
+
+	return payloads, nil
+}
+
+// credentialEnvKey turns a credential ID into the suffix of its environment variable.
+func credentialEnvKey(credentialID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, credentialID)
+}
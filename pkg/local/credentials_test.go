@@ -0,0 +1,74 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialManager_FileAndEnviron(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+
+	credentialsYAML := `
+credentials:
+  slack-prod:
+    name: Slack
+    integration_type: slack
+    payload:
+      token: from-file
+  github:
+    payload:
+      token: github-token
+`
+	if err := os.WriteFile(path, []byte(credentialsYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := NewCredentialManager(NewCredentialManagerParams{
+		Path: path,
+		Environ: []string{
+			"FLOWBAKER_CREDENTIAL_SLACK_PROD={\"token\":\"from-env\"}",
+			"FLOWBAKER_CREDENTIAL_OPENAI={\"api_key\":\"sk-test\"}",
+			"UNRELATED=value",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewCredentialManager() error = %v", err)
+	}
+
+	tests := []struct {
+		credentialID string
+		want         string
+	}{
+		{credentialID: "slack-prod", want: `{"token":"from-env"}`},
+		{credentialID: "github", want: `{"token":"github-token"}`},
+		{credentialID: "openai", want: `{"api_key":"sk-test"}`},
+	}
+
+	for _, tt := range tests {
+		payload, err := manager.GetDecryptedCredential(context.Background(), tt.credentialID)
+		if err != nil {
+			t.Errorf("GetDecryptedCredential(%q) error = %v", tt.credentialID, err)
+			continue
+		}
+
+		if string(payload) != tt.want {
+			t.Errorf("GetDecryptedCredential(%q) = %s, want %s", tt.credentialID, payload, tt.want)
+		}
+	}
+
+	credential, err := manager.GetFullCredential(context.Background(), "slack-prod")
+	if err != nil {
+		t.Fatalf("GetFullCredential() error = %v", err)
+	}
+
+	if credential.Name != "Slack" || credential.IntegrationType != "slack" {
+		t.Errorf("GetFullCredential() = %+v, want metadata from the file", credential)
+	}
+
+	if _, err := manager.GetDecryptedCredential(context.Background(), "missing"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("GetDecryptedCredential(missing) error = %v, want %v", err, ErrCredentialNotFound)
+	}
+}
@@ -0,0 +1,94 @@
+package local
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+
+	"github.com/rs/xid"
+)
+
+// Runner executes workflows through a WorkflowExecutorService that was built with the
+// local Client, so no execution reaches the platform.
+type Runner struct {
+	executorService executor.WorkflowExecutorService
+	client          *Client
+}
+
+type RunnerDependencies struct {
+	ExecutorService executor.WorkflowExecutorService
+	Client          *Client
+}
+
+func NewRunner(deps RunnerDependencies) *Runner {
+	return &Runner{
+		executorService: deps.ExecutorService,
+		client:          deps.Client,
+	}
+}
+
+type RunParams struct {
+	Workflow domain.Workflow
+	// TriggerNodeID may be empty when the workflow has a single trigger node.
+	TriggerNodeID string
+	InputJSON     []byte
+	// DryRun stubs the write actions of the workflow, see executor.ExecuteParams.
+	DryRun bool
+}
+
+type RunResult struct {
+	ExecutionID   string
+	TriggerNodeID string
+	IsPaused      bool
+
+	executor.ExecutionResult
+}
+
+// GetFailedEntries returns the history entries of the nodes that failed.
+func (r RunResult) GetFailedEntries() []domain.NodeExecutionEntry {
+	failedEntries := []domain.NodeExecutionEntry{}
+
+	for _, entry := range r.NodeExecutionResults {
+		if entry.EventType == domain.NodeFailed {
+			failedEntries = append(failedEntries, entry)
+		}
+	}
+
+	return failedEntries
+}
+
+func (r *Runner) Run(ctx context.Context, params RunParams) (RunResult, error) {
+	triggerNode, err := FindTriggerNode(params.Workflow, params.TriggerNodeID)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	inputJSON := params.InputJSON
+	if len(inputJSON) == 0 {
+		inputJSON = []byte("{}")
+	}
+
+	executionID := xid.New().String()
+	pausedBefore := len(r.client.GetPausedExecutions())
+
+	result, err := r.executorService.Execute(ctx, executor.ExecuteParams{
+		ExecutionID:  executionID,
+		Workflow:     params.Workflow,
+		EventName:    triggerNode.ID,
+		PayloadJSON:  string(inputJSON),
+		EnableEvents: false,
+		DryRun:       params.DryRun,
+	})
+	if err != nil {
+		return RunResult{}, fmt.Errorf("failed to execute workflow: %w", err)
+	}
+
+	return RunResult{
+		ExecutionID:     executionID,
+		TriggerNodeID:   triggerNode.ID,
+		IsPaused:        len(r.client.GetPausedExecutions()) > pausedBefore,
+		ExecutionResult: result,
+	}, nil
+}
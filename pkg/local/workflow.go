@@ -0,0 +1,158 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	executortypes "github.com/flowbaker/flowbaker/pkg/clients/flowbaker-executor"
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/mappers"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultWorkspaceID is used for workflows whose definition has no workspace.
+	DefaultWorkspaceID = "local"
+	// StdinPath reads a file argument from standard input instead.
+	StdinPath = "-"
+)
+
+var (
+	ErrTriggerNodeNotFound  = errors.New("trigger node not found")
+	ErrAmbiguousTriggerNode = errors.New("workflow has more than one trigger node")
+)
+
+// UnmarshalJSONOrYAML decodes JSON or YAML into v using the JSON field names of v.
+func UnmarshalJSONOrYAML(data []byte, v any) error {
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return json.Unmarshal(trimmed, v)
+	}
+
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	documentJSON, err := json.Marshal(stringifyYAMLKeys(document))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(documentJSON, v)
+}
+
+// stringifyYAMLKeys converts the map[any]any yaml.v3 produces for mappings with
+// non-string keys, e.g. output indices, so that the document can be encoded as JSON.
+func stringifyYAMLKeys(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = stringifyYAMLKeys(item)
+		}
+
+		return converted
+	case map[string]any:
+		for key, item := range v {
+			v[key] = stringifyYAMLKeys(item)
+		}
+
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = stringifyYAMLKeys(item)
+		}
+
+		return v
+	default:
+		return value
+	}
+}
+
+// LoadWorkflow reads a workflow definition in the format the platform sends to
+// executors, from a JSON or YAML file.
+func LoadWorkflow(path string) (domain.Workflow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Workflow{}, fmt.Errorf("failed to read workflow file: %w", err)
+	}
+
+	var workflow executortypes.Workflow
+	if err := UnmarshalJSONOrYAML(data, &workflow); err != nil {
+		return domain.Workflow{}, fmt.Errorf("failed to parse workflow file %s: %w", path, err)
+	}
+
+	if workflow.WorkspaceID == "" {
+		workflow.WorkspaceID = DefaultWorkspaceID
+	}
+
+	return mappers.ExecutorWorkflowToDomain(&workflow), nil
+}
+
+// ReadInput reads the trigger input from a JSON or YAML file, or from stdin when the
+// path is StdinPath, and returns it as JSON. An empty path gives an empty object.
+func ReadInput(path string, stdin io.Reader) ([]byte, error) {
+	var data []byte
+	var err error
+
+	switch path {
+	case "":
+		return []byte("{}"), nil
+	case StdinPath:
+		data, err = io.ReadAll(stdin)
+	default:
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return []byte("{}"), nil
+	}
+
+	var input any
+	if err := UnmarshalJSONOrYAML(data, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	return json.Marshal(input)
+}
+
+// FindTriggerNode returns the trigger node with the given ID, or the only trigger node
+// of the workflow when nodeID is empty.
+func FindTriggerNode(workflow domain.Workflow, nodeID string) (domain.WorkflowNode, error) {
+	if nodeID != "" {
+		node, ok := workflow.GetNodeByID(nodeID)
+		if !ok || node.Type != domain.NodeTypeTrigger {
+			return domain.WorkflowNode{}, fmt.Errorf("%w: %s", ErrTriggerNodeNotFound, nodeID)
+		}
+
+		return node, nil
+	}
+
+	triggers := []domain.WorkflowNode{}
+
+	for _, node := range workflow.Nodes {
+		if node.Type == domain.NodeTypeTrigger {
+			triggers = append(triggers, node)
+		}
+	}
+
+	switch len(triggers) {
+	case 0:
+		return domain.WorkflowNode{}, ErrTriggerNodeNotFound
+	case 1:
+		return triggers[0], nil
+	default:
+		return domain.WorkflowNode{}, fmt.Errorf("%w, the trigger node ID is required", ErrAmbiguousTriggerNode)
+	}
+}
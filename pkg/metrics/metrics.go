@@ -0,0 +1,425 @@
+// Package metrics keeps counters, histograms and gauges in memory and writes them in
+// the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDurationBuckets are upper bounds in seconds suited for node and workflow
+// durations, from quick transformations up to long running API calls.
+var DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds the metrics served by an exporter.
+type Registry struct {
+	collectors []collector
+	limits     seriesLimits
+	mutex      sync.Mutex
+}
+
+type RegistryOption func(r *Registry)
+
+// WithSeriesTTL drops the series that were not updated for ttl when the metrics are
+// written, so that label values that stopped occurring do not pile up.
+func WithSeriesTTL(ttl time.Duration) RegistryOption {
+	return func(r *Registry) {
+		r.limits.ttl = ttl
+	}
+}
+
+// WithMaxSeries limits the number of series of each metric, the series updated least
+// recently is dropped to make room for a new one.
+func WithMaxSeries(maxSeries int) RegistryOption {
+	return func(r *Registry) {
+		r.limits.maxSeries = maxSeries
+	}
+}
+
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		collectors: []collector{},
+		limits:     seriesLimits{now: time.Now},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *Registry) register(c collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteText writes all registered metrics in the Prometheus text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mutex.Lock()
+	collectors := append([]collector{}, r.collectors...)
+	r.mutex.Unlock()
+
+	writer := bufio.NewWriter(w)
+
+	for _, c := range collectors {
+		c.write(writer)
+	}
+
+	return writer.Flush()
+}
+
+// ContentType is the content type of the output of WriteText.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// seriesLimits bound the series of a metric, zero values are unlimited.
+type seriesLimits struct {
+	ttl       time.Duration
+	maxSeries int
+	now       func() time.Time
+}
+
+type series interface {
+	lastUpdated() time.Time
+}
+
+// makeRoom drops the series updated least recently when values is at the limit, it is
+// called before a series is added.
+func makeRoom[V series](limits seriesLimits, values map[string]V) {
+	if limits.maxSeries <= 0 || len(values) < limits.maxSeries {
+		return
+	}
+
+	oldestKey := ""
+	var oldest time.Time
+
+	for key, v := range values {
+		if oldestKey == "" || v.lastUpdated().Before(oldest) {
+			oldestKey, oldest = key, v.lastUpdated()
+		}
+	}
+
+	delete(values, oldestKey)
+}
+
+// dropExpired removes the series that were not updated within the TTL.
+func dropExpired[V series](limits seriesLimits, values map[string]V) {
+	if limits.ttl <= 0 {
+		return
+	}
+
+	expiredBefore := limits.now().Add(-limits.ttl)
+
+	for key, v := range values {
+		if v.lastUpdated().Before(expiredBefore) {
+			delete(values, key)
+		}
+	}
+}
+
+type metricDesc struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+}
+
+func (d metricDesc) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.metricType)
+}
+
+func (d metricDesc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+
+	return strings.Join(labelValues, "\xff")
+}
+
+// CounterVec is a set of counters partitioned by label values.
+type CounterVec struct {
+	desc   metricDesc
+	limits seriesLimits
+	values map[string]*counterValue
+	mutex  sync.Mutex
+}
+
+type counterValue struct {
+	labelValues []string
+	value       float64
+	updatedAt   time.Time
+}
+
+func (v *counterValue) lastUpdated() time.Time {
+	return v.updatedAt
+}
+
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   metricDesc{name: name, help: help, metricType: "counter", labelNames: labelNames},
+		limits: r.limits,
+		values: map[string]*counterValue{},
+	}
+
+	r.register(c)
+
+	return c
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter by value, negative values are ignored.
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+
+	key := c.desc.key(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	v, ok := c.values[key]
+	if !ok {
+		makeRoom(c.limits, c.values)
+
+		v = &counterValue{labelValues: append([]string{}, labelValues...)}
+		c.values[key] = v
+	}
+
+	v.value += value
+	v.updatedAt = c.limits.now()
+}
+
+// Value returns the current value of the counter.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	key := c.desc.key(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if v, ok := c.values[key]; ok {
+		return v.value
+	}
+
+	return 0
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	dropExpired(c.limits, c.values)
+
+	c.desc.writeHeader(w)
+
+	for _, key := range sortedKeys(c.values) {
+		v := c.values[key]
+		writeSample(w, c.desc.name, c.desc.labelNames, v.labelValues, "", v.value)
+	}
+}
+
+// HistogramVec is a set of histograms partitioned by label values.
+type HistogramVec struct {
+	desc    metricDesc
+	limits  seriesLimits
+	buckets []float64
+	values  map[string]*histogramValue
+	mutex   sync.Mutex
+}
+
+type histogramValue struct {
+	labelValues  []string
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	updatedAt    time.Time
+}
+
+func (v *histogramValue) lastUpdated() time.Time {
+	return v.updatedAt
+}
+
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sortedBuckets := append([]float64{}, buckets...)
+	sort.Float64s(sortedBuckets)
+
+	h := &HistogramVec{
+		desc:    metricDesc{name: name, help: help, metricType: "histogram", labelNames: labelNames},
+		limits:  r.limits,
+		buckets: sortedBuckets,
+		values:  map[string]*histogramValue{},
+	}
+
+	r.register(h)
+
+	return h
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.desc.key(labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	v, ok := h.values[key]
+	if !ok {
+		makeRoom(h.limits, h.values)
+
+		v = &histogramValue{
+			labelValues:  append([]string{}, labelValues...),
+			bucketCounts: make([]uint64, len(h.buckets)),
+		}
+		h.values[key] = v
+	}
+
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			v.bucketCounts[i]++
+		}
+	}
+
+	v.count++
+	v.sum += value
+	v.updatedAt = h.limits.now()
+}
+
+// Count returns the number of observations of the histogram.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	key := h.desc.key(labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if v, ok := h.values[key]; ok {
+		return v.count
+	}
+
+	return 0
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	dropExpired(h.limits, h.values)
+
+	h.desc.writeHeader(w)
+
+	bucketLabelNames := append(append([]string{}, h.desc.labelNames...), "le")
+
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+
+		for i, upperBound := range h.buckets {
+			labelValues := append(append([]string{}, v.labelValues...), formatFloat(upperBound))
+			writeSample(w, h.desc.name, bucketLabelNames, labelValues, "_bucket", float64(v.bucketCounts[i]))
+		}
+
+		labelValues := append(append([]string{}, v.labelValues...), "+Inf")
+		writeSample(w, h.desc.name, bucketLabelNames, labelValues, "_bucket", float64(v.count))
+		writeSample(w, h.desc.name, h.desc.labelNames, v.labelValues, "_sum", v.sum)
+		writeSample(w, h.desc.name, h.desc.labelNames, v.labelValues, "_count", float64(v.count))
+	}
+}
+
+// GaugeFunc is a gauge whose value is read when the metrics are written.
+type GaugeFunc struct {
+	desc metricDesc
+	fn   func() float64
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{
+		desc: metricDesc{name: name, help: help, metricType: "gauge"},
+		fn:   fn,
+	}
+
+	r.register(g)
+
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.desc.writeHeader(w)
+	writeSample(w, g.desc.name, nil, nil, "", g.fn())
+}
+
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, suffix string, value float64) {
+	w.WriteString(name)
+	w.WriteString(suffix)
+
+	if len(labelNames) > 0 {
+		w.WriteByte('{')
+
+		for i, labelName := range labelNames {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+
+			w.WriteString(labelName)
+			w.WriteString(`="`)
+			w.WriteString(escapeLabelValue(labelValues[i]))
+			w.WriteByte('"')
+		}
+
+		w.WriteByte('}')
+	}
+
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	registry := NewRegistry()
+
+	requests := registry.NewCounterVec("requests_total", "Requests handled.", "route", "status")
+	requests.Inc("/health", "200")
+	requests.Add(2, "/health", "200")
+	requests.Inc(`/a"b`, "500")
+	requests.Add(-1, "/health", "200")
+
+	durations := registry.NewHistogramVec("request_duration_seconds", "Request durations.", []float64{1, 0.1}, "route")
+	durations.Observe(0.05, "/health")
+	durations.Observe(0.5, "/health")
+	durations.Observe(3, "/health")
+
+	registry.NewGaugeFunc("active_requests", "Requests in flight.", func() float64 { return 4 })
+
+	var output strings.Builder
+	if err := registry.WriteText(&output); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	want := `# HELP requests_total Requests handled.
+# TYPE requests_total counter
+requests_total{route="/a\"b",status="500"} 1
+requests_total{route="/health",status="200"} 3
+# HELP request_duration_seconds Request durations.
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{route="/health",le="0.1"} 1
+request_duration_seconds_bucket{route="/health",le="1"} 2
+request_duration_seconds_bucket{route="/health",le="+Inf"} 3
+request_duration_seconds_sum{route="/health"} 3.55
+request_duration_seconds_count{route="/health"} 3
+# HELP active_requests Requests in flight.
+# TYPE active_requests gauge
+active_requests 4
+`
+
+	if output.String() != want {
+		t.Errorf("WriteText() output =\n%s\nwant\n%s", output.String(), want)
+	}
+
+	if got := requests.Value("/health", "200"); got != 3 {
+		t.Errorf("Value() = %v, want 3", got)
+	}
+
+	if got := durations.Count("/health"); got != 3 {
+		t.Errorf("Count() = %v, want 3", got)
+	}
+}
+
+func TestRegistry_SeriesLimits(t *testing.T) {
+	now := time.Now()
+
+	registry := NewRegistry(WithSeriesTTL(time.Minute), WithMaxSeries(2))
+	registry.limits.now = func() time.Time { return now }
+
+	requests := registry.NewCounterVec("requests_total", "Requests handled.", "route")
+	durations := registry.NewHistogramVec("request_duration_seconds", "Request durations.", []float64{1}, "route")
+
+	requests.Inc("/a")
+	durations.Observe(0.5, "/a")
+
+	now = now.Add(30 * time.Second)
+	requests.Inc("/b")
+
+	now = now.Add(time.Second)
+	requests.Inc("/a")
+
+	// At the limit, the series updated least recently makes room.
+	now = now.Add(time.Second)
+	requests.Inc("/c")
+
+	if got := requests.Value("/b"); got != 0 {
+		t.Errorf("Value(/b) = %v, want the least recently updated series to be dropped", got)
+	}
+
+	if got := requests.Value("/a"); got != 2 {
+		t.Errorf("Value(/a) = %v, want 2", got)
+	}
+
+	// Series idle for longer than the TTL are dropped when written.
+	now = now.Add(45 * time.Second)
+
+	if err := registry.WriteText(io.Discard); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	if got := durations.Count("/a"); got != 0 {
+		t.Errorf("Count(/a) = %v, want the expired series to be dropped", got)
+	}
+
+	if got := requests.Value("/c"); got != 1 {
+		t.Errorf("Value(/c) = %v, want 1", got)
+	}
+}
@@ -0,0 +1,175 @@
+package workflowtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+type NodeStatus string
+
+const (
+	NodeStatusExecuted    NodeStatus = "executed"
+	NodeStatusFailed      NodeStatus = "failed"
+	NodeStatusNotExecuted NodeStatus = "not_executed"
+)
+
+// Expect holds the assertions on a node. Status and outputs are checked against the
+// last history entry of the node, so only the final run of a node in a loop counts.
+type Expect struct {
+	Status NodeStatus `json:"status"`
+	// Error must be contained in the error of a failed node.
+	Error   string               `json:"error"`
+	Outputs map[int]ExpectOutput `json:"outputs"`
+}
+
+type ExpectOutput struct {
+	Count *int `json:"count"`
+	// Items must equal the output items, compared by their JSON encoding.
+	Items []any `json:"items"`
+}
+
+// Failure is an assertion that did not hold.
+type Failure struct {
+	NodeID  string
+	Message string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s", f.NodeID, f.Message)
+}
+
+// CheckExpectations asserts the expectations against the history entries recorded by
+// the HistoryRecorder of an execution.
+func CheckExpectations(entries []domain.NodeExecutionEntry, expectations map[string]Expect) []Failure {
+	lastEntries := map[string]domain.NodeExecutionEntry{}
+
+	for _, entry := range entries {
+		if entry.EventType == domain.NodeExecuted || entry.EventType == domain.NodeFailed {
+			lastEntries[entry.NodeID] = entry
+		}
+	}
+
+	nodeIDs := make([]string, 0, len(expectations))
+	for nodeID := range expectations {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	sort.Strings(nodeIDs)
+
+	failures := []Failure{}
+
+	for _, nodeID := range nodeIDs {
+		entry, ok := lastEntries[nodeID]
+
+		for _, message := range checkNode(entry, ok, expectations[nodeID]) {
+			failures = append(failures, Failure{NodeID: nodeID, Message: message})
+		}
+	}
+
+	return failures
+}
+
+func checkNode(entry domain.NodeExecutionEntry, ran bool, expect Expect) []string {
+	status := expect.Status
+	if status == "" && (len(expect.Outputs) > 0 || expect.Error != "") {
+		status = NodeStatusExecuted
+		if expect.Error != "" {
+			status = NodeStatusFailed
+		}
+	}
+
+	actualStatus := NodeStatusNotExecuted
+	if ran {
+		actualStatus = NodeStatusExecuted
+		if entry.EventType == domain.NodeFailed {
+			actualStatus = NodeStatusFailed
+		}
+	}
+
+	if status != "" && status != actualStatus {
+		message := fmt.Sprintf("status is %s, want %s", actualStatus, status)
+		if actualStatus == NodeStatusFailed {
+			message += fmt.Sprintf(" (error: %s)", entry.Error)
+		}
+
+		return []string{message}
+	}
+
+	messages := []string{}
+
+	if expect.Error != "" && !strings.Contains(entry.Error, expect.Error) {
+		messages = append(messages, fmt.Sprintf("error is %q, want it to contain %q", entry.Error, expect.Error))
+	}
+
+	outputIndices := make([]int, 0, len(expect.Outputs))
+	for outputIndex := range expect.Outputs {
+		outputIndices = append(outputIndices, outputIndex)
+	}
+
+	sort.Ints(outputIndices)
+
+	for _, outputIndex := range outputIndices {
+		expectOutput := expect.Outputs[outputIndex]
+		items := entry.ItemsByOutputIndex[outputIndex].Items
+
+		if expectOutput.Count != nil && len(items) != *expectOutput.Count {
+			messages = append(messages, fmt.Sprintf("output %d has %d item(s), want %d", outputIndex, len(items), *expectOutput.Count))
+		}
+
+		if expectOutput.Items == nil {
+			continue
+		}
+
+		equal, err := jsonEqual(items, expectOutput.Items)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("output %d cannot be compared: %v", outputIndex, err))
+			continue
+		}
+
+		if !equal {
+			actual, _ := json.Marshal(items)
+			expected, _ := json.Marshal(expectOutput.Items)
+
+			messages = append(messages, fmt.Sprintf("output %d items are %s, want %s", outputIndex, actual, expected))
+		}
+	}
+
+	return messages
+}
+
+func jsonEqual(actual []domain.Item, expected []any) (bool, error) {
+	if len(actual) != len(expected) {
+		return false, nil
+	}
+
+	actualValue, err := normalizeJSON(actual)
+	if err != nil {
+		return false, err
+	}
+
+	expectedValue, err := normalizeJSON(expected)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(actualValue, expectedValue), nil
+}
+
+func normalizeJSON(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
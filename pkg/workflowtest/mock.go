@@ -0,0 +1,134 @@
+package workflowtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+)
+
+// NodeMock replaces the output of a node. Outputs are keyed by output index, a node
+// that is mocked with an Error fails as if its integration returned the error.
+type NodeMock struct {
+	Outputs map[int][]any `json:"outputs"`
+	Error   string        `json:"error"`
+}
+
+func (m NodeMock) output(nodeID string) (domain.IntegrationOutput, error) {
+	if m.Error != "" {
+		return domain.IntegrationOutput{}, errors.New(m.Error)
+	}
+
+	itemsByOutputIndex := domain.NodeItemsMap{}
+
+	for outputIndex, items := range m.Outputs {
+		outputItems := make([]domain.Item, 0, len(items))
+		for _, item := range items {
+			outputItems = append(outputItems, item)
+		}
+
+		itemsByOutputIndex[outputIndex] = domain.NodeItems{
+			FromNodeID: nodeID,
+			Items:      outputItems,
+		}
+	}
+
+	return domain.IntegrationOutput{
+		ItemsByOutputIndex: itemsByOutputIndex,
+	}, nil
+}
+
+// MockSelector is an IntegrationSelector that answers action nodes with mocked
+// outputs by node ID and hands every other node to the integration registered in
+// the wrapped selector.
+type MockSelector struct {
+	domain.IntegrationSelector
+
+	mutex sync.RWMutex
+	mocks map[string]NodeMock
+}
+
+var _ domain.IntegrationSelector = (*MockSelector)(nil)
+
+func NewMockSelector(selector domain.IntegrationSelector) *MockSelector {
+	return &MockSelector{
+		IntegrationSelector: selector,
+		mocks:               map[string]NodeMock{},
+	}
+}
+
+// SetMocks replaces the mocked nodes for the next executions.
+func (s *MockSelector) SetMocks(mocks map[string]NodeMock) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.mocks = map[string]NodeMock{}
+
+	for nodeID, mock := range mocks {
+		s.mocks[nodeID] = mock
+	}
+}
+
+func (s *MockSelector) getMock(nodeID string) (NodeMock, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	mock, ok := s.mocks[nodeID]
+	return mock, ok
+}
+
+func (s *MockSelector) SelectCreator(ctx context.Context, params domain.SelectIntegrationParams) (domain.IntegrationCreator, error) {
+	creator, err := s.IntegrationSelector.SelectCreator(ctx, params)
+
+	return &mockCreator{
+		selector:  s,
+		creator:   creator,
+		selectErr: err,
+	}, nil
+}
+
+// mockCreator defers creating the real integration until a node that is not mocked
+// runs, so mocked nodes need neither a registered integration nor credentials.
+type mockCreator struct {
+	selector  *MockSelector
+	creator   domain.IntegrationCreator
+	selectErr error
+}
+
+func (c *mockCreator) CreateIntegration(ctx context.Context, p domain.CreateIntegrationParams) (domain.IntegrationExecutor, error) {
+	return &mockExecutor{
+		creator: c,
+		params:  p,
+	}, nil
+}
+
+type mockExecutor struct {
+	creator *mockCreator
+	params  domain.CreateIntegrationParams
+
+	once                sync.Once
+	integrationExecutor domain.IntegrationExecutor
+	createErr           error
+}
+
+func (e *mockExecutor) Execute(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	if mock, ok := e.creator.selector.getMock(input.NodeID); ok {
+		return mock.output(input.NodeID)
+	}
+
+	e.once.Do(func() {
+		if e.creator.selectErr != nil {
+			e.createErr = e.creator.selectErr
+			return
+		}
+
+		e.integrationExecutor, e.createErr = e.creator.creator.CreateIntegration(ctx, e.params)
+	})
+
+	if e.createErr != nil {
+		return domain.IntegrationOutput{}, e.createErr
+	}
+
+	return e.integrationExecutor.Execute(ctx, input)
+}
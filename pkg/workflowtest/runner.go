@@ -0,0 +1,124 @@
+package workflowtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+// Runner executes test cases through a local.Runner whose executor service was built
+// with the MockSelector. Test cases share the selector, so they run one at a time.
+type Runner struct {
+	runner   *local.Runner
+	selector *MockSelector
+}
+
+type RunnerDependencies struct {
+	Runner   *local.Runner
+	Selector *MockSelector
+}
+
+func NewRunner(deps RunnerDependencies) *Runner {
+	return &Runner{
+		runner:   deps.Runner,
+		selector: deps.Selector,
+	}
+}
+
+type CaseResult struct {
+	Name     string
+	Failures []Failure
+	// Err is set when the test case could not be executed at all.
+	Err    error
+	Result local.RunResult
+}
+
+func (r CaseResult) Passed() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
+
+func (r *Runner) RunSuite(ctx context.Context, workflow domain.Workflow, suite Suite) []CaseResult {
+	results := make([]CaseResult, 0, len(suite.Tests))
+
+	for _, tc := range suite.Tests {
+		results = append(results, r.RunCase(ctx, workflow, tc))
+	}
+
+	return results
+}
+
+func (r *Runner) RunCase(ctx context.Context, workflow domain.Workflow, tc TestCase) CaseResult {
+	caseResult := CaseResult{Name: tc.Name}
+
+	if err := ValidateTestCase(workflow, tc); err != nil {
+		caseResult.Err = err
+		return caseResult
+	}
+
+	inputJSON, err := tc.InputJSON()
+	if err != nil {
+		caseResult.Err = fmt.Errorf("failed to encode input: %w", err)
+		return caseResult
+	}
+
+	r.selector.SetMocks(tc.Mocks)
+	defer r.selector.SetMocks(nil)
+
+	result, err := r.runner.Run(ctx, local.RunParams{
+		Workflow:      workflow,
+		TriggerNodeID: tc.Trigger,
+		InputJSON:     inputJSON,
+	})
+	if err != nil {
+		caseResult.Err = err
+		return caseResult
+	}
+
+	caseResult.Result = result
+	caseResult.Failures = CheckExpectations(result.NodeExecutionResults, tc.Expect)
+
+	// A failing node is a test failure unless the test case expects it.
+	for _, entry := range result.GetFailedEntries() {
+		if _, ok := tc.Expect[entry.NodeID]; ok {
+			continue
+		}
+
+		caseResult.Failures = append(caseResult.Failures, Failure{
+			NodeID:  entry.NodeID,
+			Message: fmt.Sprintf("failed unexpectedly: %s", entry.Error),
+		})
+	}
+
+	return caseResult
+}
+
+// ValidateTestCase checks that mocks and expectations refer to nodes of the workflow and
+// that only action nodes are mocked.
+func ValidateTestCase(workflow domain.Workflow, tc TestCase) error {
+	for nodeID := range tc.Mocks {
+		node, ok := workflow.GetNodeByID(nodeID)
+		if !ok {
+			return fmt.Errorf("%w: mocked node %s not found in workflow", ErrInvalidSuite, nodeID)
+		}
+
+		if node.Type != domain.NodeTypeAction {
+			return fmt.Errorf("%w: node %s cannot be mocked, only action nodes can", ErrInvalidSuite, nodeID)
+		}
+	}
+
+	for nodeID, expect := range tc.Expect {
+		if _, ok := workflow.GetNodeByID(nodeID); !ok {
+			return fmt.Errorf("%w: expected node %s not found in workflow", ErrInvalidSuite, nodeID)
+		}
+
+		switch expect.Status {
+		case "", NodeStatusExecuted, NodeStatusFailed, NodeStatusNotExecuted:
+		default:
+			return fmt.Errorf("%w: unknown status %q for node %s", ErrInvalidSuite, expect.Status, nodeID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package workflowtest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flowbaker/flowbaker/pkg/domain"
+	"github.com/flowbaker/flowbaker/pkg/domain/executor"
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+type integrationFunc func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error)
+
+func (f integrationFunc) CreateIntegration(ctx context.Context, p domain.CreateIntegrationParams) (domain.IntegrationExecutor, error) {
+	return f, nil
+}
+
+func (f integrationFunc) Execute(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+	return f(ctx, input)
+}
+
+const testWorkflowYAML = `
+id: wf_orders
+name: Orders
+nodes:
+  - id: trigger
+    type: trigger
+    integration_type: webhook
+  - id: fetch-order
+    type: action
+    integration_type: remote_api
+  - id: passthrough
+    type: action
+    integration_type: passthrough
+edges:
+  - source_node_id: trigger
+    target_node_id: fetch-order
+  - source_node_id: fetch-order
+    target_node_id: passthrough
+`
+
+const testSuiteYAML = `
+workflow: workflow.yaml
+tests:
+  - name: passes mocked order through
+    input: {order_id: 7}
+    mocks:
+      fetch-order:
+        outputs:
+          0: [{id: 7, status: paid}]
+    expect:
+      fetch-order:
+        outputs:
+          0:
+            count: 1
+      passthrough:
+        status: executed
+        outputs:
+          0:
+            items: [{id: 7, status: paid}]
+  - name: expects the mocked failure
+    mocks:
+      fetch-order:
+        error: order service unavailable
+    expect:
+      fetch-order:
+        status: failed
+        error: unavailable
+      passthrough:
+        status: not_executed
+  - name: reports wrong expectations
+    mocks:
+      fetch-order:
+        outputs:
+          0: [{id: 8}]
+    expect:
+      passthrough:
+        outputs:
+          0:
+            items: [{id: 7}]
+`
+
+func TestRunner_RunSuite(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "workflow.yaml"), []byte(testWorkflowYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	suitePath := filepath.Join(dir, "orders.test.yaml")
+	if err := os.WriteFile(suitePath, []byte(testSuiteYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	suite, err := LoadSuite(suitePath)
+	if err != nil {
+		t.Fatalf("LoadSuite() error = %v", err)
+	}
+
+	workflow, err := local.LoadWorkflow(suite.Workflow)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	selector := NewMockSelector(domain.NewIntegrationSelector())
+
+	// remote_api is not registered, so the test only passes if fetch-order is mocked.
+	selector.RegisterCreator("passthrough", integrationFunc(func(ctx context.Context, input domain.IntegrationInput) (domain.IntegrationOutput, error) {
+		return domain.IntegrationOutput{
+			ItemsByOutputIndex: domain.NewNodeItemsMap(0, input.NodeID, input.GetAllItems()),
+		}, nil
+	}))
+
+	client := local.NewClient()
+
+	runner := NewRunner(RunnerDependencies{
+		Runner: local.NewRunner(local.RunnerDependencies{
+			ExecutorService: executor.NewWorkflowExecutorService(executor.WorkflowExecutorServiceDependencies{
+				IntegrationSelector: selector,
+				FlowbakerClient:     client,
+			}),
+			Client: client,
+		}),
+		Selector: selector,
+	})
+
+	results := runner.RunSuite(context.Background(), workflow, suite)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	for _, result := range results[:2] {
+		if !result.Passed() {
+			t.Errorf("%q did not pass: err = %v, failures = %v", result.Name, result.Err, result.Failures)
+		}
+	}
+
+	failures := results[2].Failures
+	if len(failures) != 1 || failures[0].NodeID != "passthrough" || !strings.Contains(failures[0].Message, `want [{"id":7}]`) {
+		t.Errorf("failures = %v, want a mismatch of passthrough output", failures)
+	}
+}
+
+func TestValidateTestCase(t *testing.T) {
+	workflow := domain.Workflow{
+		Nodes: []domain.WorkflowNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger},
+			{ID: "action", Type: domain.NodeTypeAction},
+		},
+	}
+
+	tests := []TestCase{
+		{Mocks: map[string]NodeMock{"missing": {}}},
+		{Mocks: map[string]NodeMock{"trigger": {}}},
+		{Expect: map[string]Expect{"missing": {}}},
+		{Expect: map[string]Expect{"action": {Status: "skipped"}}},
+	}
+
+	for _, tc := range tests {
+		if err := ValidateTestCase(workflow, tc); !errors.Is(err, ErrInvalidSuite) {
+			t.Errorf("ValidateTestCase(%+v) error = %v, want %v", tc, err, ErrInvalidSuite)
+		}
+	}
+}
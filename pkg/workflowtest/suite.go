@@ -0,0 +1,91 @@
+package workflowtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/flowbaker/flowbaker/pkg/local"
+)
+
+var ErrInvalidSuite = errors.New("invalid test suite")
+
+// Suite is a declarative test file for a workflow, in JSON or YAML:
+//
+//	workflow: ./order-sync.yaml
+//	credentials: ./credentials.yaml
+//	tests:
+//	  - name: syncs paid orders
+//	    trigger: webhook-trigger
+//	    input: {status: paid, amount: 42}
+//	    mocks:
+//	      create-invoice:
+//	        outputs:
+//	          0: [{invoice_id: inv_1}]
+//	    expect:
+//	      notify-slack:
+//	        status: executed
+//	        outputs:
+//	          0:
+//	            count: 1
+//	      refund-order:
+//	        status: not_executed
+//
+// Paths are relative to the test file.
+type Suite struct {
+	Workflow    string     `json:"workflow"`
+	Credentials string     `json:"credentials"`
+	Tests       []TestCase `json:"tests"`
+}
+
+type TestCase struct {
+	Name string `json:"name"`
+	// Trigger is the ID of the trigger node, optional when the workflow has one.
+	Trigger string              `json:"trigger"`
+	Input   any                 `json:"input"`
+	Mocks   map[string]NodeMock `json:"mocks"`
+	Expect  map[string]Expect   `json:"expect"`
+}
+
+// LoadSuite reads a test file and resolves its paths relative to the file.
+func LoadSuite(path string) (Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Suite{}, fmt.Errorf("failed to read test file: %w", err)
+	}
+
+	var suite Suite
+	if err := local.UnmarshalJSONOrYAML(data, &suite); err != nil {
+		return Suite{}, fmt.Errorf("failed to parse test file %s: %w", path, err)
+	}
+
+	if suite.Workflow == "" {
+		return Suite{}, fmt.Errorf("%w: %s has no workflow", ErrInvalidSuite, path)
+	}
+
+	dir := filepath.Dir(path)
+
+	suite.Workflow = resolvePath(dir, suite.Workflow)
+	suite.Credentials = resolvePath(dir, suite.Credentials)
+
+	return suite, nil
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+// InputJSON returns the trigger input of the test case, an empty object when it has none.
+func (tc TestCase) InputJSON() ([]byte, error) {
+	if tc.Input == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(tc.Input)
+}